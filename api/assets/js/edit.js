@@ -14,7 +14,7 @@ document.getElementById('editListingForm').addEventListener('submit', async e =>
 
   const listingId = e.target.dataset.listingId;
   if (!listingId) {
-    $err.textContent = 'Не указан ID объявления';
+    $err.textContent = t('ui.listing.no_id');
     $err.style.display = 'block';
     return;
   }
@@ -27,13 +27,13 @@ document.getElementById('editListingForm').addEventListener('submit', async e =>
   const file = imageInput.files[0];
 
   if (!file) {
-    $err.textContent = 'Выберите картинку';
+    $err.textContent = t('ui.listing.choose_image');
     $err.style.display = 'block';
     return;
   }
 
   if (file.size > 5 * 1024 * 1024) {
-    $err.textContent = 'Размер картинки не должен превышать 5 МБ';
+    $err.textContent = t('ui.listing.image_too_large');
     $err.style.display = 'block';
     return;
   }
@@ -63,14 +63,14 @@ document.getElementById('editListingForm').addEventListener('submit', async e =>
 
       const result = await res.json();
       if (result.success) {
-        $ok.textContent = 'Объявление изменено!';
+        $ok.textContent = t('ui.listing.edited');
         $ok.style.display = 'block';
         setTimeout(() => window.location.href = '/', 1500);
       } else {
-        throw new Error(result.message || 'Ошибка изменения');
+        throw new Error(result.message || t('ui.listing.edit_failed'));
       }
     } catch (err) {
-      $err.textContent = err.message || 'Ошибка';
+      $err.textContent = err.message || t('ui.error');
       $err.style.display = 'block';
     }
   };
@@ -0,0 +1,15 @@
+// Строки интерфейса на языке страницы передаются сервером в window.I18N
+function t(key) {
+  return (window.I18N && window.I18N[key]) || key;
+}
+
+// Выбранный язык сохраняется в cookie и учитывается сервером при ответах и отрисовке страниц
+document.addEventListener('DOMContentLoaded', () => {
+  const langSelect = document.getElementById('langSelect');
+  if (!langSelect) return;
+
+  langSelect.onchange = () => {
+    document.cookie = `lang=${langSelect.value}; path=/; max-age=31536000; samesite=lax`;
+    window.location.reload();
+  };
+});
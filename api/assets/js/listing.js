@@ -13,13 +13,13 @@ document.getElementById('addListingForm').addEventListener('submit', async e =>
   const file = imageInput.files[0];
 
   if (!file) {
-    $err.textContent = 'Выберите картинку';
+    $err.textContent = t('ui.listing.choose_image');
     $err.style.display = 'block';
     return;
   }
 
   if (file.size > 5 * 1024 * 1024) {
-    $err.textContent = 'Размер картинки не должен превышать 5 МБ';
+    $err.textContent = t('ui.listing.image_too_large');
     $err.style.display = 'block';
     return;
   }
@@ -48,14 +48,14 @@ document.getElementById('addListingForm').addEventListener('submit', async e =>
 
       const result = await res.json();
       if (result.success) {
-        $ok.textContent = 'Объявление создано!';
+        $ok.textContent = t('ui.listing.created');
         $ok.style.display = 'block';
         setTimeout(() => window.location.href = '/', 1500);
       } else {
-        throw new Error(result.message || 'Ошибка создания');
+        throw new Error(result.message || t('ui.listing.create_failed'));
       }
     } catch (err) {
-      $err.textContent = err.message || 'Ошибка';
+      $err.textContent = err.message || t('ui.error');
       $err.style.display = 'block';
     }
   };
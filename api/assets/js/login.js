@@ -36,7 +36,7 @@ document.getElementById('loginForm').addEventListener('submit', async e => {
       localStorage.setItem('AuthToken', result.data.AuthToken);
       window.location.href = '/';
     } else {
-      throw new Error(result.message || 'Ошибка входа');
+      throw new Error(result.message || t('ui.login_failed'));
     }
   } catch (err) {
     $err.textContent = err.message || 'Unexpected error';
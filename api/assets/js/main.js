@@ -20,7 +20,7 @@ async function loadListings(page = 1) {
   const paginationDiv = document.getElementById('pagination');
 
   currentPage = page;
-  listingsDiv.innerHTML = 'Загрузка...';
+  listingsDiv.innerHTML = t('ui.loading');
   alertError.style.display = 'none';
 
   const sortField = document.getElementById('sortField').value;
@@ -55,18 +55,18 @@ async function loadListings(page = 1) {
 
     const listings = result.data.listings;
     if (listings.length === 0) {
-      listingsDiv.innerHTML = 'Нет объявлений.';
+      listingsDiv.innerHTML = t('ui.no_listings');
       paginationDiv.innerHTML = '';
       return;
     }
 
     listings.forEach(listing => {
       const div = document.createElement('div');
-      div.className = 'listing';
+      div.className = listing.promoted ? 'listing promoted' : 'listing';
 
       const ownerButtons = listing.is_yours ? `
-        <button class="edit-btn" data-id="${listing.id}">Редактировать</button>
-        <button class="delete-btn" data-id="${listing.id}">Удалить</button>
+        <button class="edit-btn" data-id="${listing.id}">${t('ui.edit_button')}</button>
+        <button class="delete-btn" data-id="${listing.id}">${t('ui.delete_button')}</button>
       ` : '';
 
       const liked = listing.is_liked;
@@ -77,13 +77,14 @@ async function loadListings(page = 1) {
       `;
 
       div.innerHTML = `
+        ${listing.promoted ? `<p class="promoted-label">${t('ui.listing.promoted')}</p>` : ''}
         <h3>${listing.title}</h3>
         <img src="${listing.image_url}" alt="image" style="max-width:200px;max-height:200px;">
         <p>${listing.description}</p>
-        <p>Адрес: ${listing.address}</p>
-        <p>Цена: ${listing.price}</p>
-        <p>Опубликовано: ${new Date(listing.created_at).toLocaleString()}</p>
-        <p>Автор: <a href="#" class="author-link" data-id="${listing.author_id}">${listing.author_login || listing.author_id}</a></p>
+        <p>${t('ui.listing.address')} ${listing.address}</p>
+        <p>${t('ui.listing.price')} ${listing.price}</p>
+        <p>${t('ui.listing.published')} ${new Date(listing.created_at).toLocaleString(document.documentElement.lang)}</p>
+        <p>${t('ui.listing.author')} <a href="#" class="author-link" data-id="${listing.author_id}">${listing.author_login || listing.author_id}</a></p>
         ${ownerButtons}
         <div>${likeButton}</div>
       `;
@@ -208,7 +209,7 @@ document.addEventListener('DOMContentLoaded', () => {
 
     if (e.target.matches('.delete-btn')) {
       const listingId = e.target.dataset.id;
-      if (confirm('Удалить объявление?')) {
+      if (confirm(t('ui.listing.confirm_delete'))) {
         const token = await getAuthToken();
         try {
           const res = await fetch('/api/listings/' + listingId, {
@@ -36,7 +36,7 @@ document.getElementById('registerForm').addEventListener('submit', async e => {
       localStorage.setItem('AuthToken', result.data.AuthToken);
       window.location.href = '/';
     } else {
-      throw new Error(result.message || 'Ошибка регистрации');
+      throw new Error(result.message || t('ui.register_failed'));
     }
   } catch (err) {
     $err.textContent = err.message || 'Unexpected error';
@@ -0,0 +1,223 @@
+package contentfilter
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// Типы правил фильтра
+const (
+	RuleStopWords = "stop_words" // Запрещённые слова и фразы
+	RuleRegex     = "regex"      // Произвольное регулярное выражение
+	RuleURL       = "url"        // Ссылки и доменные имена
+	RulePhone     = "phone"      // Номера телефонов
+)
+
+// Проверяемые поля объявления
+const (
+	FieldTitle       = "title"
+	FieldDescription = "description"
+	FieldAddress     = "address"
+)
+
+// Rule описывает правило фильтра в файле конфигурации
+type Rule struct {
+	Code    string   `mapstructure:"code"`    // Код ошибки, который получает клиент
+	Type    string   `mapstructure:"type"`    // Тип правила
+	Fields  []string `mapstructure:"fields"`  // Проверяемые поля, по умолчанию название и описание
+	Words   []string `mapstructure:"words"`   // Слова и фразы для stop_words
+	Pattern string   `mapstructure:"pattern"` // Регулярное выражение для regex
+	Message string   `mapstructure:"message"` // Сообщение для клиента
+}
+
+// Violation описывает нарушение правила фильтра
+type Violation struct {
+	Code    string // Код нарушенного правила
+	Field   string // Поле, в котором найдено нарушение
+	Match   string // Найденный фрагмент текста
+	Message string // Сообщение для клиента
+}
+
+// matcher возвращает найденный запрещённый фрагмент или пустую строку
+type matcher func(text string) string
+
+type compiledRule struct {
+	Rule
+	match matcher
+}
+
+var (
+	urlPattern   = regexp.MustCompile(`(?i)(?:https?://|www\.)\S+|[\p{L}\d-]+\.(?:ru|рф|su|com|net|org|info|biz|io|me)(?:[^\p{L}\d]|$)`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d\s\-().]{8,}\d`)
+)
+
+// Filter проверяет текст объявлений по правилам из файла и перечитывает их при изменении файла
+type Filter struct {
+	config *viper.Viper
+	rules  atomic.Pointer[[]compiledRule]
+}
+
+// New загружает правила из файла и начинает следить за его изменениями.
+// Если файл после изменения содержит ошибку, продолжают действовать прежние правила
+func New(path string) (*Filter, error) {
+	f := &Filter{config: viper.New()}
+	f.config.SetConfigFile(path)
+	if err := f.config.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	if err := f.reload(); err != nil {
+		return nil, err
+	}
+
+	f.config.OnConfigChange(func(e fsnotify.Event) {
+		if err := f.reload(); err != nil {
+			logger.Error(messages.ServiceContentFilter, messages.LogErrContentFilterReload, map[string]string{
+				messages.LogDetails:  err.Error(),
+				messages.LogFilename: e.Name,
+			})
+			return
+		}
+		logger.Info(messages.ServiceContentFilter, messages.LogStatusContentFilterReloaded, map[string]string{
+			messages.LogFilename: e.Name,
+			messages.LogCount:    fmt.Sprint(len(*f.rules.Load())),
+		})
+	})
+	f.config.WatchConfig()
+
+	return f, nil
+}
+
+// reload компилирует правила из прочитанного файла и атомарно заменяет текущие
+func (f *Filter) reload() error {
+	var rules []Rule
+	if err := f.config.UnmarshalKey("rules", &rules); err != nil {
+		return err
+	}
+
+	compiled := make([]compiledRule, 0, len(rules))
+	codes := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.Code == "" {
+			return fmt.Errorf("rule %d: code is required", i)
+		}
+		if codes[rule.Code] {
+			return fmt.Errorf("rule %q: duplicate code", rule.Code)
+		}
+		codes[rule.Code] = true
+
+		if len(rule.Fields) == 0 {
+			rule.Fields = []string{FieldTitle, FieldDescription}
+		}
+		for _, field := range rule.Fields {
+			if field != FieldTitle && field != FieldDescription && field != FieldAddress {
+				return fmt.Errorf("rule %q: unknown field %q", rule.Code, field)
+			}
+		}
+
+		match, err := compileMatcher(rule)
+		if err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Code, err)
+		}
+		compiled = append(compiled, compiledRule{Rule: rule, match: match})
+	}
+
+	f.rules.Store(&compiled)
+	return nil
+}
+
+func compileMatcher(rule Rule) (matcher, error) {
+	switch rule.Type {
+	case RuleStopWords:
+		if len(rule.Words) == 0 {
+			return nil, fmt.Errorf("words are required")
+		}
+		phrases := make([]string, 0, len(rule.Words))
+		for _, word := range rule.Words {
+			if phrase := normalize(word); phrase != " " {
+				phrases = append(phrases, phrase)
+			}
+		}
+		return func(text string) string {
+			text = normalize(text)
+			for _, phrase := range phrases {
+				if strings.Contains(text, phrase) {
+					return strings.TrimSpace(phrase)
+				}
+			}
+			return ""
+		}, nil
+	case RuleRegex:
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		return re.FindString, nil
+	case RuleURL:
+		return urlPattern.FindString, nil
+	case RulePhone:
+		return findPhone, nil
+	default:
+		return nil, fmt.Errorf("unknown type %q", rule.Type)
+	}
+}
+
+// normalize приводит текст к нижнему регистру и оставляет только слова, разделённые пробелами.
+// Результат обрамлён пробелами, чтобы фразы совпадали только по границам слов
+func normalize(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return " " + strings.ReplaceAll(strings.Join(words, " "), "ё", "е") + " "
+}
+
+// findPhone ищет номер телефона: российский из 10 или 11 цифр либо международный, начинающийся с +
+func findPhone(text string) string {
+	for _, candidate := range phonePattern.FindAllString(text, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if r >= '0' && r <= '9' {
+				return r
+			}
+			return -1
+		}, candidate)
+
+		switch {
+		case strings.HasPrefix(candidate, "+") && len(digits) >= 10 && len(digits) <= 15:
+			return candidate
+		case len(digits) == 11 && (digits[0] == '7' || digits[0] == '8'):
+			return candidate
+		case len(digits) == 10 && digits[0] == '9':
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Check проверяет поля объявления и возвращает первое нарушенное правило.
+// Фильтр без правил или nil ничего не запрещает
+func (f *Filter) Check(fields map[string]string) *Violation {
+	if f == nil {
+		return nil
+	}
+
+	for _, rule := range *f.rules.Load() {
+		for _, field := range rule.Fields {
+			if match := rule.match(fields[field]); match != "" {
+				return &Violation{
+					Code:    rule.Code,
+					Field:   field,
+					Match:   match,
+					Message: rule.Message,
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxAuctionDuration - максимальная длительность аукциона, совпадает с ограничением сервиса объявлений
+const maxAuctionDuration = 30 * 24 * time.Hour
+
+// auctionErrors сопоставляет ошибки аукционов и ставок с ответами клиенту
+var auctionErrors = []errorMapping{
+	{repo.ErrInvalidBid, http.StatusBadRequest, messages.LogErrInvalidBid, messages.ClientErrInvalidBid},
+	{repo.ErrAuctionNotFound, http.StatusNotFound, messages.LogErrAuctionNotFound, messages.ClientErrAuctionNotFound},
+	{repo.ErrBidForbidden, http.StatusForbidden, messages.LogErrBidForbidden, messages.ClientErrBidForbidden},
+	{repo.ErrAuctionClosed, http.StatusConflict, messages.LogErrAuctionClosed, messages.ClientErrAuctionClosed},
+	{repo.ErrBidLeading, http.StatusConflict, messages.LogErrBidLeading, messages.ClientErrBidLeading},
+	{repo.ErrBidConflict, http.StatusConflict, messages.LogErrBidConflict, messages.ClientErrBidConflict},
+}
+
+// validateAuctionSettings проверяет настройки аукциона нового объявления
+func validateAuctionSettings(settings *repo.AuctionSettings, now time.Time) *listingError {
+	valid := settings.StartPrice >= 0 && settings.StartPrice <= 100_000_000 &&
+		settings.MinIncrement > 0 && settings.MinIncrement <= 100_000_000 &&
+		settings.EndsAt.After(now) && settings.EndsAt.Sub(now) <= maxAuctionDuration
+	if !valid {
+		return &listingError{messages.ClientErrInvalidAuction, messages.LogErrInvalidAuction, map[string]string{
+			messages.LogAmount: strconv.Itoa(settings.StartPrice),
+		}}
+	}
+	return nil
+}
+
+// GetAuction возвращает состояние аукциона и последние ставки
+func (p *ListingHandler) GetAuction(w http.ResponseWriter, r *http.Request) {
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	auction, err := p.Listing.GetAuction(listingID)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+		}, auctionErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusAuctionFetched, map[string]string{
+		messages.LogListingID: listingID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, auction)
+}
+
+// PlaceBid делает ставку на аукционе
+func (p *ListingHandler) PlaceBid(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	var req struct {
+		Amount int `json:"amount"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	auction, err := p.Listing.PlaceBid(listingID, userID, req.Amount)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+			messages.LogUserID:    userID.String(),
+			messages.LogAmount:    strconv.Itoa(req.Amount),
+		}, auctionErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusBidPlaced, map[string]string{
+		messages.LogListingID: listingID.String(),
+		messages.LogUserID:    userID.String(),
+		messages.LogAmount:    strconv.Itoa(req.Amount),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusBidPlaced, auction)
+}
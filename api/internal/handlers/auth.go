@@ -26,6 +26,8 @@ type AuthHandler struct {
 	secret  string           // Секретный ключ для шифрования
 }
 
+// serverSecretKey ключ, которым раньше шифровались пароли перед сохранением. Нужен, пока у части
+// учётных записей пароль не заменён хэшем при входе
 var serverSecretKey []byte
 
 func init() {
@@ -108,16 +110,12 @@ func (p *AuthHandler) LogIN(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newPassword, err := encryption.EncryptData(password, string(serverSecretKey))
-	if err != nil {
-		logger.Error(messages.ServiceAuth, messages.LogErrEncryption, map[string]string{
-			messages.LogDetails: err.Error(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrEncryption, nil)
+	legacy, ok := legacyPassword(w, password)
+	if !ok {
 		return
 	}
 
-	userID, err := p.User.CheckPass(username, newPassword)
+	userID, err := p.User.CheckPass(username, password, legacy)
 	if err != nil {
 		logger.Error(messages.ServiceAuth, messages.LogErrAuthFailed, map[string]string{
 			messages.LogDetails:  err.Error(),
@@ -221,16 +219,7 @@ func (p *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	newPassword, err := encryption.EncryptData(password, string(serverSecretKey))
-	if err != nil {
-		logger.Error(messages.ServiceAuth, messages.LogErrEncryption, map[string]string{
-			messages.LogDetails: err.Error(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrEncryption, nil)
-		return
-	}
-
-	userID, err := p.User.CreateAccount(username, newPassword)
+	userID, err := p.User.CreateAccount(username, password)
 	if err != nil {
 		logger.Error(messages.ServiceAuth, messages.LogErrDBQuery, map[string]string{
 			messages.LogDetails:  err.Error(),
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// collectionErrors сопоставляет ошибки подборок с ответами клиенту
+var collectionErrors = []errorMapping{
+	{repo.ErrInvalidCollection, http.StatusBadRequest, messages.LogErrInvalidCollection, messages.ClientErrInvalidCollection},
+	{repo.ErrCollectionNotFound, http.StatusNotFound, messages.LogErrCollectionNotFound, messages.ClientErrCollectionNotFound},
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrCollectionExists, http.StatusConflict, messages.LogErrCollectionExists, messages.ClientErrCollectionExists},
+	{repo.ErrCollectionLimit, http.StatusConflict, messages.LogErrCollectionLimit, messages.ClientErrCollectionLimit},
+}
+
+// writeCollectionError отвечает клиенту на ошибку работы с подборкой
+func writeCollectionError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceListing, err, details, collectionErrors)
+}
+
+// withShareURL добавляет к открытой подборке публичную ссылку
+func withShareURL(collection repo.Collection) repo.Collection {
+	if collection.ShareToken != "" {
+		collection.ShareURL = publicURL() + "/api/collections/shared/" + collection.ShareToken
+	}
+	return collection
+}
+
+// parsePathID разбирает идентификатор подборки, вопроса или пользователя из пути запроса
+func parsePathID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// decodeCollectionName разбирает тело запроса с названием подборки
+func decodeCollectionName(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return "", false
+	}
+	return req.Name, true
+}
+
+// CreateCollection создаёт подборку избранного
+func (p *ListingHandler) CreateCollection(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	name, ok := decodeCollectionName(w, r)
+	if !ok {
+		return
+	}
+
+	collection, err := p.Listing.CreateCollection(userID, name)
+	if err != nil {
+		writeCollectionError(w, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionCreated, map[string]string{
+		messages.LogCollectionID: collection.ID.String(),
+		messages.LogUserID:       userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusCollectionCreated, collection)
+}
+
+// GetCollections возвращает подборки пользователя. С параметром listing_id отмечает подборки,
+// в которые это объявление уже добавлено
+func (p *ListingHandler) GetCollections(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	var listingID uuid.UUID
+	if listingStr := r.URL.Query().Get(messages.ReqListingID); listingStr != "" {
+		var err error
+		listingID, err = uuid.Parse(listingStr)
+		if err != nil {
+			logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+				messages.LogDetails: err.Error(),
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+			return
+		}
+	}
+
+	collections, err := p.Listing.GetCollections(userID, listingID)
+	if err != nil {
+		writeCollectionError(w, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		})
+		return
+	}
+	for i := range collections {
+		collections[i] = withShareURL(collections[i])
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionsFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(collections)),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, collections)
+}
+
+// RenameCollection переименовывает подборку
+func (p *ListingHandler) RenameCollection(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	collectionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+	name, ok := decodeCollectionName(w, r)
+	if !ok {
+		return
+	}
+
+	collection, err := p.Listing.RenameCollection(collectionID, userID, name)
+	if err != nil {
+		writeCollectionError(w, err, map[string]string{
+			messages.LogCollectionID: collectionID.String(),
+			messages.LogUserID:       userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionUpdated, map[string]string{
+		messages.LogCollectionID: collectionID.String(),
+		messages.LogUserID:       userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusCollectionUpdated, withShareURL(collection))
+}
+
+// DeleteCollection удаляет подборку, объявления в ней остаются в избранном
+func (p *ListingHandler) DeleteCollection(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	collectionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := p.Listing.DeleteCollection(collectionID, userID); err != nil {
+		writeCollectionError(w, err, map[string]string{
+			messages.LogCollectionID: collectionID.String(),
+			messages.LogUserID:       userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionDeleted, map[string]string{
+		messages.LogCollectionID: collectionID.String(),
+		messages.LogUserID:       userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusCollectionDeleted, nil)
+}
+
+// UpdateCollectionListing добавляет объявление в подборку (POST) или убирает его оттуда (DELETE)
+func (p *ListingHandler) UpdateCollectionListing(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	collectionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+	listingID, err := uuid.Parse(mux.Vars(r)["listing_id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	update := p.Listing.AddToCollection
+	if r.Method == http.MethodDelete {
+		update = p.Listing.RemoveFromCollection
+	}
+
+	details := map[string]string{
+		messages.LogCollectionID: collectionID.String(),
+		messages.LogListingID:    listingID.String(),
+		messages.LogUserID:       userID.String(),
+	}
+	if err := update(collectionID, userID, listingID); err != nil {
+		writeCollectionError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionUpdated, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusCollectionUpdated, nil)
+}
+
+// ShareCollection открывает доступ к подборке по ссылке или закрывает его.
+// Каждое открытие выдаёт новую ссылку, прежние перестают работать
+func (p *ListingHandler) ShareCollection(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	collectionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Shared bool `json:"shared"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	collection, err := p.Listing.ShareCollection(collectionID, userID, req.Shared)
+	if err != nil {
+		writeCollectionError(w, err, map[string]string{
+			messages.LogCollectionID: collectionID.String(),
+			messages.LogUserID:       userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCollectionUpdated, map[string]string{
+		messages.LogCollectionID: collectionID.String(),
+		messages.LogUserID:       userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusCollectionUpdated, withShareURL(collection))
+}
+
+// GetSharedCollection возвращает подборку по публичной ссылке. Объявления подборки
+// выдаёт GET /api/listings с параметрами collection_id и share_token
+func (p *ListingHandler) GetSharedCollection(w http.ResponseWriter, r *http.Request) {
+	collection, err := p.Listing.GetSharedCollection(mux.Vars(r)["token"])
+	if err != nil {
+		writeCollectionError(w, err, map[string]string{})
+		return
+	}
+
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, withShareURL(collection))
+}
@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/repo"
+	"api/internal/response"
+	"errors"
+	"net/http"
+)
+
+// errorMapping связывает ошибку репозитория с HTTP-ответом
+type errorMapping struct {
+	err       error
+	status    int
+	logMsg    string
+	clientMsg string
+}
+
+// listingLookupErrors сопоставляет ошибки получения отдельного объявления с ответами клиенту
+var listingLookupErrors = []errorMapping{
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+}
+
+// writeMappedError отвечает клиенту по первой подходящей строке таблицы,
+// неизвестные ошибки считаются ошибкой запроса к БД
+func writeMappedError(w http.ResponseWriter, service string, err error, details map[string]string, table []errorMapping) {
+	details[messages.LogDetails] = err.Error()
+
+	for _, m := range table {
+		if errors.Is(err, m.err) {
+			logger.Error(service, m.logMsg, details)
+			response.WriteAPIResponse(w, m.status, false, m.clientMsg, nil)
+			return
+		}
+	}
+
+	logger.Error(service, messages.LogErrDBQuery, details)
+	response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+}
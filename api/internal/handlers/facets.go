@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxFacetBuckets максимальное количество интервалов гистограммы цен
+const maxFacetBuckets = 100
+
+// GetListingFacets возвращает гистограмму цен и количество объявлений по категориям и статусам
+// для тех же параметров фильтрации, что и GetAllListings
+func (p *ListingHandler) GetListingFacets(w http.ResponseWriter, r *http.Request) {
+	filter, lerr := parseListingFilter(r, middleware.GetContext(r.Context()))
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+		return
+	}
+
+	buckets, err := parseFacetBuckets(r)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	facets, err := p.Listing.GetListingFacets(filter, buckets)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogCollectionID: filter.CollectionID.String(),
+		}, listingFilterErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusFacetsFetched, map[string]string{
+		messages.LogCount: strconv.FormatInt(facets.Total, 10),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, facets)
+}
+
+// parseFacetBuckets разбирает настройку гистограммы: price_bounds - возрастающие границы через запятую,
+// price_buckets - количество интервалов равной ширины. Без параметров используется настройка сервиса
+func parseFacetBuckets(r *http.Request) (repo.FacetBuckets, error) {
+	boundsStr := r.URL.Query().Get(messages.ReqPriceBounds)
+	countStr := r.URL.Query().Get(messages.ReqPriceBuckets)
+
+	if boundsStr != "" && countStr != "" {
+		return repo.FacetBuckets{}, errors.New("price_bounds and price_buckets are mutually exclusive")
+	}
+
+	var buckets repo.FacetBuckets
+	if countStr != "" {
+		count, err := strconv.Atoi(countStr)
+		if err != nil || count < 1 || count > maxFacetBuckets {
+			return repo.FacetBuckets{}, errors.New("invalid price_buckets: " + countStr)
+		}
+		buckets.Count = count
+	}
+
+	if boundsStr != "" {
+		parts := strings.Split(boundsStr, ",")
+		if len(parts) >= maxFacetBuckets {
+			return repo.FacetBuckets{}, errors.New("too many price_bounds")
+		}
+		for _, part := range parts {
+			bound, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64)
+			if err != nil || bound < 0 {
+				return repo.FacetBuckets{}, errors.New("invalid price_bounds: " + boundsStr)
+			}
+			if n := len(buckets.Bounds); n > 0 && bound <= buckets.Bounds[n-1] {
+				return repo.FacetBuckets{}, errors.New("price_bounds must be strictly ascending")
+			}
+			buckets.Bounds = append(buckets.Bounds, bound)
+		}
+	}
+
+	return buckets, nil
+}
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/spf13/viper"
+)
+
+// feedEncoder описывает формат выгрузки объявлений для маркетплейса
+type feedEncoder interface {
+	contentType() string
+	begin(enc *xml.Encoder) error
+	item(enc *xml.Encoder, listing repo.ListingType) error
+	end(enc *xml.Encoder) error
+}
+
+// publicURL возвращает внешний адрес сервиса для абсолютных ссылок в фидах
+func publicURL() string {
+	return strings.TrimSuffix(viper.GetString("api.publicURL"), "/")
+}
+
+// avitoFeed формирует фид в формате Авито Автозагрузки
+type avitoFeed struct{}
+
+type avitoImage struct {
+	URL string `xml:"url,attr"`
+}
+
+type avitoAd struct {
+	XMLName     xml.Name     `xml:"Ad"`
+	ID          string       `xml:"Id"`
+	DateBegin   string       `xml:"DateBegin"`
+	Title       string       `xml:"Title"`
+	Description string       `xml:"Description"`
+	Address     string       `xml:"Address"`
+	Price       int          `xml:"Price"`
+	Images      []avitoImage `xml:"Images>Image,omitempty"`
+}
+
+func (avitoFeed) contentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+func (avitoFeed) begin(enc *xml.Encoder) error {
+	return enc.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "Ads"},
+		Attr: []xml.Attr{
+			{Name: xml.Name{Local: "formatVersion"}, Value: "3"},
+			{Name: xml.Name{Local: "target"}, Value: "Avito.ru"},
+		},
+	})
+}
+
+func (avitoFeed) item(enc *xml.Encoder, listing repo.ListingType) error {
+	ad := avitoAd{
+		ID:          listing.ID.String(),
+		DateBegin:   listing.CreatedAt.Format(time.DateOnly),
+		Title:       listing.Title,
+		Description: listing.Description,
+		Address:     listing.Address,
+		Price:       listing.Price,
+	}
+	if listing.ImageURL != "" {
+		ad.Images = []avitoImage{{URL: publicURL() + listing.ImageURL}}
+	}
+	return enc.Encode(ad)
+}
+
+func (avitoFeed) end(enc *xml.Encoder) error {
+	return enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "Ads"}})
+}
+
+// yandexFeed формирует фид в формате Яндекс YML
+type yandexFeed struct{}
+
+type ymlCurrency struct {
+	XMLName xml.Name `xml:"currency"`
+	ID      string   `xml:"id,attr"`
+	Rate    string   `xml:"rate,attr"`
+}
+
+type ymlCategory struct {
+	XMLName xml.Name `xml:"category"`
+	ID      int      `xml:"id,attr"`
+	Name    string   `xml:",chardata"`
+}
+
+type ymlOffer struct {
+	XMLName     xml.Name `xml:"offer"`
+	ID          string   `xml:"id,attr"`
+	Available   bool     `xml:"available,attr"`
+	URL         string   `xml:"url"`
+	Name        string   `xml:"name"`
+	Price       int      `xml:"price"`
+	CurrencyID  string   `xml:"currencyId"`
+	CategoryID  int      `xml:"categoryId"`
+	Picture     string   `xml:"picture,omitempty"`
+	Description string   `xml:"description,omitempty"`
+}
+
+// ymlDefaultCategory - единственная категория каталога
+const ymlDefaultCategory = 1
+
+func (yandexFeed) contentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+func (yandexFeed) begin(enc *xml.Encoder) error {
+	err := enc.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "yml_catalog"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "date"}, Value: time.Now().Format(time.RFC3339)}},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "shop"}}); err != nil {
+		return err
+	}
+
+	for _, el := range []struct{ name, value string }{
+		{"name", messages.FeedShopName},
+		{"company", messages.FeedShopName},
+		{"url", publicURL()},
+	} {
+		if err := enc.EncodeElement(el.value, xml.StartElement{Name: xml.Name{Local: el.name}}); err != nil {
+			return err
+		}
+	}
+
+	currencies := struct {
+		XMLName    xml.Name `xml:"currencies"`
+		Currencies []ymlCurrency
+	}{Currencies: []ymlCurrency{{ID: messages.FeedCurrency, Rate: "1"}}}
+	if err := enc.Encode(currencies); err != nil {
+		return err
+	}
+
+	categories := struct {
+		XMLName    xml.Name `xml:"categories"`
+		Categories []ymlCategory
+	}{Categories: []ymlCategory{{ID: ymlDefaultCategory, Name: "Объявления"}}}
+	if err := enc.Encode(categories); err != nil {
+		return err
+	}
+
+	return enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "offers"}})
+}
+
+func (yandexFeed) item(enc *xml.Encoder, listing repo.ListingType) error {
+	offer := ymlOffer{
+		ID:          listing.ID.String(),
+		Available:   true,
+		URL:         listingURL(listing.ID),
+		Name:        listing.Title,
+		Price:       listing.Price,
+		CurrencyID:  messages.FeedCurrency,
+		CategoryID:  ymlDefaultCategory,
+		Description: listing.Description,
+	}
+	if listing.ImageURL != "" {
+		offer.Picture = publicURL() + listing.ImageURL
+	}
+	return enc.Encode(offer)
+}
+
+func (yandexFeed) end(enc *xml.Encoder) error {
+	for _, name := range []string{"offers", "shop", "yml_catalog"} {
+		if err := enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feedWriter лениво начинает XML документ, чтобы до первого объявления можно было вернуть ошибку
+type feedWriter struct {
+	w       http.ResponseWriter
+	enc     *xml.Encoder
+	format  feedEncoder
+	started bool
+	items   int
+}
+
+func (f *feedWriter) start() error {
+	if f.started {
+		return nil
+	}
+	f.started = true
+
+	f.w.Header().Set("Content-Type", f.format.contentType())
+	f.w.WriteHeader(http.StatusOK)
+	if _, err := f.w.Write([]byte(xml.Header)); err != nil {
+		return err
+	}
+	return f.format.begin(f.enc)
+}
+
+func (f *feedWriter) write(listing repo.ListingType) error {
+	if err := f.start(); err != nil {
+		return err
+	}
+	if err := f.format.item(f.enc, listing); err != nil {
+		return err
+	}
+	f.items++
+
+	if err := f.enc.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func (f *feedWriter) finish() error {
+	if err := f.start(); err != nil {
+		return err
+	}
+	if err := f.format.end(f.enc); err != nil {
+		return err
+	}
+	return f.enc.Flush()
+}
+
+// AvitoFeed отдает объявления продавца в формате Авито Автозагрузки
+func (p *ListingHandler) AvitoFeed(w http.ResponseWriter, r *http.Request) {
+	p.serveFeed(w, r, messages.FeedAvito, avitoFeed{})
+}
+
+// YandexFeed отдает объявления продавца в формате Яндекс YML
+func (p *ListingHandler) YandexFeed(w http.ResponseWriter, r *http.Request) {
+	p.serveFeed(w, r, messages.FeedYandex, yandexFeed{})
+}
+
+// serveFeed проверяет параметры фида и потоково выгружает объявления продавца
+func (p *ListingHandler) serveFeed(w http.ResponseWriter, r *http.Request, name string, format feedEncoder) {
+	userID, err := uuid.Parse(mux.Vars(r)["user_id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	token := r.URL.Query().Get(messages.ReqToken)
+	if token == "" {
+		logger.Error(messages.ServiceListing, messages.LogErrFeedToken, map[string]string{
+			messages.LogUserID: userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusForbidden, false, messages.ClientErrFeedToken, nil)
+		return
+	}
+
+	// Фильтр фида задаётся теми же параметрами, что и список объявлений, цена необязательна
+	query := r.URL.Query()
+	if query.Get(messages.ReqMinPrice) == "" {
+		query.Set(messages.ReqMinPrice, "1")
+	}
+	if query.Get(messages.ReqMaxPrice) == "" {
+		query.Set(messages.ReqMaxPrice, "100000000")
+	}
+	r.URL.RawQuery = query.Encode()
+
+	// Маршруты фидов без сессии, выборка строится от имени владельца фида
+	filter, lerr := parseListingFilter(r, userID)
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+		return
+	}
+
+	feed := &feedWriter{w: w, enc: xml.NewEncoder(w), format: format}
+	feed.enc.Indent("", "  ")
+
+	err = p.Listing.StreamFeed(userID, token, filter, feed.write)
+	if err == nil {
+		err = feed.finish()
+	}
+
+	if err != nil {
+		if feed.started {
+			logger.Error(messages.ServiceListing, messages.LogErrFeedWrite, map[string]string{
+				messages.LogDetails: err.Error(),
+				messages.LogUserID:  userID.String(),
+				messages.LogFormat:  name,
+			})
+			return
+		}
+
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogUserID:       userID.String(),
+			messages.LogCollectionID: filter.CollectionID.String(),
+		}, exportFeedErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusFeedServed, map[string]string{
+		messages.LogUserID:    userID.String(),
+		messages.LogFormat:    name,
+		messages.LogFeedItems: strconv.Itoa(feed.items),
+	})
+}
+
+// exportFeedErrors сопоставляет ошибки выгрузки фида с ответами клиенту
+var exportFeedErrors = append([]errorMapping{
+	{repo.ErrInvalidFeedToken, http.StatusForbidden, messages.LogErrFeedToken, messages.ClientErrFeedToken},
+}, listingFilterErrors...)
+
+// GetFeedToken возвращает токен и ссылки на фиды текущего пользователя
+func (p *ListingHandler) GetFeedToken(w http.ResponseWriter, r *http.Request) {
+	p.feedToken(w, r, false)
+}
+
+// RegenerateFeedToken выпускает новый токен фида, старые ссылки перестают работать
+func (p *ListingHandler) RegenerateFeedToken(w http.ResponseWriter, r *http.Request) {
+	p.feedToken(w, r, true)
+}
+
+func (p *ListingHandler) feedToken(w http.ResponseWriter, r *http.Request, regenerate bool) {
+	userID := middleware.GetContext(r.Context())
+
+	token, err := p.Listing.GetFeedToken(userID, regenerate)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogUserID:  userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	base := publicURL() + "/api/feeds/" + userID.String()
+	resp := map[string]string{
+		messages.ReqToken:   token,
+		messages.FeedAvito:  base + "/avito.xml?token=" + token,
+		messages.FeedYandex: base + "/yandex.yml?token=" + token,
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusFeedToken, map[string]string{
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, resp)
+}
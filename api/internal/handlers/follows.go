@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"net/http"
+)
+
+// followErrors сопоставляет ошибки подписок с ответами клиенту
+var followErrors = []errorMapping{
+	{repo.ErrInvalidFollow, http.StatusBadRequest, messages.LogErrInvalidFollow, messages.ClientErrInvalidFollow},
+	{repo.ErrUserNotFound, http.StatusNotFound, messages.LogErrUserNotFound, messages.ClientErrUserNotFound},
+}
+
+// writeFollowError отвечает клиенту на ошибку работы с подпиской
+func writeFollowError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceListing, err, details, followErrors)
+}
+
+// UpdateFollow подписывает на продавца (POST) или отменяет подписку (DELETE)
+func (p *ListingHandler) UpdateFollow(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	sellerID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	update, logStatus, clientStatus := p.Listing.FollowUser, messages.LogStatusFollowed, messages.StatusFollowed
+	if r.Method == http.MethodDelete {
+		update, logStatus, clientStatus = p.Listing.UnfollowUser, messages.LogStatusUnfollowed, messages.StatusUnfollowed
+	}
+
+	details := map[string]string{
+		messages.LogSellerID: sellerID.String(),
+		messages.LogUserID:   userID.String(),
+	}
+	stats, err := update(userID, sellerID)
+	if err != nil {
+		writeFollowError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, logStatus, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, clientStatus, stats)
+}
+
+// GetFollowStats возвращает количество подписчиков и подписок продавца
+// и для авторизованного пользователя - подписан ли он на этого продавца
+func (p *ListingHandler) GetFollowStats(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	sellerID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	stats, err := p.Listing.GetFollowStats(userID, sellerID)
+	if err != nil {
+		writeFollowError(w, err, map[string]string{
+			messages.LogSellerID: sellerID.String(),
+		})
+		return
+	}
+
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, stats)
+}
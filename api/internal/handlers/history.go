@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"net/http"
+	"strconv"
+)
+
+// historyErrors сопоставляет ошибки истории объявления с ответами клиенту
+var historyErrors = []errorMapping{
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrHistoryForbidden, http.StatusForbidden, messages.LogErrHistoryForbidden, messages.ClientErrHistoryForbidden},
+}
+
+// GetListingHistory возвращает историю изменений объявления: кто, когда и какие поля поменял.
+// Доступно автору объявления и модераторам
+func (p *ListingHandler) GetListingHistory(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	page := r.URL.Query().Get(messages.ReqPage)
+	pageInt := 1
+	if page != "" {
+		var err error
+		pageInt, err = strconv.Atoi(page)
+		if err != nil || pageInt < 1 {
+			logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+				messages.LogPage: page,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	details := map[string]string{
+		messages.LogListingID: listingID.String(),
+		messages.LogUserID:    userID.String(),
+	}
+
+	entries, totalPages, currentPage, err := p.Listing.GetListingHistory(listingID, userID, pageInt)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, details, historyErrors)
+		return
+	}
+
+	resp := map[string]interface{}{
+		messages.LogHistory:     entries,
+		messages.LogTotalPages:  totalPages,
+		messages.LogCurrentPage: currentPage,
+	}
+
+	details[messages.LogCount] = strconv.Itoa(len(entries))
+	logger.Info(messages.ServiceListing, messages.LogStatusHistoryFetched, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, resp)
+}
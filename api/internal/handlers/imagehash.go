@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/jpeg" // декодер JPEG для image.Decode
+	_ "image/png"  // декодер PNG для image.Decode
+)
+
+// Размер уменьшенного изображения для разностного хэша: 9x8 точек дают 64 сравнения соседей
+const (
+	hashWidth  = 9
+	hashHeight = 8
+	hashSample = 16 // сколько точек по каждой оси усредняется в одной ячейке
+)
+
+// maxHashPixels ограничивает размер декодируемого изображения: небольшой файл может объявить
+// огромные размеры, и image.Decode выделит под них гигабайты памяти
+const maxHashPixels = 24_000_000
+
+// errImageDimensions возвращается, если изображение больше maxHashPixels точек
+var errImageDimensions = errors.New("image dimensions are too large")
+
+// imageHash считает разностный перцептивный хэш (dHash) изображения: картинка уменьшается
+// до 9x8 в оттенках серого, и каждый бит показывает, ярче ли точка своего правого соседа.
+// Пересжатие, изменение размера и небольшие правки почти не меняют хэш
+func imageHash(data []byte) (uint64, error) {
+	if err := checkImageDimensions(data); err != nil {
+		return 0, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+
+	bounds := img.Bounds()
+	var gray [hashHeight][hashWidth]float64
+	for cy := 0; cy < hashHeight; cy++ {
+		for cx := 0; cx < hashWidth; cx++ {
+			x0 := bounds.Min.X + cx*bounds.Dx()/hashWidth
+			x1 := bounds.Min.X + (cx+1)*bounds.Dx()/hashWidth
+			y0 := bounds.Min.Y + cy*bounds.Dy()/hashHeight
+			y1 := bounds.Min.Y + (cy+1)*bounds.Dy()/hashHeight
+			gray[cy][cx] = cellLuminance(img, x0, x1, y0, y1)
+		}
+	}
+
+	var hash uint64
+	for y := 0; y < hashHeight; y++ {
+		for x := 0; x < hashWidth-1; x++ {
+			hash <<= 1
+			if gray[y][x] > gray[y][x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash, nil
+}
+
+// checkImageDimensions читает из заголовка изображения его размеры, не декодируя точки
+func checkImageDimensions(data []byte) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxHashPixels/cfg.Height {
+		return errImageDimensions
+	}
+	return nil
+}
+
+// cellLuminance средняя яркость прямоугольника [x0, x1) x [y0, y1) по не более чем hashSample^2 точкам
+func cellLuminance(img image.Image, x0, x1, y0, y1 int) float64 {
+	if x1 <= x0 {
+		x1 = x0 + 1
+	}
+	if y1 <= y0 {
+		y1 = y0 + 1
+	}
+
+	stepX := max((x1-x0)/hashSample, 1)
+	stepY := max((y1-y0)/hashSample, 1)
+
+	var sum float64
+	var n int
+	for y := y0; y < y1; y += stepY {
+		for x := x0; x < x1; x += stepX {
+			r, g, b, _ := img.At(x, y).RGBA()
+			sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			n++
+		}
+	}
+	return sum / float64(n)
+}
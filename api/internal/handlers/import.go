@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"api/internal/i18n"
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+const (
+	maxImportSize = 64 << 20 // максимальный размер файла импорта
+	maxImportRows = 1000     // максимальное количество строк в одном импорте
+
+	importBatchRows = 100 // количество строк, изображения которых сохраняются и передаются в задачу за раз
+)
+
+// importRecord строка файла импорта, поля совпадают с запросом создания объявления
+type importRecord struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Address     string `json:"address"`
+	Price       int    `json:"price"`
+	ImageBase64 string `json:"image_base64"`
+	ImageName   string `json:"image_name"`
+}
+
+// importLine разобранная строка файла импорта с номером строки и ошибкой разбора
+type importLine struct {
+	row    int
+	record importRecord
+	err    string
+}
+
+// errImportTooManyRows возвращается, если файл содержит больше maxImportRows строк
+var errImportTooManyRows = errors.New("too many rows")
+
+// ImportListings принимает CSV или JSON Lines файл и создает задачу импорта объявлений
+func (p *ListingHandler) ImportListings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	format := importFormat(r)
+	if format == "" {
+		logger.Error(messages.ServiceListing, messages.LogErrImportFormat, map[string]string{
+			messages.LogFormat: r.Header.Get("Content-Type"),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrImportFormat, nil)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+
+	var lines []importLine
+	var err error
+	switch format {
+	case messages.ImportFormatCSV:
+		lines, err = parseImportCSV(body)
+	case messages.ImportFormatJSONL:
+		lines, err = parseImportJSONL(body)
+	}
+
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || errors.Is(err, errImportTooManyRows) {
+		logger.Error(messages.ServiceListing, messages.LogErrImportTooLarge, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusRequestEntityTooLarge, false, messages.ClientErrImportTooLarge, nil)
+		return
+	}
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrImportParse, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogFormat:  format,
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrImportParse, nil)
+		return
+	}
+
+	if len(lines) == 0 {
+		logger.Error(messages.ServiceListing, messages.LogErrImportEmpty, nil)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrImportEmpty, nil)
+		return
+	}
+
+	// Изображения декодируются и сохраняются в фоне, после создания задачи
+	var pending []importLine
+	var rowErrors []repo.ImportRowError
+	for _, line := range lines {
+		if line.err != "" {
+			rowErrors = append(rowErrors, repo.ImportRowError{Row: line.row, Message: line.err})
+			continue
+		}
+		pending = append(pending, line)
+	}
+
+	job, err := p.Listing.CreateImportJob(userID, len(lines), rowErrors)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogUserID:  userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	go p.processImport(job.ID, userID, pending)
+
+	logger.Info(messages.ServiceListing, messages.LogStatusImportStarted, map[string]string{
+		messages.LogJobID:  job.ID.String(),
+		messages.LogUserID: userID.String(),
+		messages.LogRows:   strconv.Itoa(len(lines)),
+	})
+	response.WriteAPIResponse(w, http.StatusAccepted, true, messages.StatusImportStarted, localizeImportJob(r, job))
+}
+
+// processImport проверяет строки импорта, сохраняет их изображения и передаёт строки в задачу
+// порциями по importBatchRows. Изображения строк, которые не удалось передать или которые
+// сервис объявлений отклонил, удаляются
+func (p *ListingHandler) processImport(jobID uuid.UUID, userID uuid.UUID, lines []importLine) {
+	details := map[string]string{
+		messages.LogJobID:  jobID.String(),
+		messages.LogUserID: userID.String(),
+	}
+
+	// Если все строки отклонены при разборе, задача завершается одной пустой порцией
+	for start := 0; start == 0 || start < len(lines); start += importBatchRows {
+		end := min(start+importBatchRows, len(lines))
+		rows, rowErrors := p.prepareImportRows(userID, lines[start:end])
+
+		rejected, err := p.Listing.AppendImportRows(jobID, userID, rows, rowErrors, end == len(lines))
+		if err != nil {
+			details[messages.LogDetails] = err.Error()
+			logger.Error(messages.ServiceListing, messages.LogErrImportAppend, details)
+			for _, row := range rows {
+				removeListingImage(row.Listing.ImageURL)
+			}
+			return
+		}
+
+		rejectedRows := make(map[int]bool, len(rejected))
+		for _, rowErr := range rejected {
+			rejectedRows[rowErr.Row] = true
+		}
+		for _, row := range rows {
+			if rejectedRows[row.Row] {
+				removeListingImage(row.Listing.ImageURL)
+			}
+		}
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusImportProcessed, details)
+}
+
+// prepareImportRows проверяет строки импорта так же, как создание объявления, и сохраняет их изображения
+func (p *ListingHandler) prepareImportRows(userID uuid.UUID, lines []importLine) ([]repo.ImportRow, []repo.ImportRowError) {
+	var rows []repo.ImportRow
+	var rowErrors []repo.ImportRowError
+	for _, line := range lines {
+		rec := line.record
+		if lerr := validateListingFields(rec.Title, rec.Description, rec.Address, rec.Price, rec.ImageBase64, rec.ImageName); lerr != nil {
+			rowErrors = append(rowErrors, repo.ImportRowError{Row: line.row, Message: lerr.client})
+			continue
+		}
+
+		if v := p.checkContent(rec.Title, rec.Description, rec.Address); v != nil {
+			rowErrors = append(rowErrors, repo.ImportRowError{Row: line.row, Message: violationMessage(v)})
+			continue
+		}
+
+		imageData, lerr := decodeListingImage(rec.ImageBase64)
+		if lerr != nil {
+			rowErrors = append(rowErrors, repo.ImportRowError{Row: line.row, Message: lerr.client})
+			continue
+		}
+
+		imageURL, err := saveListingImage(imageData, rec.ImageName)
+		if err != nil {
+			logger.Error(messages.ServiceListing, messages.LogErrFileSave, map[string]string{
+				messages.LogDetails: err.Error(),
+			})
+			rowErrors = append(rowErrors, repo.ImportRowError{Row: line.row, Message: messages.ClientErrFileSave})
+			continue
+		}
+
+		// Без хэша строка проверяется на дубликаты только по тексту
+		hash, err := imageHash(imageData)
+		if err != nil {
+			logger.Error(messages.ServiceListing, messages.LogErrImageHash, map[string]string{
+				messages.LogDetails: err.Error(),
+			})
+		}
+
+		rows = append(rows, repo.ImportRow{
+			Row: line.row,
+			Listing: repo.ListingType{
+				Title:       rec.Title,
+				Description: rec.Description,
+				Address:     rec.Address,
+				Price:       rec.Price,
+				AuthorID:    userID,
+				ImageURL:    imageURL,
+				ImageHash:   hash,
+			},
+		})
+	}
+	return rows, rowErrors
+}
+
+// importJobErrors сопоставляет ошибки получения задачи импорта с ответами клиенту
+var importJobErrors = []errorMapping{
+	{repo.ErrImportJobNotFound, http.StatusNotFound, messages.LogErrImportJobNotFound, messages.ClientErrImportJobNotFound},
+	{repo.ErrImportJobForbidden, http.StatusForbidden, messages.LogErrImportJobForbidden, messages.ClientErrImportJobForbidden},
+}
+
+// GetImportJob возвращает состояние задачи импорта
+func (p *ListingHandler) GetImportJob(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	vars := mux.Vars(r)
+	jobIDStr, ok := vars["id"]
+	if !ok {
+		logger.Error(messages.ServiceListing, messages.LogErrMissingID, nil)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrMissingID, nil)
+		return
+	}
+
+	jobID, err := uuid.Parse(jobIDStr)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	job, err := p.Listing.GetImportJob(jobID, userID)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogJobID:  jobID.String(),
+			messages.LogUserID: userID.String(),
+		}, importJobErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusImportFetched, map[string]string{
+		messages.LogJobID:  jobID.String(),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, localizeImportJob(r, job))
+}
+
+// importFormat определяет формат файла импорта по параметру format или заголовку Content-Type
+func importFormat(r *http.Request) string {
+	switch strings.ToLower(r.URL.Query().Get(messages.ReqFormat)) {
+	case messages.ImportFormatCSV:
+		return messages.ImportFormatCSV
+	case messages.ImportFormatJSONL:
+		return messages.ImportFormatJSONL
+	case "":
+	default:
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "text/csv":
+		return messages.ImportFormatCSV
+	case "application/jsonl", "application/x-ndjson", "application/x-jsonlines":
+		return messages.ImportFormatJSONL
+	}
+	return ""
+}
+
+// parseImportCSV разбирает CSV файл с заголовком, названия колонок совпадают с полями importRecord
+func parseImportCSV(body io.Reader) ([]importLine, error) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(strings.ToLower(name))] = i
+	}
+
+	field := func(record []string, name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var lines []importLine
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		if err != nil && !errors.As(err, &parseErr) {
+			return nil, err
+		}
+
+		// Строки с ошибкой разбора тоже занимают место в лимите
+		if len(lines) == maxImportRows {
+			return nil, errImportTooManyRows
+		}
+
+		if parseErr != nil {
+			lines = append(lines, importLine{row: row, err: messages.ClientErrImportParse})
+			continue
+		}
+
+		line := importLine{
+			row: row,
+			record: importRecord{
+				Title:       field(record, "title"),
+				Description: field(record, "description"),
+				Address:     field(record, "address"),
+				ImageBase64: field(record, "image_base64"),
+				ImageName:   field(record, "image_name"),
+			},
+		}
+
+		price, err := strconv.Atoi(field(record, "price"))
+		if err != nil {
+			line.err = messages.ClientErrInvalidPrice
+		}
+		line.record.Price = price
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// parseImportJSONL разбирает JSON Lines файл, каждая непустая строка - отдельное объявление
+func parseImportJSONL(body io.Reader) ([]importLine, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportSize)
+
+	var lines []importLine
+	for row := 1; scanner.Scan(); row++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+
+		if len(lines) == maxImportRows {
+			return nil, errImportTooManyRows
+		}
+
+		line := importLine{row: row}
+		if err := json.Unmarshal(data, &line.record); err != nil {
+			line.err = messages.ClientErrImportParse
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// localizeImportJob переводит ошибки строк импорта, сохранённые кодами сообщений, на язык запроса
+func localizeImportJob(r *http.Request, job repo.ImportJob) repo.ImportJob {
+	if job.Errors == nil {
+		return job
+	}
+
+	locale := i18n.FromContext(r.Context())
+	errs := make([]repo.ImportRowError, len(job.Errors))
+	for i, e := range job.Errors {
+		errs[i] = repo.ImportRowError{Row: e.Row, Message: i18n.Translate(locale, e.Message)}
+	}
+	job.Errors = errs
+	return job
+}
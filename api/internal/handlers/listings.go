@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"api/internal/contentfilter"
+	"api/internal/i18n"
 	"api/internal/logger"
 	"api/internal/messages"
 	"api/internal/middleware"
@@ -8,10 +10,15 @@ import (
 	"api/internal/response"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
@@ -19,77 +26,173 @@ import (
 
 type ListingHandler struct {
 	Listing repo.ListingRepo
+	User    repo.UserRepo
+	Filter  *contentfilter.Filter
+	Feeds   *FeedCache
 }
 
-func (p *ListingHandler) GetAllListings(w http.ResponseWriter, r *http.Request) {
-	userID := middleware.GetContext(r.Context())
+// maxAuthorFilter - максимальное количество авторов в фильтре выдачи
+const maxAuthorFilter = 50
 
-	sortField := r.URL.Query().Get(messages.ReqSortField)
-	sortOrder := r.URL.Query().Get(messages.ReqSortOrder)
-	onlyLikedStr := r.URL.Query().Get(messages.ReqOnlyLiked)
-	targetUserId := r.URL.Query().Get(messages.ReqTargetUserID)
-	page := r.URL.Query().Get(messages.ReqPage)
-	minPrice := r.URL.Query().Get(messages.ReqMinPrice)
-	maxPrice := r.URL.Query().Get(messages.ReqMaxPrice)
+// maxImageSize - максимальный размер изображения объявления
+const maxImageSize = 5 << 20
 
-	pageInt, err := strconv.Atoi(page)
-	if err != nil || pageInt < 1 {
-		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
-			messages.LogPage: page,
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
-		return
+// listingError описывает ошибку валидации объявления
+type listingError struct {
+	client  string            // Сообщение для клиента
+	log     string            // Сообщение для лога
+	details map[string]string // Подробности для лога
+}
+
+// validateListingFields проверяет поля объявления по общим правилам создания и редактирования
+func validateListingFields(title, description, address string, price int, imageBase64, imageName string) *listingError {
+	if title == "" || address == "" || imageBase64 == "" || imageName == "" {
+		return &listingError{messages.ClientErrMissingFields, messages.LogErrMissingFields, nil}
 	}
 
-	minPriceInt, err := strconv.Atoi(minPrice)
-	if err != nil || minPriceInt < 1 || minPriceInt > 100000000 {
-		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
-			messages.LogPrice: minPrice,
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
-		return
+	if len(title) < 3 || len(title) > 100 {
+		return &listingError{messages.ClientErrInvalidTitle, messages.LogErrInvalidTitle, map[string]string{
+			messages.LogTitleLength: strconv.Itoa(len(title)),
+		}}
 	}
 
-	maxPriceInt, err := strconv.Atoi(maxPrice)
-	if err != nil || maxPriceInt < 1 || maxPriceInt > 100000000 || maxPriceInt < minPriceInt {
-		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
-			messages.LogPrice: maxPrice,
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
-		return
+	if len(description) > 1000 {
+		return &listingError{messages.ClientErrInvalidDescription, messages.LogErrInvalidDescription, map[string]string{
+			messages.LogDescLength: strconv.Itoa(len(description)),
+		}}
 	}
 
-	onlyLiked := onlyLikedStr == "true"
+	if len(address) < 5 || len(address) > 200 {
+		return &listingError{messages.ClientErrInvalidAddress, messages.LogErrInvalidAddress, map[string]string{
+			messages.LogAddressLength: strconv.Itoa(len(address)),
+		}}
+	}
 
-	var targetUser uuid.UUID
-	if targetUserId != "" {
-		targetUser, err = uuid.Parse(targetUserId)
+	if price <= 0 || price > 100_000_000 {
+		return &listingError{messages.ClientErrInvalidPrice, messages.LogErrInvalidPrice, map[string]string{
+			messages.LogPrice: strconv.Itoa(price),
+		}}
+	}
+
+	return nil
+}
+
+// checkContent проверяет текст объявления правилами фильтра содержимого
+func (p *ListingHandler) checkContent(title, description, address string) *contentfilter.Violation {
+	return p.Filter.Check(map[string]string{
+		contentfilter.FieldTitle:       title,
+		contentfilter.FieldDescription: description,
+		contentfilter.FieldAddress:     address,
+	})
+}
+
+// violationMessage возвращает сообщение нарушенного правила: код правила, если для него есть перевод,
+// иначе текст из файла правил или общее сообщение фильтра
+func violationMessage(v *contentfilter.Violation) string {
+	switch {
+	case i18n.Known(v.Code):
+		return v.Code
+	case v.Message != "":
+		return v.Message
+	default:
+		return messages.ClientErrContentRejected
 	}
+}
+
+// decodeListingImage декодирует изображение из base64 и проверяет его размер и тип
+func decodeListingImage(imageBase64 string) ([]byte, *listingError) {
+	imageData, err := base64.StdEncoding.DecodeString(imageBase64)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+		return nil, &listingError{messages.ClientErrInvalidImage, messages.LogErrInvalidImage, map[string]string{
+			messages.LogDetails: err.Error(),
+		}}
+	}
+
+	if len(imageData) > maxImageSize {
+		return nil, &listingError{messages.ClientErrImageTooLarge, messages.LogErrImageTooLarge, map[string]string{
+			messages.LogImageSize: strconv.Itoa(len(imageData)),
+		}}
+	}
+
+	filetype := http.DetectContentType(imageData)
+	if filetype != "image/jpeg" && filetype != "image/png" {
+		return nil, &listingError{messages.ClientErrUnsupportedImageType, messages.LogErrUnsupportedImageType, map[string]string{
+			messages.LogImageType: filetype,
+		}}
+	}
+
+	if err := checkImageDimensions(imageData); errors.Is(err, errImageDimensions) {
+		return nil, &listingError{messages.ClientErrImageTooLarge, messages.LogErrImageTooLarge, map[string]string{
+			messages.LogDetails: err.Error(),
+		}}
+	} else if err != nil {
+		return nil, &listingError{messages.ClientErrInvalidImage, messages.LogErrInvalidImage, map[string]string{
+			messages.LogDetails: err.Error(),
+		}}
+	}
+
+	return imageData, nil
+}
+
+// saveListingImage сохраняет изображение в каталог загрузок и возвращает его URL
+func saveListingImage(imageData []byte, imageName string) (string, error) {
+	filename := uuid.New().String() + filepath.Ext(imageName)
+	savePath := filepath.Join("uploads", filename)
+	if err := os.WriteFile(savePath, imageData, 0644); err != nil {
+		return "", err
+	}
+	return "/uploads/" + filename, nil
+}
+
+// removeListingImage удаляет изображение, сохранённое saveListingImage
+func removeListingImage(imageURL string) {
+	path := filepath.Join("uploads", filepath.Base(imageURL))
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Error(messages.ServiceListing, messages.LogErrFileRemove, map[string]string{
 			messages.LogDetails: err.Error(),
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+	}
+}
+
+// listingFilterErrors сопоставляет ошибки выборки объявлений по фильтру с ответами клиенту
+var listingFilterErrors = []errorMapping{
+	{repo.ErrCollectionNotFound, http.StatusNotFound, messages.LogErrCollectionNotFound, messages.ClientErrCollectionNotFound},
+	{repo.ErrInvalidAttributes, http.StatusBadRequest, messages.LogErrInvalidAttributes, messages.ClientErrInvalidAttributes},
+	{repo.ErrInvalidRequest, http.StatusBadRequest, messages.LogErrParamsRequest, messages.ClientErrBadRequest},
+}
+
+// listingErrors сопоставляет ошибки создания и редактирования объявления с ответами клиенту
+var listingErrors = []errorMapping{
+	{repo.ErrDuplicateListing, http.StatusConflict, messages.LogErrDuplicateListing, messages.ClientErrDuplicateListing},
+	{repo.ErrInvalidAttributes, http.StatusBadRequest, messages.LogErrInvalidAttributes, messages.ClientErrInvalidAttributes},
+	{repo.ErrInvalidRequest, http.StatusBadRequest, messages.LogErrParamsRequest, messages.ClientErrBadRequest},
+}
+
+func (p *ListingHandler) GetAllListings(w http.ResponseWriter, r *http.Request) {
+	page := r.URL.Query().Get(messages.ReqPage)
+
+	pageInt, err := strconv.Atoi(page)
+	if err != nil || pageInt < 1 {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogPage: page,
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
 		return
 	}
 
-	filter := repo.ListingFilter{
-		UserID:     userID,
-		TargetUser: targetUser,
-		SortField:  sortField,
-		SortOrder:  sortOrder,
-		OnlyLiked:  onlyLiked,
-		Page:       pageInt,
-		MinPrice:   minPriceInt,
-		MaxPrice:   maxPriceInt,
+	filter, lerr := parseListingFilter(r, middleware.GetContext(r.Context()))
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+		return
 	}
+	filter.Page = pageInt
 
 	listings, totalPages, currentPage, err := p.Listing.GetAllListings(filter)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
-			messages.LogDetails: err.Error(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogCollectionID: filter.CollectionID.String(),
+		}, listingFilterErrors)
 		return
 	}
 
@@ -109,12 +212,15 @@ func (p *ListingHandler) AddListing(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetContext(r.Context())
 
 	var req struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-		Address     string `json:"address"`
-		Price       int    `json:"price"`
-		ImageBase64 string `json:"image_base64"`
-		ImageName   string `json:"image_name"`
+		Title       string                `json:"title"`
+		Description string                `json:"description"`
+		Address     string                `json:"address"`
+		Price       int                   `json:"price"`
+		ImageBase64 string                `json:"image_base64"`
+		ImageName   string                `json:"image_name"`
+		CategoryID  int                   `json:"category_id"`
+		Attributes  map[string]string     `json:"attributes"`
+		Auction     *repo.AuctionSettings `json:"auction"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -125,82 +231,57 @@ func (p *ListingHandler) AddListing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Title == "" || req.Address == "" || req.ImageBase64 == "" || req.ImageName == "" {
-		logger.Error(messages.ServiceListing, messages.LogErrMissingFields, nil)
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrMissingFields, nil)
+	if lerr := validateListingFields(req.Title, req.Description, req.Address, req.Price, req.ImageBase64, req.ImageName); lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
 		return
 	}
 
-	if len(req.Title) < 3 || len(req.Title) > 100 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidTitle, map[string]string{
-			messages.LogTitleLength: strconv.Itoa(len(req.Title)),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidTitle, nil)
-		return
+	if req.Auction != nil {
+		if lerr := validateAuctionSettings(req.Auction, time.Now()); lerr != nil {
+			logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+			return
+		}
 	}
 
-	if len(req.Description) > 1000 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidDescription, map[string]string{
-			messages.LogDescLength: strconv.Itoa(len(req.Description)),
+	if v := p.checkContent(req.Title, req.Description, req.Address); v != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrContentRejected, map[string]string{
+			messages.LogUserID: userID.String(),
+			messages.LogRule:   v.Code,
+			messages.LogField:  v.Field,
+			messages.LogMatch:  v.Match,
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidDescription, nil)
-		return
-	}
-
-	if len(req.Address) < 5 || len(req.Address) > 200 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidAddress, map[string]string{
-			messages.LogAddressLength: strconv.Itoa(len(req.Address)),
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, violationMessage(v), map[string]string{
+			messages.LogRule:  v.Code,
+			messages.LogField: v.Field,
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidAddress, nil)
 		return
 	}
 
-	if req.Price <= 0 || req.Price > 100000000 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidPrice, map[string]string{
-			messages.LogPrice: strconv.Itoa(req.Price),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidPrice, nil)
+	imageData, lerr := decodeListingImage(req.ImageBase64)
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
 		return
 	}
 
-	imageData, err := base64.StdEncoding.DecodeString(req.ImageBase64)
+	imageURL, err := saveListingImage(imageData, req.ImageName)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidImage, map[string]string{
+		logger.Error(messages.ServiceListing, messages.LogErrFileSave, map[string]string{
 			messages.LogDetails: err.Error(),
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidImage, nil)
-		return
-	}
-
-	const maxFileSize = 5 << 20
-	if len(imageData) > maxFileSize {
-		logger.Error(messages.ServiceListing, messages.LogErrImageTooLarge, map[string]string{
-			messages.LogImageSize: strconv.Itoa(len(imageData)),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrImageTooLarge, nil)
-		return
-	}
-
-	filetype := http.DetectContentType(imageData[:512])
-	if filetype != "image/jpeg" && filetype != "image/png" {
-		logger.Error(messages.ServiceListing, messages.LogErrUnsupportedImageType, map[string]string{
-			messages.LogImageType: filetype,
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrUnsupportedImageType, nil)
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrFileSave, nil)
 		return
 	}
 
-	filename := uuid.New().String() + filepath.Ext(req.ImageName)
-	savePath := filepath.Join("uploads", filename)
-	if err := os.WriteFile(savePath, imageData, 0644); err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrFileSave, map[string]string{
+	// Без хэша объявление проверяется на дубликаты только по тексту
+	hash, err := imageHash(imageData)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrImageHash, map[string]string{
 			messages.LogDetails: err.Error(),
-			messages.LogPath:    savePath,
 		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrFileSave, nil)
-		return
 	}
-	imageURL := "/uploads/" + filename
 
 	listing := repo.ListingType{
 		Title:       req.Title,
@@ -209,14 +290,17 @@ func (p *ListingHandler) AddListing(w http.ResponseWriter, r *http.Request) {
 		Price:       req.Price,
 		AuthorID:    userID,
 		ImageURL:    imageURL,
+		CategoryID:  req.CategoryID,
+		Attributes:  req.Attributes,
+		ImageHash:   hash,
+		Auction:     req.Auction,
 	}
 
 	id, err := p.Listing.AddListing(listing)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
-			messages.LogDetails: err.Error(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		}, listingErrors)
 		return
 	}
 
@@ -236,13 +320,15 @@ func (p *ListingHandler) EditListing(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.GetContext(r.Context())
 
 	var req struct {
-		Title       string    `json:"title"`
-		Description string    `json:"description"`
-		Address     string    `json:"address"`
-		Price       int       `json:"price"`
-		ImageBase64 string    `json:"image_base64"`
-		ImageName   string    `json:"image_name"`
-		ID          uuid.UUID `json:"listing_id"`
+		Title       string            `json:"title"`
+		Description string            `json:"description"`
+		Address     string            `json:"address"`
+		Price       int               `json:"price"`
+		ImageBase64 string            `json:"image_base64"`
+		ImageName   string            `json:"image_name"`
+		ID          uuid.UUID         `json:"listing_id"`
+		CategoryID  int               `json:"category_id"`
+		Attributes  map[string]string `json:"attributes"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -253,82 +339,49 @@ func (p *ListingHandler) EditListing(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Title == "" || req.Address == "" || req.ImageBase64 == "" || req.ImageName == "" {
-		logger.Error(messages.ServiceListing, messages.LogErrMissingFields, nil)
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrMissingFields, nil)
+	if lerr := validateListingFields(req.Title, req.Description, req.Address, req.Price, req.ImageBase64, req.ImageName); lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
 		return
 	}
 
-	if len(req.Title) < 3 || len(req.Title) > 100 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidTitle, map[string]string{
-			messages.LogTitleLength: strconv.Itoa(len(req.Title)),
+	if v := p.checkContent(req.Title, req.Description, req.Address); v != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrContentRejected, map[string]string{
+			messages.LogUserID: userID.String(),
+			messages.LogRule:   v.Code,
+			messages.LogField:  v.Field,
+			messages.LogMatch:  v.Match,
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidTitle, nil)
-		return
-	}
-
-	if len(req.Description) > 1000 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidDescription, map[string]string{
-			messages.LogDescLength: strconv.Itoa(len(req.Description)),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidDescription, nil)
-		return
-	}
-
-	if len(req.Address) < 5 || len(req.Address) > 200 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidAddress, map[string]string{
-			messages.LogAddressLength: strconv.Itoa(len(req.Address)),
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, violationMessage(v), map[string]string{
+			messages.LogRule:  v.Code,
+			messages.LogField: v.Field,
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidAddress, nil)
 		return
 	}
 
-	if req.Price <= 0 || req.Price > 100_000_000 {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidPrice, map[string]string{
-			messages.LogPrice: strconv.Itoa(req.Price),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidPrice, nil)
+	imageData, lerr := decodeListingImage(req.ImageBase64)
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
 		return
 	}
 
-	imageData, err := base64.StdEncoding.DecodeString(req.ImageBase64)
+	imageURL, err := saveListingImage(imageData, req.ImageName)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrInvalidImage, map[string]string{
+		logger.Error(messages.ServiceListing, messages.LogErrFileSave, map[string]string{
 			messages.LogDetails: err.Error(),
 		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidImage, nil)
-		return
-	}
-
-	const maxFileSize = 5 << 20
-	if len(imageData) > maxFileSize {
-		logger.Error(messages.ServiceListing, messages.LogErrImageTooLarge, map[string]string{
-			messages.LogImageSize: strconv.Itoa(len(imageData)),
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrImageTooLarge, nil)
-		return
-	}
-
-	filetype := http.DetectContentType(imageData[:512])
-	if filetype != "image/jpeg" && filetype != "image/png" {
-		logger.Error(messages.ServiceListing, messages.LogErrUnsupportedImageType, map[string]string{
-			messages.LogImageType: filetype,
-		})
-		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrUnsupportedImageType, nil)
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrFileSave, nil)
 		return
 	}
 
-	filename := uuid.New().String() + filepath.Ext(req.ImageName)
-	savePath := filepath.Join("uploads", filename)
-	if err := os.WriteFile(savePath, imageData, 0644); err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrFileSave, map[string]string{
+	// Без хэша объявление проверяется на дубликаты только по тексту
+	hash, err := imageHash(imageData)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrImageHash, map[string]string{
 			messages.LogDetails: err.Error(),
-			messages.LogPath:    savePath,
 		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrFileSave, nil)
-		return
 	}
-	imageURL := "/uploads/" + filename
 
 	listing := repo.ListingType{
 		ID:          req.ID,
@@ -338,15 +391,17 @@ func (p *ListingHandler) EditListing(w http.ResponseWriter, r *http.Request) {
 		Price:       req.Price,
 		AuthorID:    userID,
 		ImageURL:    imageURL,
+		CategoryID:  req.CategoryID,
+		Attributes:  req.Attributes,
+		ImageHash:   hash,
 	}
 
 	err = p.Listing.EditListing(listing, userID)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
-			messages.LogDetails:   err.Error(),
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
 			messages.LogListingID: req.ID.String(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+			messages.LogUserID:    userID.String(),
+		}, listingErrors)
 		return
 	}
 
@@ -381,11 +436,10 @@ func (p *ListingHandler) DeleteListing(w http.ResponseWriter, r *http.Request) {
 
 	err = p.Listing.DeleteListing(listingID, userID)
 	if err != nil {
-		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
-			messages.LogDetails:   err.Error(),
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
 			messages.LogListingID: listingID.String(),
-		})
-		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+			messages.LogUserID:    userID.String(),
+		}, trashErrors)
 		return
 	}
 
@@ -461,3 +515,318 @@ func (p *ListingHandler) RemoveLike(w http.ResponseWriter, r *http.Request) {
 	})
 	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusLikeRemoved, nil)
 }
+
+// trashErrors сопоставляет ошибки удаления в корзину и восстановления из неё с ответами клиенту
+var trashErrors = []errorMapping{
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrNotListingOwner, http.StatusForbidden, messages.LogErrNotListingOwner, messages.ClientErrNotListingOwner},
+	{repo.ErrNotRestorable, http.StatusConflict, messages.LogErrListingNotRestorable, messages.ClientErrListingNotRestorable},
+}
+
+func (p *ListingHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	page := r.URL.Query().Get(messages.ReqPage)
+	pageInt := 1
+	if page != "" {
+		var err error
+		pageInt, err = strconv.Atoi(page)
+		if err != nil || pageInt < 1 {
+			logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+				messages.LogPage: page,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	listings, totalPages, currentPage, err := p.Listing.GetTrash(userID, pageInt)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		}, trashErrors)
+		return
+	}
+
+	resp := map[string]interface{}{
+		messages.LogListings:    listings,
+		messages.LogTotalPages:  totalPages,
+		messages.LogCurrentPage: currentPage,
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusTrashFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(listings)),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, resp)
+}
+
+func (p *ListingHandler) RestoreListing(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	vars := mux.Vars(r)
+	listingIDStr, ok := vars["id"]
+	if !ok {
+		logger.Error(messages.ServiceListing, messages.LogErrMissingID, nil)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrMissingID, nil)
+		return
+	}
+
+	listingID, err := uuid.Parse(listingIDStr)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	err = p.Listing.RestoreListing(listingID, userID)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+			messages.LogUserID:    userID.String(),
+		}, trashErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusListingRestored, map[string]string{
+		messages.LogListingID: listingID.String(),
+		messages.LogUserID:    userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusListingRestored, nil)
+}
+
+// parseListingFilter разбирает параметры фильтрации объявлений, общие для списка и агрегатов
+// userID - пользователь, от имени которого строится выборка избранного и подписок
+func parseListingFilter(r *http.Request, userID uuid.UUID) (repo.ListingFilter, *listingError) {
+	sortField := r.URL.Query().Get(messages.ReqSortField)
+	sortOrder := r.URL.Query().Get(messages.ReqSortOrder)
+	onlyLikedStr := r.URL.Query().Get(messages.ReqOnlyLiked)
+	targetUserId := r.URL.Query().Get(messages.ReqTargetUserID)
+	minPrice := r.URL.Query().Get(messages.ReqMinPrice)
+	maxPrice := r.URL.Query().Get(messages.ReqMaxPrice)
+
+	minPriceInt, err := strconv.Atoi(minPrice)
+	if err != nil || minPriceInt < 1 || minPriceInt > 100000000 {
+		return repo.ListingFilter{}, &listingError{
+			client:  messages.ClientErrBadRequest,
+			log:     messages.LogErrParamsRequest,
+			details: map[string]string{messages.LogPrice: minPrice},
+		}
+	}
+
+	maxPriceInt, err := strconv.Atoi(maxPrice)
+	if err != nil || maxPriceInt < 1 || maxPriceInt > 100000000 || maxPriceInt < minPriceInt {
+		return repo.ListingFilter{}, &listingError{
+			client:  messages.ClientErrBadRequest,
+			log:     messages.LogErrParamsRequest,
+			details: map[string]string{messages.LogPrice: maxPrice},
+		}
+	}
+
+	onlyLiked := onlyLikedStr == "true"
+
+	categoryID := 0
+	if categoryStr := r.URL.Query().Get(messages.ReqCategoryID); categoryStr != "" {
+		categoryID, err = strconv.Atoi(categoryStr)
+		if err != nil || categoryID < 1 {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrBadRequest,
+				log:     messages.LogErrParamsRequest,
+				details: map[string]string{messages.LogCategoryID: categoryStr},
+			}
+		}
+	}
+
+	attrFilters, err := parseAttributeFilters(r.URL.Query())
+	if err != nil {
+		return repo.ListingFilter{}, &listingError{
+			client:  messages.ClientErrBadRequest,
+			log:     messages.LogErrParamsRequest,
+			details: map[string]string{messages.LogDetails: err.Error()},
+		}
+	}
+
+	var targetUser uuid.UUID
+	if targetUserId != "" {
+		targetUser, err = uuid.Parse(targetUserId)
+		if err != nil {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrInvalidUUID,
+				log:     messages.LogErrInvalidUUID,
+				details: map[string]string{messages.LogDetails: err.Error()},
+			}
+		}
+	}
+
+	var collectionID uuid.UUID
+	if collectionStr := r.URL.Query().Get(messages.ReqCollectionID); collectionStr != "" {
+		collectionID, err = uuid.Parse(collectionStr)
+		if err != nil {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrInvalidUUID,
+				log:     messages.LogErrInvalidUUID,
+				details: map[string]string{messages.LogDetails: err.Error()},
+			}
+		}
+	}
+
+	var createdAfter, createdBefore *time.Time
+	for param, dst := range map[string]**time.Time{
+		messages.ReqCreatedAfter:  &createdAfter,
+		messages.ReqCreatedBefore: &createdBefore,
+	} {
+		value := r.URL.Query().Get(param)
+		if value == "" {
+			continue
+		}
+		t, err := parseFilterTime(value)
+		if err != nil {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrBadRequest,
+				log:     messages.LogErrParamsRequest,
+				details: map[string]string{messages.LogDetails: err.Error()},
+			}
+		}
+		*dst = &t
+	}
+	if createdAfter != nil && createdBefore != nil && !createdAfter.Before(*createdBefore) {
+		return repo.ListingFilter{}, &listingError{
+			client:  messages.ClientErrBadRequest,
+			log:     messages.LogErrParamsRequest,
+			details: map[string]string{messages.LogDetails: "created_after must be earlier than created_before"},
+		}
+	}
+
+	var authorIDs []uuid.UUID
+	if authorsStr := r.URL.Query().Get(messages.ReqAuthorIDs); authorsStr != "" {
+		for _, idStr := range strings.Split(authorsStr, ",") {
+			id, err := uuid.Parse(strings.TrimSpace(idStr))
+			if err != nil {
+				return repo.ListingFilter{}, &listingError{
+					client:  messages.ClientErrInvalidUUID,
+					log:     messages.LogErrInvalidUUID,
+					details: map[string]string{messages.LogDetails: err.Error()},
+				}
+			}
+			authorIDs = append(authorIDs, id)
+		}
+		if len(authorIDs) > maxAuthorFilter {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrBadRequest,
+				log:     messages.LogErrParamsRequest,
+				details: map[string]string{messages.LogDetails: "too many author_ids"},
+			}
+		}
+	}
+
+	minLikes := 0
+	if likesStr := r.URL.Query().Get(messages.ReqMinLikes); likesStr != "" {
+		minLikes, err = strconv.Atoi(likesStr)
+		if err != nil || minLikes < 0 {
+			return repo.ListingFilter{}, &listingError{
+				client:  messages.ClientErrBadRequest,
+				log:     messages.LogErrParamsRequest,
+				details: map[string]string{messages.LogDetails: "invalid min_likes: " + likesStr},
+			}
+		}
+	}
+
+	return repo.ListingFilter{
+		UserID:       userID,
+		TargetUser:   targetUser,
+		SortField:    sortField,
+		SortOrder:    sortOrder,
+		OnlyLiked:    onlyLiked,
+		MinPrice:     minPriceInt,
+		MaxPrice:     maxPriceInt,
+		CategoryID:   categoryID,
+		Attributes:   attrFilters,
+		CollectionID: collectionID,
+		ShareToken:   r.URL.Query().Get(messages.ReqShareToken),
+		Following:    r.URL.Query().Get(messages.ReqFollowing) == "true",
+		Query:        r.URL.Query().Get(messages.ReqQuery),
+
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		AuthorIDs:     authorIDs,
+		MinLikes:      minLikes,
+		HasImage:      r.URL.Query().Get(messages.ReqHasImage) == "true",
+	}, nil
+}
+
+// parseFilterTime разбирает границу периода публикации: момент в RFC 3339 или дату,
+// которая означает начало дня по времени сервера
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected RFC 3339 or YYYY-MM-DD", value)
+	}
+	return t, nil
+}
+
+// parseAttributeFilters разбирает фильтры по атрибутам вида attr.<name>, attr.<name>.min и attr.<name>.max
+func parseAttributeFilters(query url.Values) ([]repo.AttributeFilter, error) {
+	filters := make(map[string]*repo.AttributeFilter)
+	get := func(name string) *repo.AttributeFilter {
+		f, ok := filters[name]
+		if !ok {
+			f = &repo.AttributeFilter{Name: name}
+			filters[name] = f
+		}
+		return f
+	}
+
+	for key, values := range query {
+		if !strings.HasPrefix(key, messages.ReqAttrPrefix) || len(values) == 0 {
+			continue
+		}
+		name := strings.TrimPrefix(key, messages.ReqAttrPrefix)
+		value := values[0]
+
+		switch {
+		case strings.HasSuffix(name, messages.ReqAttrMin), strings.HasSuffix(name, messages.ReqAttrMax):
+			bound, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", key, err)
+			}
+			if strings.HasSuffix(name, messages.ReqAttrMin) {
+				get(strings.TrimSuffix(name, messages.ReqAttrMin)).Min = &bound
+			} else {
+				get(strings.TrimSuffix(name, messages.ReqAttrMax)).Max = &bound
+			}
+		default:
+			get(name).Value = &value
+		}
+	}
+
+	result := make([]repo.AttributeFilter, 0, len(filters))
+	for _, f := range filters {
+		if f.Name == "" {
+			return nil, fmt.Errorf("empty attribute name")
+		}
+		result = append(result, *f)
+	}
+	return result, nil
+}
+
+// GetCategories возвращает категории и схемы их атрибутов
+func (p *ListingHandler) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := p.Listing.GetCategories()
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusCategories, map[string]string{
+		messages.LogCount: strconv.Itoa(len(categories)),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, categories)
+}
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"net/http"
+	"strconv"
+)
+
+// moderationErrors сопоставляет ошибки очереди модерации с ответами клиенту
+var moderationErrors = []errorMapping{
+	{repo.ErrNotModerator, http.StatusForbidden, messages.LogErrNotModerator, messages.ClientErrNotModerator},
+}
+
+// GetDuplicates возвращает модератору совпадения, найденные при публикации объявлений
+func (p *ListingHandler) GetDuplicates(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	page := r.URL.Query().Get(messages.ReqPage)
+	pageInt := 1
+	if page != "" {
+		var err error
+		pageInt, err = strconv.Atoi(page)
+		if err != nil || pageInt < 1 {
+			logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+				messages.LogPage: page,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	matches, totalPages, currentPage, err := p.Listing.GetDuplicates(userID, pageInt)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		}, moderationErrors)
+		return
+	}
+
+	resp := map[string]interface{}{
+		messages.LogMatches:     matches,
+		messages.LogTotalPages:  totalPages,
+		messages.LogCurrentPage: currentPage,
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusDuplicatesFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(matches)),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, resp)
+}
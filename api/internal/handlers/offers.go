@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// offerRequest тело запроса с предложением цены, для принятия и отклонения оно не нужно
+type offerRequest struct {
+	Amount    int       `json:"amount"`
+	Message   string    `json:"message"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// decodeOfferRequest разбирает тело запроса, пустое тело допустимо
+func decodeOfferRequest(r *http.Request) (offerRequest, error) {
+	var req offerRequest
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if errors.Is(err, io.EOF) {
+		return req, nil
+	}
+	return req, err
+}
+
+// offerErrors сопоставляет ошибки предложений с ответами клиенту
+var offerErrors = []errorMapping{
+	{repo.ErrInvalidOffer, http.StatusBadRequest, messages.LogErrInvalidOffer, messages.ClientErrInvalidOffer},
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrOfferNotFound, http.StatusNotFound, messages.LogErrOfferNotFound, messages.ClientErrOfferNotFound},
+	{repo.ErrOfferForbidden, http.StatusForbidden, messages.LogErrOfferForbidden, messages.ClientErrOfferForbidden},
+	{repo.ErrOfferConflict, http.StatusConflict, messages.LogErrOfferConflict, messages.ClientErrOfferConflict},
+}
+
+// writeOfferError отвечает клиенту на ошибку работы с предложением
+func writeOfferError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceListing, err, details, offerErrors)
+}
+
+// MakeOffer создаёт предложение цены покупателя по объявлению
+func (p *ListingHandler) MakeOffer(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	req, err := decodeOfferRequest(r)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	offer, err := p.Listing.MakeOffer(listingID, userID, req.Amount, req.Message, req.ExpiresAt)
+	if err != nil {
+		writeOfferError(w, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+			messages.LogUserID:    userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusOfferCreated, map[string]string{
+		messages.LogListingID: listingID.String(),
+		messages.LogOfferID:   offer.ID.String(),
+		messages.LogUserID:    userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusOfferCreated, offer)
+}
+
+// RespondOffer принимает, отклоняет предложение или делает встречное, действие передаётся в пути запроса
+func (p *ListingHandler) RespondOffer(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+	vars := mux.Vars(r)
+
+	offerID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	req, err := decodeOfferRequest(r)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	action := vars["action"]
+	offer, err := p.Listing.RespondOffer(offerID, userID, action, req.Amount, req.Message, req.ExpiresAt)
+	if err != nil {
+		writeOfferError(w, err, map[string]string{
+			messages.LogOfferID: offerID.String(),
+			messages.LogAction:  action,
+			messages.LogUserID:  userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusOfferResponded, map[string]string{
+		messages.LogOfferID: offerID.String(),
+		messages.LogAction:  action,
+		messages.LogUserID:  userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusOfferUpdated, offer)
+}
+
+// GetOffers возвращает предложения, в которых пользователь покупатель или продавец
+func (p *ListingHandler) GetOffers(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID := uuid.Nil
+	if v := r.URL.Query().Get(messages.ReqListingID); v != "" {
+		var err error
+		listingID, err = uuid.Parse(v)
+		if err != nil {
+			logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+				messages.LogDetails: err.Error(),
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+			return
+		}
+	}
+
+	offers, err := p.Listing.GetOffers(userID, listingID)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogUserID:  userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusOffersFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(offers)),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, offers)
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// OrderHandler обрабатывает запросы заказов и уведомления платёжного провайдера
+type OrderHandler struct {
+	Order         repo.OrderRepo
+	WebhookSecret []byte // Общий с сервисом заказов ключ подписи уведомлений провайдера
+}
+
+// paymentSignatureHeader - заголовок с HMAC-SHA256 подписью тела уведомления
+const paymentSignatureHeader = "X-Payment-Signature"
+
+// maxWebhookSize - максимальный размер тела уведомления
+const maxWebhookSize = 64 << 10
+
+// orderErrors сопоставляет ошибки заказов с ответами клиенту
+var orderErrors = []errorMapping{
+	{repo.ErrInvalidOrder, http.StatusBadRequest, messages.LogErrInvalidOrder, messages.ClientErrInvalidOrder},
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrOrderNotFound, http.StatusNotFound, messages.LogErrOrderNotFound, messages.ClientErrOrderNotFound},
+	{repo.ErrPaymentNotFound, http.StatusNotFound, messages.LogErrPaymentNotFound, messages.ClientErrPaymentNotFound},
+	{repo.ErrOrderForbidden, http.StatusForbidden, messages.LogErrOrderForbidden, messages.ClientErrOrderForbidden},
+	{repo.ErrOrderConflict, http.StatusConflict, messages.LogErrOrderConflict, messages.ClientErrOrderConflict},
+	{repo.ErrPaymentUnavailable, http.StatusServiceUnavailable, messages.LogErrPaymentUnavailable, messages.ClientErrPaymentUnavailable},
+}
+
+// writeOrderError отвечает клиенту на ошибку работы с заказом
+func writeOrderError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceOrder, err, details, orderErrors)
+}
+
+// CreateOrder оформляет заказ на объявление, при переданном offer_id - по принятому предложению цены
+func (p *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	var req struct {
+		ListingID uuid.UUID `json:"listing_id"`
+		OfferID   uuid.UUID `json:"offer_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ListingID == uuid.Nil {
+		details := map[string]string{}
+		if err != nil {
+			details[messages.LogDetails] = err.Error()
+		}
+		logger.Error(messages.ServiceOrder, messages.LogErrParamsRequest, details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	order, err := p.Order.CreateOrder(req.ListingID, userID, req.OfferID)
+	if err != nil {
+		writeOrderError(w, err, map[string]string{
+			messages.LogListingID: req.ListingID.String(),
+			messages.LogUserID:    userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceOrder, messages.LogStatusOrderCreated, map[string]string{
+		messages.LogListingID: req.ListingID.String(),
+		messages.LogOrderID:   order.ID.String(),
+		messages.LogUserID:    userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusOrderCreated, order)
+}
+
+// GetOrder возвращает заказ, в котором пользователь покупатель или продавец
+func (p *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	orderID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceOrder, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	order, err := p.Order.GetOrder(orderID, userID)
+	if err != nil {
+		writeOrderError(w, err, map[string]string{
+			messages.LogOrderID: orderID.String(),
+			messages.LogUserID:  userID.String(),
+		})
+		return
+	}
+
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, order)
+}
+
+// GetOrders возвращает заказы пользователя, параметр role=buyer или role=seller оставляет только покупки или продажи
+func (p *OrderHandler) GetOrders(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+	role := r.URL.Query().Get(messages.ReqRole)
+
+	orders, err := p.Order.GetOrders(userID, role)
+	if err != nil {
+		writeOrderError(w, err, map[string]string{
+			messages.LogUserID: userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceOrder, messages.LogStatusOrdersFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(orders)),
+		messages.LogUserID: userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, orders)
+}
+
+// UpdateOrder отменяет неоплаченный заказ или запрашивает возврат оплаченного, действие передаётся в пути запроса
+func (p *OrderHandler) UpdateOrder(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+	vars := mux.Vars(r)
+
+	orderID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		logger.Error(messages.ServiceOrder, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	action := vars["action"]
+	update := p.Order.CancelOrder
+	if action == "refund" {
+		update = p.Order.RefundOrder
+	}
+
+	order, err := update(orderID, userID)
+	if err != nil {
+		writeOrderError(w, err, map[string]string{
+			messages.LogOrderID: orderID.String(),
+			messages.LogAction:  action,
+			messages.LogUserID:  userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceOrder, messages.LogStatusOrderUpdated, map[string]string{
+		messages.LogOrderID: orderID.String(),
+		messages.LogAction:  action,
+		messages.LogUserID:  userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusOrderUpdated, order)
+}
+
+// PaymentWebhook принимает уведомление платёжного провайдера, проверяет подпись и передаёт его сервису заказов.
+// На повторное уведомление отвечает успехом, чтобы провайдер перестал его присылать
+func (p *OrderHandler) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookSize))
+	if err != nil {
+		logger.Error(messages.ServiceOrder, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	if !validSignature(p.WebhookSecret, body, r.Header.Get(paymentSignatureHeader)) {
+		logger.Error(messages.ServiceOrder, messages.LogErrInvalidSignature, map[string]string{
+			messages.LogReqPath: r.URL.Path,
+		})
+		response.WriteAPIResponse(w, http.StatusUnauthorized, false, messages.ClientErrInvalidSignature, nil)
+		return
+	}
+
+	var event repo.PaymentEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		logger.Error(messages.ServiceOrder, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	details := map[string]string{
+		messages.LogEventID:   event.EventID,
+		messages.LogEventType: event.Type,
+		messages.LogPaymentID: event.PaymentID,
+	}
+
+	order, duplicate, err := p.Order.HandlePaymentWebhook(event)
+	if err != nil {
+		writeOrderError(w, err, details)
+		return
+	}
+
+	details[messages.LogOrderID] = order.ID.String()
+	if duplicate {
+		logger.Info(messages.ServiceOrder, messages.LogStatusWebhookDuplicate, details)
+	} else {
+		logger.Info(messages.ServiceOrder, messages.LogStatusWebhookProcessed, details)
+	}
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusWebhookProcessed, nil)
+}
+
+// validSignature сравнивает подпись уведомления с HMAC-SHA256 его тела
+func validSignature(secret, body []byte, signature string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hmac.Equal(got, mac.Sum(nil))
+}
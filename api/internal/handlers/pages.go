@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"net/http"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxMetaDescription - максимальная длина описания в метатегах, длиннее превью всё равно обрезают
+const maxMetaDescription = 200
+
+// ldCurrency - код валюты по ISO 4217 для разметки schema.org, фиды Яндекса используют устаревший RUR
+const ldCurrency = "RUB"
+
+// pageMeta метаданные страницы для OpenGraph и Twitter карточек
+type pageMeta struct {
+	Title       string
+	Description string
+	URL         string // Канонический адрес страницы
+	Image       string // Абсолютный адрес картинки превью
+	Type        string // og:type
+	Feed        string // Адрес RSS ленты страницы, если она есть
+}
+
+// listingPageData данные страницы объявления
+type listingPageData struct {
+	Meta    pageMeta
+	Listing repo.ListingType
+	LD      map[string]any // Разметка schema.org Product
+}
+
+// sellerPageData данные страницы продавца
+type sellerPageData struct {
+	Meta        pageMeta
+	SellerID    uuid.UUID
+	Login       string
+	Profile     repo.UserProfile
+	Stats       repo.FollowStats
+	Listings    []repo.ListingType
+	CurrentPage int64
+	TotalPages  int64
+	LD          map[string]any // Разметка schema.org ItemList со ссылками на объявления
+}
+
+// listingURL возвращает абсолютный адрес страницы объявления
+func listingURL(id uuid.UUID) string {
+	return publicURL() + "/listings/" + id.String()
+}
+
+// sellerURL возвращает абсолютный адрес страницы продавца
+func sellerURL(id uuid.UUID) string {
+	return publicURL() + "/sellers/" + id.String()
+}
+
+// sellerFeedURL возвращает адрес RSS ленты объявлений продавца
+func sellerFeedURL(id uuid.UUID) string {
+	return publicURL() + "/feeds/sellers/" + id.String() + ".rss"
+}
+
+// absoluteURL дополняет относительный адрес внешним адресом сервиса
+func absoluteURL(path string) string {
+	if path == "" || !strings.HasPrefix(path, "/") {
+		return path
+	}
+	return publicURL() + path
+}
+
+// metaDescription обрезает описание для метатегов по границе символа
+func metaDescription(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= maxMetaDescription {
+		return text
+	}
+	return string([]rune(text)[:maxMetaDescription-1]) + "…"
+}
+
+// availability возвращает доступность товара schema.org по статусу объявления
+func availability(status string) string {
+	switch status {
+	case "reserved":
+		return "https://schema.org/Reserved"
+	case "sold":
+		return "https://schema.org/SoldOut"
+	case "closed":
+		return "https://schema.org/Discontinued"
+	default:
+		return "https://schema.org/InStock"
+	}
+}
+
+// productLD формирует разметку schema.org Product для объявления
+func productLD(listing repo.ListingType) map[string]any {
+	url := listingURL(listing.ID)
+	seller := listing.AuthorLogin
+	if seller == "" {
+		seller = listing.AuthorID.String()
+	}
+
+	ld := map[string]any{
+		"@context":    "https://schema.org",
+		"@type":       "Product",
+		"name":        listing.Title,
+		"description": listing.Description,
+		"url":         url,
+		"offers": map[string]any{
+			"@type":         "Offer",
+			"url":           url,
+			"price":         listing.Price,
+			"priceCurrency": ldCurrency,
+			"availability":  availability(listing.Status),
+			"seller": map[string]any{
+				"@type": "Person",
+				"name":  seller,
+				"url":   sellerURL(listing.AuthorID),
+			},
+		},
+	}
+	if listing.ImageURL != "" {
+		ld["image"] = []string{absoluteURL(listing.ImageURL)}
+	}
+	return ld
+}
+
+// ListingPage отдаёт страницу объявления, собранную на сервере, с метатегами для превью ссылок
+func (p *ListingHandler) ListingPage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	listing, err := p.Listing.GetListing(listingID, userID)
+	if err != nil {
+		writeMappedError(w, messages.ServiceStatic, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+		}, listingLookupErrors)
+		return
+	}
+
+	renderPage(w, r, "listing_page.html", listingPageData{
+		Meta: pageMeta{
+			Title:       listing.Title,
+			Description: metaDescription(listing.Description),
+			URL:         listingURL(listing.ID),
+			Image:       absoluteURL(listing.ImageURL),
+			Type:        "product",
+		},
+		Listing: listing,
+		LD:      productLD(listing),
+	})
+}
+
+// SellerPage отдаёт страницу продавца с его объявлениями, собранную на сервере
+func (p *ListingHandler) SellerPage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	sellerID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	page := 1
+	if pageStr := r.URL.Query().Get(messages.ReqPage); pageStr != "" {
+		var err error
+		page, err = strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			logger.Error(messages.ServiceStatic, messages.LogErrParamsRequest, map[string]string{
+				messages.LogPage: pageStr,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	profile, err := p.User.GetUser(sellerID)
+	if err != nil {
+		writeProfileError(w, err, map[string]string{
+			messages.LogSellerID: sellerID.String(),
+		})
+		return
+	}
+
+	stats, err := p.Listing.GetFollowStats(userID, sellerID)
+	if err != nil {
+		writeFollowError(w, err, map[string]string{
+			messages.LogSellerID: sellerID.String(),
+		})
+		return
+	}
+
+	listings, totalPages, currentPage, err := p.Listing.GetAllListings(repo.ListingFilter{
+		UserID:     userID,
+		TargetUser: sellerID,
+		MinPrice:   1,
+		MaxPrice:   100_000_000,
+		Page:       page,
+	})
+	if err != nil {
+		logger.Error(messages.ServiceStatic, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails:  err.Error(),
+			messages.LogSellerID: sellerID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	login := profile.Name()
+
+	items := make([]map[string]any, 0, len(listings))
+	for i, listing := range listings {
+		items = append(items, map[string]any{
+			"@type":    "ListItem",
+			"position": i + 1,
+			"url":      listingURL(listing.ID),
+		})
+	}
+
+	data := sellerPageData{
+		Meta: pageMeta{
+			Title:       login,
+			Description: metaDescription(profile.Bio),
+			URL:         sellerURL(sellerID),
+			Type:        "profile",
+			Feed:        sellerFeedURL(sellerID),
+		},
+		SellerID:    sellerID,
+		Login:       login,
+		Profile:     profile,
+		Stats:       stats,
+		Listings:    listings,
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+		LD: map[string]any{
+			"@context":        "https://schema.org",
+			"@type":           "ItemList",
+			"name":            login,
+			"url":             sellerURL(sellerID),
+			"itemListElement": items,
+		},
+	}
+	// Превью страницы - аватар продавца, а без него - картинка первого объявления
+	if profile.AvatarURL != "" {
+		data.Meta.Image = absoluteURL(profile.AvatarURL)
+	} else if len(listings) > 0 {
+		data.Meta.Image = absoluteURL(listings[0].ImageURL)
+	}
+
+	renderPage(w, r, "seller.html", data)
+}
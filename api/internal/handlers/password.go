@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"api/internal/encryption"
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// passwordErrors сопоставляет ошибки смены и сброса пароля с ответами клиенту
+var passwordErrors = []errorMapping{
+	{repo.ErrWrongPassword, http.StatusForbidden, messages.LogErrWrongPassword, messages.ClientErrWrongPassword},
+	{repo.ErrUserNotFound, http.StatusNotFound, messages.LogErrUserNotFound, messages.ClientErrUserNotFound},
+	{repo.ErrInvalidResetToken, http.StatusBadRequest, messages.LogErrInvalidResetToken, messages.ClientErrInvalidResetToken},
+}
+
+// decryptPassword расшифровывает пароль, присланный клиентом так же, как при входе. Новый пароль
+// проверяется по правилам регистрации. При ошибке сам отвечает клиенту
+func (p *AuthHandler) decryptPassword(w http.ResponseWriter, encrypted string, isNew bool) (string, bool) {
+	password, err := encryption.DecryptData(encrypted, p.secret)
+	if err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrDecryption, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDecryption, nil)
+		return "", false
+	}
+
+	if isNew && !isValidPassword(password) {
+		logger.Info(messages.ServiceAuth, messages.LogErrInvalidPass, nil)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidPass, nil)
+		return "", false
+	}
+	return password, true
+}
+
+// legacyPassword шифрует пароль ключом сервера так, как он сохранялся до перехода на хэши.
+// Сервис пользователей сверяет с ним пароли ещё не обновлённых учётных записей. При ошибке
+// сам отвечает клиенту
+func legacyPassword(w http.ResponseWriter, password string) (string, bool) {
+	encrypted, err := encryption.EncryptData(password, string(serverSecretKey))
+	if err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrEncryption, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrEncryption, nil)
+		return "", false
+	}
+	return encrypted, true
+}
+
+// ChangePassword меняет пароль текущего пользователя по старому паролю и завершает
+// все его сессии, кроме текущей
+func (p *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	// Сессия уже проверена middleware, токен разбирается ещё раз, чтобы оставить её активной
+	token, err := p.Token.ParseJWT(r.Header.Get(messages.AuthToken))
+	if err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrParseToken, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrBadToken, nil)
+		return
+	}
+
+	var requestData map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	oldPassword, ok := p.decryptPassword(w, requestData[messages.ReqOldPassword], false)
+	if !ok {
+		return
+	}
+	legacyOldPassword, ok := legacyPassword(w, oldPassword)
+	if !ok {
+		return
+	}
+	newPassword, ok := p.decryptPassword(w, requestData[messages.ReqNewPassword], true)
+	if !ok {
+		return
+	}
+
+	details := map[string]string{messages.LogUserID: userID.String()}
+	err = p.User.ChangePassword(userID, oldPassword, legacyOldPassword, newPassword)
+	if err != nil {
+		writeMappedError(w, messages.ServiceAuth, err, details, passwordErrors)
+		return
+	}
+
+	revoked, err := p.Session.DeleteUserSessions(userID, token.SessionID)
+	if err != nil {
+		details[messages.LogDetails] = err.Error()
+		logger.Error(messages.ServiceAuth, messages.LogErrRevokeSessions, details)
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrRevokeSessions, nil)
+		return
+	}
+
+	details[messages.LogRevoked] = strconv.Itoa(revoked)
+	logger.Info(messages.ServiceAuth, messages.LogStatusPasswordChanged, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusPasswordChanged, map[string]int{
+		messages.LogRevoked: revoked,
+	})
+}
+
+// RequestPasswordReset отправляет письмо со ссылкой сброса пароля. Ответ одинаковый для существующих
+// и несуществующих пользователей, чтобы по нему нельзя было проверить логин
+func (p *AuthHandler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var requestData map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	username := requestData[messages.ReqUsername]
+	if !isValidUsername(username) {
+		logger.Error(messages.ServiceAuth, messages.LogErrInvalidUsername, map[string]string{
+			messages.LogUsername: username,
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUsername, nil)
+		return
+	}
+
+	details := map[string]string{messages.LogUsername: username}
+	if err := p.User.RequestPasswordReset(username); err != nil {
+		details[messages.LogDetails] = err.Error()
+		logger.Error(messages.ServiceAuth, messages.LogErrDBQuery, details)
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	logger.Info(messages.ServiceAuth, messages.LogStatusPasswordResetRequested, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusPasswordResetRequested, nil)
+}
+
+// ResetPassword задаёт новый пароль по токену из письма и завершает все сессии пользователя
+func (p *AuthHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var requestData map[string]string
+	if err := json.NewDecoder(r.Body).Decode(&requestData); err != nil {
+		logger.Error(messages.ServiceAuth, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	resetToken := requestData[messages.ReqToken]
+	if resetToken == "" {
+		logger.Error(messages.ServiceAuth, messages.LogErrInvalidResetToken, nil)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidResetToken, nil)
+		return
+	}
+
+	newPassword, ok := p.decryptPassword(w, requestData[messages.ReqNewPassword], true)
+	if !ok {
+		return
+	}
+
+	userID, err := p.User.ResetPassword(resetToken, newPassword)
+	if err != nil {
+		writeMappedError(w, messages.ServiceAuth, err, map[string]string{}, passwordErrors)
+		return
+	}
+
+	details := map[string]string{messages.LogUserID: userID.String()}
+	revoked, err := p.Session.DeleteUserSessions(userID, uuid.Nil)
+	if err != nil {
+		details[messages.LogDetails] = err.Error()
+		logger.Error(messages.ServiceAuth, messages.LogErrRevokeSessions, details)
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrRevokeSessions, nil)
+		return
+	}
+
+	details[messages.LogRevoked] = strconv.Itoa(revoked)
+	logger.Info(messages.ServiceAuth, messages.LogStatusPasswordReset, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusPasswordReset, nil)
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// promotionErrors сопоставляет ошибки продвижения с ответами клиенту
+var promotionErrors = []errorMapping{
+	{repo.ErrInvalidPromotion, http.StatusBadRequest, messages.LogErrInvalidPromotion, messages.ClientErrInvalidPromotion},
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrNotListingOwner, http.StatusForbidden, messages.LogErrNotListingOwner, messages.ClientErrNotListingOwner},
+}
+
+// CreatePromotion создаёт платное продвижение объявления его автором
+func (p *ListingHandler) CreatePromotion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	var req struct {
+		Type     string    `json:"type"`
+		StartsAt time.Time `json:"starts_at"`
+		EndsAt   time.Time `json:"ends_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	promotion, err := p.Listing.CreatePromotion(listingID, userID, req.Type, req.StartsAt, req.EndsAt)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+			messages.LogUserID:    userID.String(),
+		}, promotionErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusPromotionCreated, map[string]string{
+		messages.LogListingID:   listingID.String(),
+		messages.LogPromotionID: promotion.ID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusPromotionCreated, promotion)
+}
+
+// GetPromotions возвращает продвижения объявлений текущего пользователя
+func (p *ListingHandler) GetPromotions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	promotions, err := p.Listing.GetPromotions(userID)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogUserID:  userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusPromotionsFetched, map[string]string{
+		messages.LogUserID: userID.String(),
+		messages.LogCount:  strconv.Itoa(len(promotions)),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, promotions)
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// questionErrors сопоставляет ошибки вопросов с ответами клиенту
+var questionErrors = []errorMapping{
+	{repo.ErrInvalidQuestion, http.StatusBadRequest, messages.LogErrInvalidQuestion, messages.ClientErrInvalidQuestion},
+	{repo.ErrListingNotFound, http.StatusNotFound, messages.LogErrListingNotFound, messages.ClientErrListingNotFound},
+	{repo.ErrQuestionNotFound, http.StatusNotFound, messages.LogErrQuestionNotFound, messages.ClientErrQuestionNotFound},
+	{repo.ErrQuestionForbidden, http.StatusForbidden, messages.LogErrQuestionForbidden, messages.ClientErrQuestionForbidden},
+	{repo.ErrNotModerator, http.StatusForbidden, messages.LogErrNotModerator, messages.ClientErrNotModerator},
+}
+
+// writeQuestionError отвечает клиенту на ошибку работы с вопросом
+func writeQuestionError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceListing, err, details, questionErrors)
+}
+
+// decodeQuestionText разбирает тело запроса с текстом вопроса или ответа
+func decodeQuestionText(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return "", false
+	}
+	return req.Text, true
+}
+
+// AskQuestion задаёт продавцу публичный вопрос по объявлению
+func (p *ListingHandler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+	text, ok := decodeQuestionText(w, r)
+	if !ok {
+		return
+	}
+
+	question, err := p.Listing.AskQuestion(listingID, userID, text)
+	if err != nil {
+		writeQuestionError(w, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+			messages.LogUserID:    userID.String(),
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusQuestionCreated, map[string]string{
+		messages.LogListingID:  listingID.String(),
+		messages.LogQuestionID: question.ID.String(),
+		messages.LogUserID:     userID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusCreated, true, messages.StatusQuestionCreated, question)
+}
+
+// AnswerQuestion публикует ответ автора объявления на вопрос
+func (p *ListingHandler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	questionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+	text, ok := decodeQuestionText(w, r)
+	if !ok {
+		return
+	}
+
+	details := map[string]string{
+		messages.LogQuestionID: questionID.String(),
+		messages.LogUserID:     userID.String(),
+	}
+	question, err := p.Listing.AnswerQuestion(questionID, userID, text)
+	if err != nil {
+		writeQuestionError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusQuestionAnswered, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusQuestionAnswered, question)
+}
+
+// GetQuestions возвращает вопросы по объявлению: всем - отвеченные, автору - ещё и ожидающие ответа
+func (p *ListingHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	page := r.URL.Query().Get(messages.ReqPage)
+	pageInt := 1
+	if page != "" {
+		var err error
+		pageInt, err = strconv.Atoi(page)
+		if err != nil || pageInt < 1 {
+			logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+				messages.LogPage: page,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	questions, totalPages, currentPage, err := p.Listing.GetQuestions(listingID, userID, pageInt)
+	if err != nil {
+		writeQuestionError(w, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+		})
+		return
+	}
+
+	resp := map[string]interface{}{
+		messages.LogQuestions:   questions,
+		messages.LogTotalPages:  totalPages,
+		messages.LogCurrentPage: currentPage,
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusQuestionsFetched, map[string]string{
+		messages.LogCount:     strconv.Itoa(len(questions)),
+		messages.LogListingID: listingID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, resp)
+}
+
+// ModerateQuestion скрывает вопрос (hide) или возвращает его в выдачу (show), действие передаётся в пути запроса
+func (p *ListingHandler) ModerateQuestion(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	questionID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+	action := mux.Vars(r)["action"]
+
+	details := map[string]string{
+		messages.LogQuestionID: questionID.String(),
+		messages.LogAction:     action,
+		messages.LogUserID:     userID.String(),
+	}
+	question, err := p.Listing.HideQuestion(questionID, userID, action == "hide")
+	if err != nil {
+		writeQuestionError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusQuestionHidden, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusQuestionUpdated, question)
+}
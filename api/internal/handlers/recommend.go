@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/response"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// maxRecommendations максимальное количество объявлений в рекомендациях
+const maxRecommendations = 50
+
+// GetSimilarListings возвращает объявления, похожие на данное
+func (p *ListingHandler) GetSimilarListings(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	limit, ok := recommendationLimit(w, r)
+	if !ok {
+		return
+	}
+
+	listings, err := p.Listing.GetSimilarListings(listingID, userID, limit)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+		}, listingLookupErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusSimilarFetched, map[string]string{
+		messages.LogListingID: listingID.String(),
+		messages.LogCount:     strconv.Itoa(len(listings)),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, map[string]interface{}{
+		messages.LogListings: listings,
+	})
+}
+
+// GetRecommendations возвращает персональную ленту «для вас» на основе лайков пользователя
+func (p *ListingHandler) GetRecommendations(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	limit, ok := recommendationLimit(w, r)
+	if !ok {
+		return
+	}
+
+	listings, err := p.Listing.GetRecommendations(userID, limit)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogUserID:  userID.String(),
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusForYouFetched, map[string]string{
+		messages.LogUserID: userID.String(),
+		messages.LogCount:  strconv.Itoa(len(listings)),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, map[string]interface{}{
+		messages.LogListings: listings,
+	})
+}
+
+// RecordView учитывает просмотр объявления в трендовой сортировке
+func (p *ListingHandler) RecordView(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	listingID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrInvalidUUID, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrInvalidUUID, nil)
+		return
+	}
+
+	err = p.Listing.RecordView(listingID, userID)
+	if err != nil {
+		writeMappedError(w, messages.ServiceListing, err, map[string]string{
+			messages.LogListingID: listingID.String(),
+		}, listingLookupErrors)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusViewRecorded, map[string]string{
+		messages.LogListingID: listingID.String(),
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, nil)
+}
+
+// recommendationLimit разбирает необязательный параметр limit, 0 - размер страницы по умолчанию
+func recommendationLimit(w http.ResponseWriter, r *http.Request) (int, bool) {
+	limitStr := r.URL.Query().Get(messages.ReqLimit)
+	if limitStr == "" {
+		return 0, true
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 || limit > maxRecommendations {
+		logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+			messages.ReqLimit: limitStr,
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return 0, false
+	}
+	return limit, true
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// syndicationItems - сколько последних объявлений попадает в RSS и Atom ленты
+const syndicationItems = 50
+
+// listingFeed лента последних объявлений, общая для форматов RSS и Atom
+type listingFeed struct {
+	Title    string
+	Link     string // Страница сайта, которую описывает лента
+	Self     string // Адрес самой ленты
+	Listings []repo.ListingType
+}
+
+type rssItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description,omitempty"`
+	GUID        rssGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssDocument struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	Channel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+		Items         []rssItem `xml:"item"`
+	} `xml:"channel"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string     `xml:"title"`
+	ID        string     `xml:"id"`
+	Link      atomLink   `xml:"link"`
+	Published string     `xml:"published"`
+	Updated   string     `xml:"updated"`
+	Author    atomAuthor `xml:"author"`
+	Summary   string     `xml:"summary,omitempty"`
+}
+
+type atomDocument struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// sellerName возвращает логин автора объявления, а если он неизвестен - идентификатор
+func sellerName(listing repo.ListingType) string {
+	if listing.AuthorLogin != "" {
+		return listing.AuthorLogin
+	}
+	return listing.AuthorID.String()
+}
+
+// updated возвращает время публикации самого нового объявления ленты. Время сборки в документ
+// не пишется, иначе пересобранная без изменений лента сбрасывала бы Last-Modified
+func (f listingFeed) updated() time.Time {
+	var newest time.Time
+	for _, listing := range f.Listings {
+		if listing.CreatedAt.After(newest) {
+			newest = listing.CreatedAt
+		}
+	}
+	return newest.UTC()
+}
+
+func (f listingFeed) rss() ([]byte, error) {
+	doc := rssDocument{Version: "2.0"}
+	doc.Channel.Title = f.Title
+	doc.Channel.Link = f.Link
+	doc.Channel.Description = f.Title
+	if updated := f.updated(); !updated.IsZero() {
+		doc.Channel.LastBuildDate = updated.Format(time.RFC1123Z)
+	}
+
+	for _, listing := range f.Listings {
+		link := listingURL(listing.ID)
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       listing.Title,
+			Link:        link,
+			Description: listing.Description,
+			GUID:        rssGUID{IsPermaLink: true, Value: link},
+			PubDate:     listing.CreatedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return encodeXML(doc)
+}
+
+func (f listingFeed) atom() ([]byte, error) {
+	updated := f.updated()
+	if updated.IsZero() {
+		updated = time.Now().UTC()
+	}
+
+	doc := atomDocument{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		Title:   f.Title,
+		ID:      f.Self,
+		Updated: updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: f.Link, Rel: "alternate"},
+			{Href: f.Self, Rel: "self"},
+		},
+	}
+
+	for _, listing := range f.Listings {
+		link := listingURL(listing.ID)
+		published := listing.CreatedAt.UTC().Format(time.RFC3339)
+		doc.Entries = append(doc.Entries, atomEntry{
+			Title:     listing.Title,
+			ID:        link,
+			Link:      atomLink{Href: link, Rel: "alternate"},
+			Published: published,
+			Updated:   published,
+			Author:    atomAuthor{Name: sellerName(listing), URI: sellerURL(listing.AuthorID)},
+			Summary:   metaDescription(listing.Description),
+		})
+	}
+	return encodeXML(doc)
+}
+
+// serveListingFeed отдаёт ленту в формате из пути запроса, собирая её через кэш
+func (p *ListingHandler) serveListingFeed(w http.ResponseWriter, r *http.Request, key string, details map[string]string, build func() (listingFeed, error)) {
+	format := mux.Vars(r)["format"]
+	contentType := "application/rss+xml; charset=utf-8"
+	if format == messages.FeedAtom {
+		contentType = "application/atom+xml; charset=utf-8"
+	}
+	details[messages.LogFormat] = format
+
+	err := p.Feeds.serve(w, r, format+":"+key, contentType, func() ([]byte, error) {
+		feed, err := build()
+		if err != nil {
+			return nil, err
+		}
+		if format == messages.FeedAtom {
+			return feed.atom()
+		}
+		return feed.rss()
+	})
+	if err != nil {
+		writeFeedError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusFeedServed, details)
+}
+
+// ListingsFeed отдаёт RSS или Atom ленту новых объявлений. Параметры запроса те же, что у GET /api/listings,
+// поэтому сохранённый у клиента фильтр (категория, цена, атрибуты, открытая подборка) превращается в ленту
+// подстановкой его параметров. Лента анонимная: избранное и подписки в ней не учитываются
+func (p *ListingHandler) ListingsFeed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get(messages.ReqMinPrice) == "" {
+		query.Set(messages.ReqMinPrice, "1")
+	}
+	if query.Get(messages.ReqMaxPrice) == "" {
+		query.Set(messages.ReqMaxPrice, "100000000")
+	}
+	// Лента всегда начинается с новых объявлений
+	for _, param := range []string{messages.ReqPage, messages.ReqSortField, messages.ReqSortOrder} {
+		query.Del(param)
+	}
+	r.URL.RawQuery = query.Encode()
+
+	filter, lerr := parseListingFilter(r, middleware.GetContext(r.Context()))
+	if lerr != nil {
+		logger.Error(messages.ServiceListing, lerr.log, lerr.details)
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+		return
+	}
+	filter.UserID = uuid.Nil
+	filter.Page = 1
+	filter.PageSize = syndicationItems
+
+	// Параметры отсортированы Encode, поэтому одинаковые фильтры попадают в одну запись кэша
+	p.serveListingFeed(w, r, "listings?"+r.URL.RawQuery, map[string]string{}, func() (listingFeed, error) {
+		listings, _, _, err := p.Listing.GetAllListings(filter)
+		if err != nil {
+			return listingFeed{}, err
+		}
+
+		link := publicURL() + "/"
+		if len(r.URL.RawQuery) > 0 {
+			link += "?" + r.URL.RawQuery
+		}
+		return listingFeed{
+			Title:    messages.FeedShopName,
+			Link:     link,
+			Self:     publicURL() + r.URL.RequestURI(),
+			Listings: listings,
+		}, nil
+	})
+}
+
+// SellerFeed отдаёт RSS или Atom ленту новых объявлений продавца
+func (p *ListingHandler) SellerFeed(w http.ResponseWriter, r *http.Request) {
+	sellerID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	details := map[string]string{messages.LogSellerID: sellerID.String()}
+	p.serveListingFeed(w, r, "sellers/"+sellerID.String(), details, func() (listingFeed, error) {
+		// Профиль нужен для названия ленты и заодно проверяет, что продавец существует,
+		// чтобы не отдавать пустую ленту по опечатке в ссылке
+		profile, err := p.User.GetUser(sellerID)
+		if err != nil {
+			return listingFeed{}, err
+		}
+
+		listings, _, _, err := p.Listing.GetAllListings(repo.ListingFilter{
+			TargetUser: sellerID,
+			MinPrice:   1,
+			MaxPrice:   100_000_000,
+			Page:       1,
+			PageSize:   syndicationItems,
+		})
+		if err != nil {
+			return listingFeed{}, err
+		}
+
+		return listingFeed{
+			Title:    profile.Name() + " - " + messages.FeedShopName,
+			Link:     sellerURL(sellerID),
+			Self:     publicURL() + r.URL.Path,
+			Listings: listings,
+		}, nil
+	})
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/repo"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// sitemapShardSize - сколько объявлений попадает в одну часть карты сайта. Протокол допускает
+// до 50 000 адресов, но часть собирается одним запросом, а сервис объявлений отдаёт не больше 1000
+const sitemapShardSize = 1000
+
+// maxFeedCacheEntries - предел числа документов в кэше, ленты по фильтрам могут иметь любые параметры
+const maxFeedCacheEntries = 1000
+
+const sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// errSitemapNotFound - запрошена часть карты сайта за пределами каталога
+var errSitemapNotFound = errors.New("sitemap shard not found")
+
+type cachedFeed struct {
+	body        []byte
+	contentType string
+	modified    time.Time // Когда содержимое документа последний раз изменилось
+	expires     time.Time
+}
+
+// FeedCache хранит собранные карты сайта и RSS/Atom ленты. Время изменения документа сохраняется,
+// пока пересобранное содержимое совпадает с прежним, поэтому If-Modified-Since продолжает получать 304
+type FeedCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedFeed
+}
+
+// NewFeedCache создаёт кэш документов с заданным временем жизни
+func NewFeedCache(ttl time.Duration) *FeedCache {
+	return &FeedCache{ttl: ttl, entries: make(map[string]cachedFeed)}
+}
+
+// serve отдаёт документ из кэша, при необходимости собирая его заново. Заголовки If-Modified-Since
+// и HEAD запросы обрабатывает http.ServeContent
+func (c *FeedCache) serve(w http.ResponseWriter, r *http.Request, key, contentType string, build func() ([]byte, error)) error {
+	now := time.Now()
+
+	c.mu.Lock()
+	doc, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if !ok || now.After(doc.expires) {
+		body, err := build()
+		if err != nil {
+			return err
+		}
+
+		modified := now.Truncate(time.Second)
+		if ok && bytes.Equal(body, doc.body) {
+			modified = doc.modified
+		}
+		doc = cachedFeed{body: body, contentType: contentType, modified: modified, expires: now.Add(c.ttl)}
+
+		c.mu.Lock()
+		if len(c.entries) >= maxFeedCacheEntries {
+			c.evict(now)
+		}
+		c.entries[key] = doc
+		c.mu.Unlock()
+	}
+
+	w.Header().Set("Content-Type", doc.contentType)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(c.ttl.Seconds())))
+	http.ServeContent(w, r, "", doc.modified, bytes.NewReader(doc.body))
+	return nil
+}
+
+// evict удаляет устаревшие документы, а если кэш всё ещё полон - очищает его целиком
+func (c *FeedCache) evict(now time.Time) {
+	for key, doc := range c.entries {
+		if now.After(doc.expires) {
+			delete(c.entries, key)
+		}
+	}
+	if len(c.entries) >= maxFeedCacheEntries {
+		clear(c.entries)
+	}
+}
+
+// encodeXML сериализует документ с XML заголовком
+func encodeXML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// feedErrors сопоставляет ошибки карты сайта и лент с ответами клиенту
+var feedErrors = []errorMapping{
+	{errSitemapNotFound, http.StatusNotFound, messages.LogErrSitemapNotFound, messages.ClientErrSitemapNotFound},
+	{repo.ErrUserNotFound, http.StatusNotFound, messages.LogErrUserNotFound, messages.ClientErrUserNotFound},
+	{repo.ErrCollectionNotFound, http.StatusNotFound, messages.LogErrCollectionNotFound, messages.ClientErrCollectionNotFound},
+	{repo.ErrInvalidAttributes, http.StatusBadRequest, messages.LogErrInvalidAttributes, messages.ClientErrInvalidAttributes},
+	{repo.ErrInvalidRequest, http.StatusBadRequest, messages.LogErrParamsRequest, messages.ClientErrBadRequest},
+}
+
+// writeFeedError отвечает клиенту на ошибку сборки карты сайта или ленты
+func writeFeedError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceListing, err, details, feedErrors)
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	XMLNS    string       `xml:"xmlns,attr"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
+// sitemapFilter выбирает часть каталога для карты сайта. Объявления идут от старых к новым,
+// чтобы новые публикации дописывались в последнюю часть, не сдвигая остальные
+func sitemapFilter(shard int) repo.ListingFilter {
+	return repo.ListingFilter{
+		SortField: "created_at",
+		SortOrder: "ASC",
+		MinPrice:  1,
+		MaxPrice:  100_000_000,
+		Page:      shard,
+		PageSize:  sitemapShardSize,
+	}
+}
+
+// sitemapShardURL возвращает адрес части карты сайта
+func sitemapShardURL(shard int64) string {
+	return publicURL() + "/sitemaps/listings-" + strconv.FormatInt(shard, 10) + ".xml"
+}
+
+// listingsURLSet собирает карту сайта из страницы объявлений
+func listingsURLSet(listings []repo.ListingType) sitemapURLSet {
+	set := sitemapURLSet{XMLNS: sitemapNS, URLs: make([]sitemapURL, 0, len(listings))}
+	for _, listing := range listings {
+		set.URLs = append(set.URLs, sitemapURL{
+			Loc:     listingURL(listing.ID),
+			LastMod: listing.CreatedAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return set
+}
+
+// Sitemap отдаёт карту сайта. Пока каталог помещается в одну часть, это обычный список адресов,
+// иначе - индекс со ссылками на части /sitemaps/listings-{n}.xml
+func (p *ListingHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	err := p.Feeds.serve(w, r, "sitemap", "application/xml; charset=utf-8", func() ([]byte, error) {
+		listings, totalPages, _, err := p.Listing.GetAllListings(sitemapFilter(1))
+		if err != nil {
+			return nil, err
+		}
+		if totalPages <= 1 {
+			return encodeXML(listingsURLSet(listings))
+		}
+
+		index := sitemapIndex{XMLNS: sitemapNS}
+		for shard := int64(1); shard <= totalPages; shard++ {
+			index.Sitemaps = append(index.Sitemaps, sitemapURL{Loc: sitemapShardURL(shard)})
+		}
+		return encodeXML(index)
+	})
+	if err != nil {
+		writeFeedError(w, err, map[string]string{
+			messages.LogFormat: messages.FeedSitemap,
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusSitemapServed, map[string]string{})
+}
+
+// SitemapShard отдаёт часть карты сайта с объявлениями
+func (p *ListingHandler) SitemapShard(w http.ResponseWriter, r *http.Request) {
+	shardStr := mux.Vars(r)["shard"]
+	shard, err := strconv.Atoi(shardStr)
+	if err != nil || shard < 1 {
+		writeFeedError(w, errSitemapNotFound, map[string]string{
+			messages.LogShard: shardStr,
+		})
+		return
+	}
+
+	err = p.Feeds.serve(w, r, "sitemap-"+strconv.Itoa(shard), "application/xml; charset=utf-8", func() ([]byte, error) {
+		listings, _, currentPage, err := p.Listing.GetAllListings(sitemapFilter(shard))
+		if err != nil {
+			return nil, err
+		}
+		// Сервис возвращает последнюю страницу вместо несуществующей
+		if currentPage != int64(shard) || len(listings) == 0 {
+			return nil, errSitemapNotFound
+		}
+		return encodeXML(listingsURLSet(listings))
+	})
+	if err != nil {
+		writeFeedError(w, err, map[string]string{
+			messages.LogShard: shardStr,
+		})
+		return
+	}
+
+	logger.Info(messages.ServiceListing, messages.LogStatusSitemapServed, map[string]string{
+		messages.LogShard: shardStr,
+	})
+}
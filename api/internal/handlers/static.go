@@ -1,19 +1,83 @@
 package handlers
 
 import (
+	"api/internal/i18n"
 	"api/internal/logger"
 	"api/internal/messages"
 	"api/internal/response"
+	"bytes"
+	"fmt"
 	"html/template"
 	"net/http"
+	"path/filepath"
 )
 
-// serveHTML обрабатывает запрос на отдачу HTML страницы
-func serveHTML(w http.ResponseWriter, r *http.Request, filename string) {
-	tmpl, err := template.ParseFiles("assets/html/" + filename)
+// pageFuncs возвращает функции шаблонов страниц для языка запроса:
+// t переводит строку интерфейса, lang и locales описывают текущий и доступные языки, ui передаёт строки в скрипты,
+// pageRange перечисляет номера страниц для пагинации
+func pageFuncs(locale string) template.FuncMap {
+	return template.FuncMap{
+		"t": func(key string) string {
+			return i18n.Translate(locale, key)
+		},
+		"lang": func() string {
+			return locale
+		},
+		"locales": i18n.Locales,
+		"ui": func() map[string]string {
+			return i18n.UI(locale)
+		},
+		"pageRange": func(total int64) []int64 {
+			pages := make([]int64, total)
+			for i := range pages {
+				pages[i] = int64(i) + 1
+			}
+			return pages
+		},
+	}
+}
+
+// pages хранит разобранные при запуске шаблоны страниц по языку и имени файла
+var pages map[string]map[string]*template.Template
+
+// LoadPages разбирает шаблоны страниц из каталога dir для каждого языка, общие части страниц
+// берутся из dir/partials. Вызывается один раз при запуске, изменения шаблонов применяются после перезапуска
+func LoadPages(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.html"))
 	if err != nil {
+		return err
+	}
+	partials, err := filepath.Glob(filepath.Join(dir, "partials", "*.html"))
+	if err != nil {
+		return err
+	}
+
+	loaded := make(map[string]map[string]*template.Template)
+	for _, locale := range i18n.Locales() {
+		loaded[locale] = make(map[string]*template.Template)
+		for _, file := range files {
+			name := filepath.Base(file)
+			tmpl, err := template.New(name).Funcs(pageFuncs(locale)).ParseFiles(append([]string{file}, partials...)...)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			loaded[locale][name] = tmpl
+		}
+	}
+	pages = loaded
+	return nil
+}
+
+// serveHTML обрабатывает запрос на отдачу HTML страницы на языке пользователя
+func serveHTML(w http.ResponseWriter, r *http.Request, filename string) {
+	renderPage(w, r, filename, nil)
+}
+
+// renderPage отдаёт страницу на языке пользователя, заполненную данными data
+func renderPage(w http.ResponseWriter, r *http.Request, filename string, data any) {
+	tmpl, ok := pages[i18n.FromContext(r.Context())][filename]
+	if !ok {
 		logger.Error(messages.ServiceStatic, messages.LogErrLoadTemplate, map[string]string{
-			messages.LogDetails:  err.Error(),
 			messages.LogReqPath:  r.URL.Path,
 			messages.LogFilename: filename,
 		})
@@ -21,8 +85,9 @@ func serveHTML(w http.ResponseWriter, r *http.Request, filename string) {
 		return
 	}
 
-	err = tmpl.Execute(w, nil)
-	if err != nil {
+	// Страница собирается целиком до отправки, чтобы при ошибке клиент не получил её половину
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
 		logger.Error(messages.ServiceStatic, messages.LogErrRenderTemplate, map[string]string{
 			messages.LogDetails:  err.Error(),
 			messages.LogReqPath:  r.URL.Path,
@@ -32,6 +97,9 @@ func serveHTML(w http.ResponseWriter, r *http.Request, filename string) {
 		return
 	}
 
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+
 	logger.Info(messages.ServiceStatic, messages.LogStatusPageServed, map[string]string{
 		messages.LogReqPath:  r.URL.Path,
 		messages.LogFilename: filename,
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"api/internal/contentfilter"
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/response"
+	"net/http"
+	"strconv"
+)
+
+// maxSuggestions максимальное количество подсказок поиска
+const maxSuggestions = 20
+
+// suggestionQuery - вид подсказки, составленной из чужого поискового запроса
+const suggestionQuery = "query"
+
+// SuggestListings подсказывает по началу ввода популярные поисковые запросы и названия объявлений.
+// Запросы попадают в подсказки, когда их ищут через GET /api/listings?query=
+func (p *ListingHandler) SuggestListings(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get(messages.ReqPrefix)
+
+	limit := 0
+	if limitStr := r.URL.Query().Get(messages.ReqLimit); limitStr != "" {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 1 || limit > maxSuggestions {
+			logger.Error(messages.ServiceListing, messages.LogErrParamsRequest, map[string]string{
+				messages.ReqLimit: limitStr,
+			})
+			response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+			return
+		}
+	}
+
+	suggestions, err := p.Listing.SuggestListings(prefix, limit)
+	if err != nil {
+		logger.Error(messages.ServiceListing, messages.LogErrDBQuery, map[string]string{
+			messages.LogDetails: err.Error(),
+			messages.LogPrefix:  prefix,
+		})
+		response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrDBQuery, nil)
+		return
+	}
+
+	// Прошлые запросы пишут пользователи, поэтому они проверяются фильтром так же, как названия объявлений
+	allowed := suggestions[:0]
+	for _, sg := range suggestions {
+		if sg.Kind == suggestionQuery && p.Filter.Check(map[string]string{contentfilter.FieldTitle: sg.Text}) != nil {
+			continue
+		}
+		allowed = append(allowed, sg)
+	}
+	suggestions = allowed
+
+	logger.Info(messages.ServiceListing, messages.LogStatusSuggestionsFetched, map[string]string{
+		messages.LogCount:  strconv.Itoa(len(suggestions)),
+		messages.LogPrefix: prefix,
+	})
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, suggestions)
+}
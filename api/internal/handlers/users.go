@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"api/internal/logger"
+	"api/internal/messages"
+	"api/internal/middleware"
+	"api/internal/repo"
+	"api/internal/response"
+	"encoding/json"
+	"net/http"
+)
+
+// UserHandler обрабатывает запросы профилей пользователей
+type UserHandler struct {
+	User repo.UserRepo // Репозиторий пользователей
+}
+
+// profileErrors сопоставляет ошибки профиля с ответами клиенту
+var profileErrors = []errorMapping{
+	{repo.ErrInvalidProfile, http.StatusBadRequest, messages.LogErrInvalidProfile, messages.ClientErrInvalidProfile},
+	{repo.ErrUserNotFound, http.StatusNotFound, messages.LogErrUserNotFound, messages.ClientErrUserNotFound},
+}
+
+// writeProfileError отвечает клиенту на ошибку работы с профилем
+func writeProfileError(w http.ResponseWriter, err error, details map[string]string) {
+	writeMappedError(w, messages.ServiceUser, err, details, profileErrors)
+}
+
+// GetUser возвращает публичный профиль пользователя
+func (p *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	userID, ok := parsePathID(w, r)
+	if !ok {
+		return
+	}
+
+	details := map[string]string{messages.LogUserID: userID.String()}
+	profile, err := p.User.GetUser(userID)
+	if err != nil {
+		writeProfileError(w, err, details)
+		return
+	}
+	// Профиль публичный, почта в нём не показывается
+	profile.Email = ""
+
+	logger.Info(messages.ServiceUser, messages.LogStatusProfileFetched, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusSuccess, profile)
+}
+
+// UpdateProfile изменяет профиль текущего пользователя. Поля, которых нет в запросе, не изменяются.
+// Аватар загружается так же, как изображение объявления, пустой avatar_base64 удаляет его
+func (p *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.GetContext(r.Context())
+
+	var req struct {
+		repo.ProfileUpdate
+		AvatarBase64 *string `json:"avatar_base64"`
+		AvatarName   string  `json:"avatar_name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error(messages.ServiceUser, messages.LogErrParamsRequest, map[string]string{
+			messages.LogDetails: err.Error(),
+		})
+		response.WriteAPIResponse(w, http.StatusBadRequest, false, messages.ClientErrBadRequest, nil)
+		return
+	}
+
+	update := req.ProfileUpdate
+	if req.AvatarBase64 != nil {
+		var avatarURL string
+		if *req.AvatarBase64 != "" {
+			imageData, lerr := decodeListingImage(*req.AvatarBase64)
+			if lerr != nil {
+				logger.Error(messages.ServiceUser, lerr.log, lerr.details)
+				response.WriteAPIResponse(w, http.StatusBadRequest, false, lerr.client, nil)
+				return
+			}
+
+			var err error
+			avatarURL, err = saveListingImage(imageData, req.AvatarName)
+			if err != nil {
+				logger.Error(messages.ServiceUser, messages.LogErrFileSave, map[string]string{
+					messages.LogDetails: err.Error(),
+				})
+				response.WriteAPIResponse(w, http.StatusInternalServerError, false, messages.ClientErrFileSave, nil)
+				return
+			}
+		}
+		update.AvatarURL = &avatarURL
+	}
+
+	details := map[string]string{messages.LogUserID: userID.String()}
+	profile, err := p.User.UpdateProfile(userID, update)
+	if err != nil {
+		writeProfileError(w, err, details)
+		return
+	}
+
+	logger.Info(messages.ServiceUser, messages.LogStatusProfileUpdated, details)
+	response.WriteAPIResponse(w, http.StatusOK, true, messages.StatusProfileUpdated, profile)
+}
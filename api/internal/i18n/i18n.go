@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"log"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLocale - язык, на котором отвечает API, если язык пользователя не поддерживается
+const DefaultLocale = "ru"
+
+// CookieName - cookie с языком, выбранным пользователем в интерфейсе
+const CookieName = "lang"
+
+// uiPrefix - префикс ключей строк интерфейса, которые передаются в скрипты страниц
+const uiPrefix = "ui."
+
+//go:embed locales/*.json
+var localesFS embed.FS
+
+var (
+	catalogs = make(map[string]map[string]string) // Язык -> код сообщения -> текст
+	locales  []string                             // Поддерживаемые языки, язык по умолчанию первый
+	matcher  language.Matcher
+)
+
+type contextKey struct{}
+
+// init загружает каталоги сообщений из встроенных файлов locales/<язык>.json
+func init() {
+	files, err := localesFS.ReadDir("locales")
+	if err != nil {
+		log.Fatalf("failed to read message catalogs: %v", err)
+	}
+
+	for _, file := range files {
+		data, err := localesFS.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			log.Fatalf("failed to read message catalog %s: %v", file.Name(), err)
+		}
+
+		var catalog map[string]string
+		if err := json.Unmarshal(data, &catalog); err != nil {
+			log.Fatalf("failed to parse message catalog %s: %v", file.Name(), err)
+		}
+		catalogs[strings.TrimSuffix(file.Name(), path.Ext(file.Name()))] = catalog
+	}
+
+	if _, ok := catalogs[DefaultLocale]; !ok {
+		log.Fatalf("message catalog for default locale %s is missing", DefaultLocale)
+	}
+
+	locales = append(locales, DefaultLocale)
+	for locale := range catalogs {
+		if locale != DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	slices.Sort(locales[1:])
+
+	tags := make([]language.Tag, 0, len(locales))
+	for _, locale := range locales {
+		tags = append(tags, language.MustParse(locale))
+	}
+	matcher = language.NewMatcher(tags)
+}
+
+// Locales возвращает поддерживаемые языки
+func Locales() []string {
+	return locales
+}
+
+// Translate возвращает текст сообщения на языке пользователя.
+// Если перевода нет, используется язык по умолчанию, а если нет и его - сам код
+func Translate(locale, code string) string {
+	if text, ok := catalogs[locale][code]; ok {
+		return text
+	}
+	if text, ok := catalogs[DefaultLocale][code]; ok {
+		return text
+	}
+	return code
+}
+
+// Known сообщает, есть ли код в каталоге языка по умолчанию
+func Known(code string) bool {
+	_, ok := catalogs[DefaultLocale][code]
+	return ok
+}
+
+// UI возвращает строки интерфейса для скриптов страниц
+func UI(locale string) map[string]string {
+	strs := make(map[string]string)
+	for code := range catalogs[DefaultLocale] {
+		if strings.HasPrefix(code, uiPrefix) {
+			strs[code] = Translate(locale, code)
+		}
+	}
+	return strs
+}
+
+// Negotiate выбирает язык запроса: сначала язык, выбранный пользователем, затем Accept-Language
+func Negotiate(r *http.Request) string {
+	if cookie, err := r.Cookie(CookieName); err == nil {
+		if _, ok := catalogs[cookie.Value]; ok {
+			return cookie.Value
+		}
+	}
+
+	tags, _, err := language.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if err != nil || len(tags) == 0 {
+		return DefaultLocale
+	}
+	_, idx, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DefaultLocale
+	}
+	return locales[idx]
+}
+
+// localeWriter запоминает язык запроса, чтобы ответы API переводились без передачи запроса
+type localeWriter struct {
+	http.ResponseWriter
+	locale string
+}
+
+func (w *localeWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *localeWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Middleware определяет язык запроса и сохраняет его в контексте и в ResponseWriter
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		locale := Negotiate(r)
+
+		w.Header().Set("Content-Language", locale)
+		w.Header().Add("Vary", "Accept-Language, Cookie")
+
+		ctx := context.WithValue(r.Context(), contextKey{}, locale)
+		next.ServeHTTP(&localeWriter{ResponseWriter: w, locale: locale}, r.WithContext(ctx))
+	})
+}
+
+// FromContext возвращает язык запроса
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(contextKey{}).(string); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// FromWriter возвращает язык запроса, которому отвечает ResponseWriter
+func FromWriter(w http.ResponseWriter) string {
+	if lw, ok := w.(*localeWriter); ok {
+		return lw.locale
+	}
+	return DefaultLocale
+}
@@ -2,12 +2,15 @@ package messages
 
 // Константы для идентификации сервисов
 const (
-	ServiceEncryption  = "encryption"
-	ServiceHealthcheck = "healthcheck"
-	ServiceMiddleware  = "middleware"
-	ServiceAuth        = "auth"
-	ServiceListing     = "listing"
-	ServiceStatic      = "static"
+	ServiceEncryption    = "encryption"
+	ServiceHealthcheck   = "healthcheck"
+	ServiceMiddleware    = "middleware"
+	ServiceAuth          = "auth"
+	ServiceListing       = "listing"
+	ServiceStatic        = "static"
+	ServiceContentFilter = "content_filter"
+	ServiceOrder         = "order"
+	ServiceUser          = "user"
 )
 
 // Константы для шифрования
@@ -49,6 +52,32 @@ const (
 	LogImageURL      = "image_url"
 	LogListingID     = "listing_id"
 	LogPage          = "page"
+	LogFormat        = "format"
+	LogRows          = "rows"
+	LogJobID         = "job_id"
+	LogFeedItems     = "feed_items"
+	LogCategoryID    = "category_id"
+	LogPromotionID   = "promotion_id"
+	LogAttribute     = "attribute"
+	LogMatches       = "matches"
+	LogRule          = "rule"
+	LogField         = "field"
+	LogMatch         = "match"
+	LogOfferID       = "offer_id"
+	LogAction        = "action"
+	LogAmount        = "amount"
+	LogOrderID       = "order_id"
+	LogPaymentID     = "payment_id"
+	LogEventID       = "event_id"
+	LogEventType     = "event_type"
+	LogCollectionID  = "collection_id"
+	LogSellerID      = "seller_id"
+	LogQuestionID    = "question_id"
+	LogQuestions     = "questions"
+	LogShard         = "shard"
+	LogPrefix        = "prefix"
+	LogHistory       = "history"
+	LogRevoked       = "revoked_sessions"
 )
 
 // healthcheck
@@ -64,15 +93,55 @@ const (
 
 // Поля запросов
 const (
-	ReqUsername     = "username"
-	ReqPassword     = "password"
-	ReqSortField    = "sort_field"
-	ReqSortOrder    = "sort_order"
-	ReqOnlyLiked    = "only_liked"
-	ReqTargetUserID = "target_user_id"
-	ReqPage         = "page"
-	ReqMinPrice     = "min_price"
-	ReqMaxPrice     = "max_price"
+	ReqUsername      = "username"
+	ReqPassword      = "password"
+	ReqSortField     = "sort_field"
+	ReqSortOrder     = "sort_order"
+	ReqOnlyLiked     = "only_liked"
+	ReqTargetUserID  = "target_user_id"
+	ReqPage          = "page"
+	ReqMinPrice      = "min_price"
+	ReqMaxPrice      = "max_price"
+	ReqFormat        = "format"
+	ReqToken         = "token"
+	ReqCategoryID    = "category_id"
+	ReqAttrPrefix    = "attr."
+	ReqAttrMin       = ".min"
+	ReqAttrMax       = ".max"
+	ReqPriceBounds   = "price_bounds"
+	ReqPriceBuckets  = "price_buckets"
+	ReqLimit         = "limit"
+	ReqListingID     = "listing_id"
+	ReqRole          = "role"
+	ReqCollectionID  = "collection_id"
+	ReqShareToken    = "share_token"
+	ReqFollowing     = "following"
+	ReqPrefix        = "prefix"
+	ReqQuery         = "query"
+	ReqCreatedAfter  = "created_after"
+	ReqCreatedBefore = "created_before"
+	ReqAuthorIDs     = "author_ids"
+	ReqMinLikes      = "min_likes"
+	ReqHasImage      = "has_image"
+	ReqOldPassword   = "old_password"
+	ReqNewPassword   = "new_password"
+)
+
+// Форматы импорта объявлений
+const (
+	ImportFormatCSV   = "csv"
+	ImportFormatJSONL = "jsonl"
+)
+
+// Фиды для маркетплейсов
+const (
+	FeedAvito    = "avito"
+	FeedYandex   = "yandex"
+	FeedShopName = "vk-internship"
+	FeedCurrency = "RUR"
+	FeedRSS      = "rss"
+	FeedAtom     = "atom"
+	FeedSitemap  = "sitemap"
 )
 
 // Токен авторизации
@@ -80,39 +149,86 @@ const (
 	AuthToken = "AuthToken"
 )
 
-// Клиентские ошибки (краткие, понятные пользователю)
+// Коды клиентских ошибок, тексты на языках пользователей находятся в каталогах i18n
 const (
-	ClientErrAuth                 = "неверное имя пользователя или пароль"
-	ClientErrBadRequest           = "некорректный запрос"
-	ClientErrNoPermission         = "нет прав доступа"
-	ClientErrSessionExpired       = "сессия истекла"
-	ClientErrSessionCreation      = "ошибка создания сессии"
-	ClientErrUserNotFound         = "пользователь не найден"
-	ClientErrInvalidPublicKey     = "некорректный публичный ключ"
-	ClientErrDecryption           = "ошибка расшифрования данных"
-	ClientErrEncryption           = "ошибка шифрования данных"
-	ClientErrPageLoad             = "ошибка загрузки страницы"
-	ClientErrNoParams             = "отсутствуют необходимые параметры"
-	ClientErrNoCookie             = "требуется авторизация"
-	ClientErrBadToken             = "некорректный токен"
-	ClientErrNoSession            = "сессия не найдена"
-	ClientErrCreateAccount        = "ошибка создания аккаунта"
-	ClientErrUserExists           = "пользователь с таким именем уже существует"
-	ClientErrNoToken              = "требуется токен авторизации"
-	ClientErrInvalidUsername      = "неверный формат логина"
-	ClientErrInvalidPass          = "неверный формат пароля"
-	ClientErrInvalidUUID          = "неверный формат UUID"
-	ClientErrDBQuery              = "ошибка запроса к базе данных"
-	ClientErrMissingFields        = "отсутствуют обязательные поля в запросе"
-	ClientErrInvalidTitle         = "неверный заголовок объявления"
-	ClientErrInvalidDescription   = "неверное описание объявления"
-	ClientErrInvalidPrice         = "неверная цена объявления"
-	ClientErrInvalidImage         = "неверный формат изображения"
-	ClientErrImageTooLarge        = "размер изображения превышает лимит"
-	ClientErrUnsupportedImageType = "неподдерживаемый тип изображения"
-	ClientErrFileSave             = "ошибка сохранения файла"
-	ClientErrInvalidAddress       = "неверный адрес"
-	ClientErrMissingID            = "отсутствует ID в запросе"
+	ClientErrAuth                 = "invalid_credentials"
+	ClientErrBadRequest           = "bad_request"
+	ClientErrNoPermission         = "no_permission"
+	ClientErrSessionExpired       = "session_expired"
+	ClientErrSessionCreation      = "session_creation_failed"
+	ClientErrUserNotFound         = "user_not_found"
+	ClientErrInvalidPublicKey     = "invalid_public_key"
+	ClientErrDecryption           = "decryption_failed"
+	ClientErrEncryption           = "encryption_failed"
+	ClientErrPageLoad             = "page_load_failed"
+	ClientErrNoParams             = "no_params"
+	ClientErrNoCookie             = "auth_required"
+	ClientErrBadToken             = "bad_token"
+	ClientErrNoSession            = "no_session"
+	ClientErrCreateAccount        = "account_creation_failed"
+	ClientErrUserExists           = "user_exists"
+	ClientErrNoToken              = "token_required"
+	ClientErrInvalidUsername      = "invalid_username"
+	ClientErrInvalidPass          = "invalid_password"
+	ClientErrInvalidUUID          = "invalid_uuid"
+	ClientErrDBQuery              = "db_query_failed"
+	ClientErrMissingFields        = "missing_fields"
+	ClientErrInvalidTitle         = "invalid_title"
+	ClientErrInvalidDescription   = "invalid_description"
+	ClientErrInvalidPrice         = "invalid_price"
+	ClientErrInvalidImage         = "invalid_image"
+	ClientErrImageTooLarge        = "image_too_large"
+	ClientErrUnsupportedImageType = "unsupported_image_type"
+	ClientErrFileSave             = "file_save_failed"
+	ClientErrInvalidAddress       = "invalid_address"
+	ClientErrMissingID            = "missing_id"
+	ClientErrImportFormat         = "unsupported_import_format"
+	ClientErrImportEmpty          = "import_empty"
+	ClientErrImportTooLarge       = "import_too_large"
+	ClientErrImportParse          = "import_parse_failed"
+	ClientErrFeedToken            = "invalid_feed_token"
+	ClientErrInvalidAttributes    = "invalid_attributes"
+	ClientErrListingNotFound      = "listing_not_found"
+	ClientErrNotListingOwner      = "not_listing_owner"
+	ClientErrInvalidPromotion     = "invalid_promotion"
+	ClientErrDuplicateListing     = "duplicate_listing"
+	ClientErrNotModerator         = "not_moderator"
+	ClientErrContentRejected      = "content_rejected"
+	ClientErrInvalidOffer         = "invalid_offer"
+	ClientErrOfferNotFound        = "offer_not_found"
+	ClientErrOfferForbidden       = "offer_forbidden"
+	ClientErrOfferConflict        = "offer_conflict"
+	ClientErrInvalidAuction       = "invalid_auction"
+	ClientErrAuctionNotFound      = "auction_not_found"
+	ClientErrInvalidBid           = "invalid_bid"
+	ClientErrBidForbidden         = "bid_forbidden"
+	ClientErrAuctionClosed        = "auction_closed"
+	ClientErrBidLeading           = "bid_already_leading"
+	ClientErrBidConflict          = "bid_conflict"
+	ClientErrOrderNotFound        = "order_not_found"
+	ClientErrInvalidOrder         = "invalid_order"
+	ClientErrOrderForbidden       = "order_forbidden"
+	ClientErrOrderConflict        = "order_conflict"
+	ClientErrPaymentUnavailable   = "payment_unavailable"
+	ClientErrInvalidSignature     = "invalid_signature"
+	ClientErrPaymentNotFound      = "payment_not_found"
+	ClientErrInvalidCollection    = "invalid_collection"
+	ClientErrCollectionNotFound   = "collection_not_found"
+	ClientErrCollectionExists     = "collection_exists"
+	ClientErrCollectionLimit      = "collection_limit"
+	ClientErrInvalidFollow        = "invalid_follow"
+	ClientErrInvalidQuestion      = "invalid_question"
+	ClientErrQuestionNotFound     = "question_not_found"
+	ClientErrQuestionForbidden    = "question_forbidden"
+	ClientErrSitemapNotFound      = "sitemap_not_found"
+	ClientErrHistoryForbidden     = "history_forbidden"
+	ClientErrInvalidProfile       = "invalid_profile"
+	ClientErrWrongPassword        = "wrong_password"
+	ClientErrInvalidResetToken    = "invalid_reset_token"
+	ClientErrRevokeSessions       = "sessions_revoke_failed"
+	ClientErrListingNotRestorable = "listing_not_restorable"
+	ClientErrImportJobNotFound    = "import_job_not_found"
+	ClientErrImportJobForbidden   = "import_job_forbidden"
 )
 
 // Логи ошибок (подробные, для отладки)
@@ -155,33 +271,151 @@ const (
 	LogErrFileSave             = "failed to save file"
 	LogErrInvalidAddress       = "invalid address"
 	LogErrMissingID            = "missing ID in request"
+	LogErrImportFormat         = "unsupported import format"
+	LogErrImportEmpty          = "import file has no rows"
+	LogErrImportTooLarge       = "import file exceeds limits"
+	LogErrImportParse          = "failed to parse import file"
+	LogErrFeedToken            = "invalid feed token"
+	LogErrFeedWrite            = "failed to write feed"
+	LogErrInvalidAttributes    = "invalid category or attributes"
+	LogErrListingNotFound      = "listing not found"
+	LogErrNotListingOwner      = "user is not the owner of the listing"
+	LogErrInvalidPromotion     = "invalid promotion"
+	LogErrImageHash            = "failed to compute image hash"
+	LogErrDuplicateListing     = "listing rejected as duplicate"
+	LogErrNotModerator         = "user is not a moderator"
+	LogErrContentRejected      = "listing rejected by content filter"
+	LogErrContentFilterReload  = "failed to reload content filter rules, keeping previous rules"
+	LogErrInvalidOffer         = "invalid offer"
+	LogErrOfferNotFound        = "offer not found"
+	LogErrOfferForbidden       = "offer action forbidden"
+	LogErrOfferConflict        = "offer state conflict"
+	LogErrInvalidAuction       = "invalid auction settings"
+	LogErrAuctionNotFound      = "auction not found"
+	LogErrInvalidBid           = "invalid bid"
+	LogErrBidForbidden         = "bid forbidden"
+	LogErrAuctionClosed        = "auction closed"
+	LogErrBidLeading           = "bidder already leads"
+	LogErrBidConflict          = "concurrent bid conflict"
+	LogErrOrderNotFound        = "order not found"
+	LogErrInvalidOrder         = "invalid order"
+	LogErrOrderForbidden       = "order action forbidden"
+	LogErrOrderConflict        = "order state conflict"
+	LogErrPaymentUnavailable   = "payment provider unavailable"
+	LogErrInvalidSignature     = "invalid webhook signature"
+	LogErrPaymentNotFound      = "payment not found"
+	LogErrInvalidCollection    = "invalid collection"
+	LogErrCollectionNotFound   = "collection not found"
+	LogErrCollectionExists     = "collection name already taken"
+	LogErrCollectionLimit      = "collection limit reached"
+	LogErrInvalidFollow        = "invalid follow request"
+	LogErrUserNotFound         = "user not found"
+	LogErrInvalidQuestion      = "invalid question"
+	LogErrQuestionNotFound     = "question not found"
+	LogErrQuestionForbidden    = "question action forbidden"
+	LogErrSitemapNotFound      = "sitemap shard not found"
+	LogErrHistoryForbidden     = "listing history forbidden"
+	LogErrInvalidProfile       = "invalid profile"
+	LogErrWrongPassword        = "wrong password"
+	LogErrInvalidResetToken    = "invalid password reset token"
+	LogErrRevokeSessions       = "failed to revoke user sessions"
+	LogErrListingNotRestorable = "listing cannot be restored"
+	LogErrImportAppend         = "failed to pass import rows to listing service"
+	LogErrFileRemove           = "failed to remove file"
+	LogErrImportJobNotFound    = "import job not found"
+	LogErrImportJobForbidden   = "import job belongs to another user"
 )
 
-// Статусы успешных операций для клиента
+// Коды статусов успешных операций для клиента, тексты находятся в каталогах i18n
 const (
-	StatusSuccess        = "операция выполнена успешно"
-	StatusAuth           = "авторизация успешна"
-	StatusLogOut         = "выход выполнен"
-	StatusListingAdded   = "объявление добавлено успешно"
-	StatusListingEdited  = "объявление отредактировано успешно"
-	StatusListingDeleted = "объявление удалено успешно"
-	StatusLikeAdded      = "лайк добавлен успешно"
-	StatusLikeRemoved    = "лайк удалён успешно"
+	StatusSuccess                = "success"
+	StatusAuth                   = "authorized"
+	StatusLogOut                 = "logged_out"
+	StatusListingAdded           = "listing_added"
+	StatusListingEdited          = "listing_edited"
+	StatusListingDeleted         = "listing_deleted"
+	StatusLikeAdded              = "like_added"
+	StatusLikeRemoved            = "like_removed"
+	StatusListingRestored        = "listing_restored"
+	StatusPromotionCreated       = "promotion_created"
+	StatusImportStarted          = "import_started"
+	StatusOfferCreated           = "offer_created"
+	StatusOfferUpdated           = "offer_updated"
+	StatusBidPlaced              = "bid_placed"
+	StatusOrderCreated           = "order_created"
+	StatusOrderUpdated           = "order_updated"
+	StatusWebhookProcessed       = "webhook_processed"
+	StatusCollectionCreated      = "collection_created"
+	StatusCollectionUpdated      = "collection_updated"
+	StatusCollectionDeleted      = "collection_deleted"
+	StatusFollowed               = "followed"
+	StatusUnfollowed             = "unfollowed"
+	StatusQuestionCreated        = "question_created"
+	StatusQuestionAnswered       = "question_answered"
+	StatusQuestionUpdated        = "question_updated"
+	StatusProfileUpdated         = "profile_updated"
+	StatusPasswordChanged        = "password_changed"
+	StatusPasswordResetRequested = "password_reset_requested"
+	StatusPasswordReset          = "password_reset"
 )
 
 // Статусы для логирования успешных операций
 const (
-	LogStatusUserAuth        = "user authenticated"
-	LogStatusUserLogOut      = "user logged out"
-	LogStatusParamsSent      = "crypto params sent successfully"
-	LogStatusKeyDerived      = "shared key derived successfully"
-	LogStatusEncryption      = "data encrypted successfully"
-	LogStatusDecryption      = "data decrypted successfully"
-	LogStatusPageServed      = "page served successfully"
-	LogStatusListingsFetched = "listings fetched successfully"
-	LogStatusListingAdded    = "listing added successfully"
-	LogStatusListingEdited   = "listing edited successfully"
-	LogStatusListingDeleted  = "listing deleted successfully"
-	LogStatusLikeAdded       = "like added successfully"
-	LogStatusLikeRemoved     = "like removed successfully"
+	LogStatusUserAuth               = "user authenticated"
+	LogStatusUserLogOut             = "user logged out"
+	LogStatusParamsSent             = "crypto params sent successfully"
+	LogStatusKeyDerived             = "shared key derived successfully"
+	LogStatusEncryption             = "data encrypted successfully"
+	LogStatusDecryption             = "data decrypted successfully"
+	LogStatusPageServed             = "page served successfully"
+	LogStatusListingsFetched        = "listings fetched successfully"
+	LogStatusListingAdded           = "listing added successfully"
+	LogStatusListingEdited          = "listing edited successfully"
+	LogStatusListingDeleted         = "listing deleted successfully"
+	LogStatusLikeAdded              = "like added successfully"
+	LogStatusLikeRemoved            = "like removed successfully"
+	LogStatusTrashFetched           = "trash fetched successfully"
+	LogStatusListingRestored        = "listing restored successfully"
+	LogStatusImportStarted          = "import job started"
+	LogStatusImportFetched          = "import job fetched successfully"
+	LogStatusFeedServed             = "feed served successfully"
+	LogStatusFeedToken              = "feed token issued"
+	LogStatusCategories             = "categories fetched successfully"
+	LogStatusFacetsFetched          = "listing facets fetched successfully"
+	LogStatusSimilarFetched         = "similar listings fetched successfully"
+	LogStatusForYouFetched          = "recommendations fetched successfully"
+	LogStatusViewRecorded           = "listing view recorded"
+	LogStatusPromotionCreated       = "promotion created successfully"
+	LogStatusPromotionsFetched      = "promotions fetched successfully"
+	LogStatusDuplicatesFetched      = "duplicate matches fetched successfully"
+	LogStatusContentFilterReloaded  = "content filter rules reloaded"
+	LogStatusOfferCreated           = "offer created successfully"
+	LogStatusOfferResponded         = "offer responded successfully"
+	LogStatusOffersFetched          = "offers fetched successfully"
+	LogStatusBidPlaced              = "bid placed successfully"
+	LogStatusAuctionFetched         = "auction fetched successfully"
+	LogStatusOrderCreated           = "order created successfully"
+	LogStatusOrderUpdated           = "order updated successfully"
+	LogStatusOrdersFetched          = "orders fetched successfully"
+	LogStatusWebhookProcessed       = "payment webhook processed successfully"
+	LogStatusWebhookDuplicate       = "duplicate payment webhook ignored"
+	LogStatusCollectionCreated      = "collection created successfully"
+	LogStatusCollectionUpdated      = "collection updated successfully"
+	LogStatusCollectionDeleted      = "collection deleted successfully"
+	LogStatusCollectionsFetched     = "collections fetched successfully"
+	LogStatusFollowed               = "user followed successfully"
+	LogStatusUnfollowed             = "user unfollowed successfully"
+	LogStatusQuestionCreated        = "question created successfully"
+	LogStatusQuestionAnswered       = "question answered successfully"
+	LogStatusQuestionHidden         = "question visibility changed successfully"
+	LogStatusQuestionsFetched       = "questions fetched successfully"
+	LogStatusSitemapServed          = "sitemap served successfully"
+	LogStatusSuggestionsFetched     = "suggestions fetched successfully"
+	LogStatusHistoryFetched         = "listing history fetched successfully"
+	LogStatusProfileFetched         = "user profile fetched successfully"
+	LogStatusProfileUpdated         = "user profile updated successfully"
+	LogStatusPasswordChanged        = "password changed successfully"
+	LogStatusPasswordResetRequested = "password reset requested"
+	LogStatusPasswordReset          = "password reset successfully"
+	LogStatusImportProcessed        = "import job rows processed"
 )
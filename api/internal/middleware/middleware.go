@@ -85,8 +85,11 @@ func (p *MiddlewareHandler) CheckSesWithNilOnError(next http.Handler) http.Handl
 	})
 }
 
-// GetContext извлекает ID пользователя из контекста
+// GetContext извлекает ID пользователя из контекста, без сессии возвращает uuid.Nil
 func GetContext(ctx context.Context) (userID uuid.UUID) {
-	userID = ctx.Value(userKey).(uuid.UUID)
+	userID, ok := ctx.Value(userKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
 	return userID
 }
@@ -19,12 +19,50 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ListingService_GetAllListings_FullMethodName = "/listingpb.ListingService/GetAllListings"
-	ListingService_AddListing_FullMethodName     = "/listingpb.ListingService/AddListing"
-	ListingService_EditListing_FullMethodName    = "/listingpb.ListingService/EditListing"
-	ListingService_DeleteListing_FullMethodName  = "/listingpb.ListingService/DeleteListing"
-	ListingService_AddLike_FullMethodName        = "/listingpb.ListingService/AddLike"
-	ListingService_RemoveLike_FullMethodName     = "/listingpb.ListingService/RemoveLike"
+	ListingService_GetAllListings_FullMethodName       = "/listingpb.ListingService/GetAllListings"
+	ListingService_GetListing_FullMethodName           = "/listingpb.ListingService/GetListing"
+	ListingService_AddListing_FullMethodName           = "/listingpb.ListingService/AddListing"
+	ListingService_EditListing_FullMethodName          = "/listingpb.ListingService/EditListing"
+	ListingService_DeleteListing_FullMethodName        = "/listingpb.ListingService/DeleteListing"
+	ListingService_AddLike_FullMethodName              = "/listingpb.ListingService/AddLike"
+	ListingService_RemoveLike_FullMethodName           = "/listingpb.ListingService/RemoveLike"
+	ListingService_GetTrash_FullMethodName             = "/listingpb.ListingService/GetTrash"
+	ListingService_RestoreListing_FullMethodName       = "/listingpb.ListingService/RestoreListing"
+	ListingService_CreateImportJob_FullMethodName      = "/listingpb.ListingService/CreateImportJob"
+	ListingService_AppendImportRows_FullMethodName     = "/listingpb.ListingService/AppendImportRows"
+	ListingService_GetImportJob_FullMethodName         = "/listingpb.ListingService/GetImportJob"
+	ListingService_GetFeedToken_FullMethodName         = "/listingpb.ListingService/GetFeedToken"
+	ListingService_StreamFeed_FullMethodName           = "/listingpb.ListingService/StreamFeed"
+	ListingService_GetCategories_FullMethodName        = "/listingpb.ListingService/GetCategories"
+	ListingService_GetListingFacets_FullMethodName     = "/listingpb.ListingService/GetListingFacets"
+	ListingService_GetSimilarListings_FullMethodName   = "/listingpb.ListingService/GetSimilarListings"
+	ListingService_GetRecommendations_FullMethodName   = "/listingpb.ListingService/GetRecommendations"
+	ListingService_RecordView_FullMethodName           = "/listingpb.ListingService/RecordView"
+	ListingService_CreatePromotion_FullMethodName      = "/listingpb.ListingService/CreatePromotion"
+	ListingService_GetPromotions_FullMethodName        = "/listingpb.ListingService/GetPromotions"
+	ListingService_GetDuplicates_FullMethodName        = "/listingpb.ListingService/GetDuplicates"
+	ListingService_MakeOffer_FullMethodName            = "/listingpb.ListingService/MakeOffer"
+	ListingService_RespondOffer_FullMethodName         = "/listingpb.ListingService/RespondOffer"
+	ListingService_GetOffers_FullMethodName            = "/listingpb.ListingService/GetOffers"
+	ListingService_PlaceBid_FullMethodName             = "/listingpb.ListingService/PlaceBid"
+	ListingService_GetAuction_FullMethodName           = "/listingpb.ListingService/GetAuction"
+	ListingService_CreateCollection_FullMethodName     = "/listingpb.ListingService/CreateCollection"
+	ListingService_RenameCollection_FullMethodName     = "/listingpb.ListingService/RenameCollection"
+	ListingService_DeleteCollection_FullMethodName     = "/listingpb.ListingService/DeleteCollection"
+	ListingService_GetCollections_FullMethodName       = "/listingpb.ListingService/GetCollections"
+	ListingService_AddToCollection_FullMethodName      = "/listingpb.ListingService/AddToCollection"
+	ListingService_RemoveFromCollection_FullMethodName = "/listingpb.ListingService/RemoveFromCollection"
+	ListingService_ShareCollection_FullMethodName      = "/listingpb.ListingService/ShareCollection"
+	ListingService_GetSharedCollection_FullMethodName  = "/listingpb.ListingService/GetSharedCollection"
+	ListingService_FollowUser_FullMethodName           = "/listingpb.ListingService/FollowUser"
+	ListingService_UnfollowUser_FullMethodName         = "/listingpb.ListingService/UnfollowUser"
+	ListingService_GetFollowStats_FullMethodName       = "/listingpb.ListingService/GetFollowStats"
+	ListingService_AskQuestion_FullMethodName          = "/listingpb.ListingService/AskQuestion"
+	ListingService_AnswerQuestion_FullMethodName       = "/listingpb.ListingService/AnswerQuestion"
+	ListingService_GetQuestions_FullMethodName         = "/listingpb.ListingService/GetQuestions"
+	ListingService_HideQuestion_FullMethodName         = "/listingpb.ListingService/HideQuestion"
+	ListingService_SuggestListings_FullMethodName      = "/listingpb.ListingService/SuggestListings"
+	ListingService_GetListingHistory_FullMethodName    = "/listingpb.ListingService/GetListingHistory"
 )
 
 // ListingServiceClient is the client API for ListingService service.
@@ -32,11 +70,49 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ListingServiceClient interface {
 	GetAllListings(ctx context.Context, in *GetAllListingsRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error)
+	GetListing(ctx context.Context, in *GetListingRequest, opts ...grpc.CallOption) (*Listing, error)
 	AddListing(ctx context.Context, in *AddListingRequest, opts ...grpc.CallOption) (*AddListingResponse, error)
 	EditListing(ctx context.Context, in *EditListingRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteListing(ctx context.Context, in *DeleteListingRequest, opts ...grpc.CallOption) (*Empty, error)
 	AddLike(ctx context.Context, in *AddLikeRequest, opts ...grpc.CallOption) (*Empty, error)
 	RemoveLike(ctx context.Context, in *RemoveLikeRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetTrash(ctx context.Context, in *GetTrashRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error)
+	RestoreListing(ctx context.Context, in *RestoreListingRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreateImportJob(ctx context.Context, in *CreateImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	AppendImportRows(ctx context.Context, in *AppendImportRowsRequest, opts ...grpc.CallOption) (*AppendImportRowsResponse, error)
+	GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error)
+	GetFeedToken(ctx context.Context, in *FeedTokenRequest, opts ...grpc.CallOption) (*FeedTokenResponse, error)
+	StreamFeed(ctx context.Context, in *StreamFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Listing], error)
+	GetCategories(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetCategoriesResponse, error)
+	GetListingFacets(ctx context.Context, in *GetListingFacetsRequest, opts ...grpc.CallOption) (*ListingFacets, error)
+	GetSimilarListings(ctx context.Context, in *GetSimilarListingsRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error)
+	GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error)
+	RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*Empty, error)
+	CreatePromotion(ctx context.Context, in *CreatePromotionRequest, opts ...grpc.CallOption) (*Promotion, error)
+	GetPromotions(ctx context.Context, in *GetPromotionsRequest, opts ...grpc.CallOption) (*GetPromotionsResponse, error)
+	GetDuplicates(ctx context.Context, in *GetDuplicatesRequest, opts ...grpc.CallOption) (*GetDuplicatesResponse, error)
+	MakeOffer(ctx context.Context, in *MakeOfferRequest, opts ...grpc.CallOption) (*Offer, error)
+	RespondOffer(ctx context.Context, in *RespondOfferRequest, opts ...grpc.CallOption) (*Offer, error)
+	GetOffers(ctx context.Context, in *GetOffersRequest, opts ...grpc.CallOption) (*GetOffersResponse, error)
+	PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*Auction, error)
+	GetAuction(ctx context.Context, in *GetAuctionRequest, opts ...grpc.CallOption) (*Auction, error)
+	CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	RenameCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Empty, error)
+	GetCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error)
+	AddToCollection(ctx context.Context, in *CollectionItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveFromCollection(ctx context.Context, in *CollectionItemRequest, opts ...grpc.CallOption) (*Empty, error)
+	ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	GetSharedCollection(ctx context.Context, in *GetSharedCollectionRequest, opts ...grpc.CallOption) (*Collection, error)
+	FollowUser(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error)
+	UnfollowUser(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error)
+	GetFollowStats(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error)
+	AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	GetQuestions(ctx context.Context, in *GetQuestionsRequest, opts ...grpc.CallOption) (*GetQuestionsResponse, error)
+	HideQuestion(ctx context.Context, in *HideQuestionRequest, opts ...grpc.CallOption) (*Question, error)
+	SuggestListings(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error)
+	GetListingHistory(ctx context.Context, in *GetListingHistoryRequest, opts ...grpc.CallOption) (*GetListingHistoryResponse, error)
 }
 
 type listingServiceClient struct {
@@ -57,6 +133,16 @@ func (c *listingServiceClient) GetAllListings(ctx context.Context, in *GetAllLis
 	return out, nil
 }
 
+func (c *listingServiceClient) GetListing(ctx context.Context, in *GetListingRequest, opts ...grpc.CallOption) (*Listing, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Listing)
+	err := c.cc.Invoke(ctx, ListingService_GetListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *listingServiceClient) AddListing(ctx context.Context, in *AddListingRequest, opts ...grpc.CallOption) (*AddListingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddListingResponse)
@@ -64,212 +150,1420 @@ func (c *listingServiceClient) AddListing(ctx context.Context, in *AddListingReq
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *listingServiceClient) EditListing(ctx context.Context, in *EditListingRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_EditListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) DeleteListing(ctx context.Context, in *DeleteListingRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_DeleteListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) AddLike(ctx context.Context, in *AddLikeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_AddLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RemoveLike(ctx context.Context, in *RemoveLikeRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_RemoveLike_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetTrash(ctx context.Context, in *GetTrashRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllListingsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetTrash_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RestoreListing(ctx context.Context, in *RestoreListingRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_RestoreListing_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) CreateImportJob(ctx context.Context, in *CreateImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ListingService_CreateImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) AppendImportRows(ctx context.Context, in *AppendImportRowsRequest, opts ...grpc.CallOption) (*AppendImportRowsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AppendImportRowsResponse)
+	err := c.cc.Invoke(ctx, ListingService_AppendImportRows_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetImportJob(ctx context.Context, in *GetImportJobRequest, opts ...grpc.CallOption) (*ImportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportJob)
+	err := c.cc.Invoke(ctx, ListingService_GetImportJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetFeedToken(ctx context.Context, in *FeedTokenRequest, opts ...grpc.CallOption) (*FeedTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeedTokenResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetFeedToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) StreamFeed(ctx context.Context, in *StreamFeedRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Listing], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ListingService_ServiceDesc.Streams[0], ListingService_StreamFeed_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamFeedRequest, Listing]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ListingService_StreamFeedClient = grpc.ServerStreamingClient[Listing]
+
+func (c *listingServiceClient) GetCategories(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*GetCategoriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCategoriesResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetCategories_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetListingFacets(ctx context.Context, in *GetListingFacetsRequest, opts ...grpc.CallOption) (*ListingFacets, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListingFacets)
+	err := c.cc.Invoke(ctx, ListingService_GetListingFacets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetSimilarListings(ctx context.Context, in *GetSimilarListingsRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllListingsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetSimilarListings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetRecommendations(ctx context.Context, in *GetRecommendationsRequest, opts ...grpc.CallOption) (*GetAllListingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAllListingsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetRecommendations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RecordView(ctx context.Context, in *RecordViewRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_RecordView_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) CreatePromotion(ctx context.Context, in *CreatePromotionRequest, opts ...grpc.CallOption) (*Promotion, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Promotion)
+	err := c.cc.Invoke(ctx, ListingService_CreatePromotion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetPromotions(ctx context.Context, in *GetPromotionsRequest, opts ...grpc.CallOption) (*GetPromotionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPromotionsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetPromotions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetDuplicates(ctx context.Context, in *GetDuplicatesRequest, opts ...grpc.CallOption) (*GetDuplicatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDuplicatesResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetDuplicates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) MakeOffer(ctx context.Context, in *MakeOfferRequest, opts ...grpc.CallOption) (*Offer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Offer)
+	err := c.cc.Invoke(ctx, ListingService_MakeOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RespondOffer(ctx context.Context, in *RespondOfferRequest, opts ...grpc.CallOption) (*Offer, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Offer)
+	err := c.cc.Invoke(ctx, ListingService_RespondOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetOffers(ctx context.Context, in *GetOffersRequest, opts ...grpc.CallOption) (*GetOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOffersResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) PlaceBid(ctx context.Context, in *PlaceBidRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, ListingService_PlaceBid_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetAuction(ctx context.Context, in *GetAuctionRequest, opts ...grpc.CallOption) (*Auction, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Auction)
+	err := c.cc.Invoke(ctx, ListingService_GetAuction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) CreateCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, ListingService_CreateCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RenameCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, ListingService_RenameCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) DeleteCollection(ctx context.Context, in *CollectionRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_DeleteCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetCollections(ctx context.Context, in *GetCollectionsRequest, opts ...grpc.CallOption) (*GetCollectionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCollectionsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetCollections_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) AddToCollection(ctx context.Context, in *CollectionItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_AddToCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) RemoveFromCollection(ctx context.Context, in *CollectionItemRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ListingService_RemoveFromCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) ShareCollection(ctx context.Context, in *ShareCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, ListingService_ShareCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetSharedCollection(ctx context.Context, in *GetSharedCollectionRequest, opts ...grpc.CallOption) (*Collection, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Collection)
+	err := c.cc.Invoke(ctx, ListingService_GetSharedCollection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) FollowUser(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowStats)
+	err := c.cc.Invoke(ctx, ListingService_FollowUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) UnfollowUser(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowStats)
+	err := c.cc.Invoke(ctx, ListingService_UnfollowUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetFollowStats(ctx context.Context, in *FollowRequest, opts ...grpc.CallOption) (*FollowStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FollowStats)
+	err := c.cc.Invoke(ctx, ListingService_GetFollowStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, ListingService_AskQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, ListingService_AnswerQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetQuestions(ctx context.Context, in *GetQuestionsRequest, opts ...grpc.CallOption) (*GetQuestionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuestionsResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetQuestions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) HideQuestion(ctx context.Context, in *HideQuestionRequest, opts ...grpc.CallOption) (*Question, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Question)
+	err := c.cc.Invoke(ctx, ListingService_HideQuestion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) SuggestListings(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SuggestResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SuggestResponse)
+	err := c.cc.Invoke(ctx, ListingService_SuggestListings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *listingServiceClient) GetListingHistory(ctx context.Context, in *GetListingHistoryRequest, opts ...grpc.CallOption) (*GetListingHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetListingHistoryResponse)
+	err := c.cc.Invoke(ctx, ListingService_GetListingHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListingServiceServer is the server API for ListingService service.
+// All implementations must embed UnimplementedListingServiceServer
+// for forward compatibility.
+type ListingServiceServer interface {
+	GetAllListings(context.Context, *GetAllListingsRequest) (*GetAllListingsResponse, error)
+	GetListing(context.Context, *GetListingRequest) (*Listing, error)
+	AddListing(context.Context, *AddListingRequest) (*AddListingResponse, error)
+	EditListing(context.Context, *EditListingRequest) (*Empty, error)
+	DeleteListing(context.Context, *DeleteListingRequest) (*Empty, error)
+	AddLike(context.Context, *AddLikeRequest) (*Empty, error)
+	RemoveLike(context.Context, *RemoveLikeRequest) (*Empty, error)
+	GetTrash(context.Context, *GetTrashRequest) (*GetAllListingsResponse, error)
+	RestoreListing(context.Context, *RestoreListingRequest) (*Empty, error)
+	CreateImportJob(context.Context, *CreateImportJobRequest) (*ImportJob, error)
+	AppendImportRows(context.Context, *AppendImportRowsRequest) (*AppendImportRowsResponse, error)
+	GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error)
+	GetFeedToken(context.Context, *FeedTokenRequest) (*FeedTokenResponse, error)
+	StreamFeed(*StreamFeedRequest, grpc.ServerStreamingServer[Listing]) error
+	GetCategories(context.Context, *Empty) (*GetCategoriesResponse, error)
+	GetListingFacets(context.Context, *GetListingFacetsRequest) (*ListingFacets, error)
+	GetSimilarListings(context.Context, *GetSimilarListingsRequest) (*GetAllListingsResponse, error)
+	GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetAllListingsResponse, error)
+	RecordView(context.Context, *RecordViewRequest) (*Empty, error)
+	CreatePromotion(context.Context, *CreatePromotionRequest) (*Promotion, error)
+	GetPromotions(context.Context, *GetPromotionsRequest) (*GetPromotionsResponse, error)
+	GetDuplicates(context.Context, *GetDuplicatesRequest) (*GetDuplicatesResponse, error)
+	MakeOffer(context.Context, *MakeOfferRequest) (*Offer, error)
+	RespondOffer(context.Context, *RespondOfferRequest) (*Offer, error)
+	GetOffers(context.Context, *GetOffersRequest) (*GetOffersResponse, error)
+	PlaceBid(context.Context, *PlaceBidRequest) (*Auction, error)
+	GetAuction(context.Context, *GetAuctionRequest) (*Auction, error)
+	CreateCollection(context.Context, *CollectionRequest) (*Collection, error)
+	RenameCollection(context.Context, *CollectionRequest) (*Collection, error)
+	DeleteCollection(context.Context, *CollectionRequest) (*Empty, error)
+	GetCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error)
+	AddToCollection(context.Context, *CollectionItemRequest) (*Empty, error)
+	RemoveFromCollection(context.Context, *CollectionItemRequest) (*Empty, error)
+	ShareCollection(context.Context, *ShareCollectionRequest) (*Collection, error)
+	GetSharedCollection(context.Context, *GetSharedCollectionRequest) (*Collection, error)
+	FollowUser(context.Context, *FollowRequest) (*FollowStats, error)
+	UnfollowUser(context.Context, *FollowRequest) (*FollowStats, error)
+	GetFollowStats(context.Context, *FollowRequest) (*FollowStats, error)
+	AskQuestion(context.Context, *AskQuestionRequest) (*Question, error)
+	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*Question, error)
+	GetQuestions(context.Context, *GetQuestionsRequest) (*GetQuestionsResponse, error)
+	HideQuestion(context.Context, *HideQuestionRequest) (*Question, error)
+	SuggestListings(context.Context, *SuggestRequest) (*SuggestResponse, error)
+	GetListingHistory(context.Context, *GetListingHistoryRequest) (*GetListingHistoryResponse, error)
+	mustEmbedUnimplementedListingServiceServer()
+}
+
+// UnimplementedListingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedListingServiceServer struct{}
+
+func (UnimplementedListingServiceServer) GetAllListings(context.Context, *GetAllListingsRequest) (*GetAllListingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllListings not implemented")
+}
+func (UnimplementedListingServiceServer) GetListing(context.Context, *GetListingRequest) (*Listing, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListing not implemented")
+}
+func (UnimplementedListingServiceServer) AddListing(context.Context, *AddListingRequest) (*AddListingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddListing not implemented")
+}
+func (UnimplementedListingServiceServer) EditListing(context.Context, *EditListingRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditListing not implemented")
+}
+func (UnimplementedListingServiceServer) DeleteListing(context.Context, *DeleteListingRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteListing not implemented")
+}
+func (UnimplementedListingServiceServer) AddLike(context.Context, *AddLikeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddLike not implemented")
+}
+func (UnimplementedListingServiceServer) RemoveLike(context.Context, *RemoveLikeRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveLike not implemented")
+}
+func (UnimplementedListingServiceServer) GetTrash(context.Context, *GetTrashRequest) (*GetAllListingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrash not implemented")
+}
+func (UnimplementedListingServiceServer) RestoreListing(context.Context, *RestoreListingRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreListing not implemented")
+}
+func (UnimplementedListingServiceServer) CreateImportJob(context.Context, *CreateImportJobRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateImportJob not implemented")
+}
+func (UnimplementedListingServiceServer) AppendImportRows(context.Context, *AppendImportRowsRequest) (*AppendImportRowsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendImportRows not implemented")
+}
+func (UnimplementedListingServiceServer) GetImportJob(context.Context, *GetImportJobRequest) (*ImportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImportJob not implemented")
+}
+func (UnimplementedListingServiceServer) GetFeedToken(context.Context, *FeedTokenRequest) (*FeedTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeedToken not implemented")
+}
+func (UnimplementedListingServiceServer) StreamFeed(*StreamFeedRequest, grpc.ServerStreamingServer[Listing]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFeed not implemented")
+}
+func (UnimplementedListingServiceServer) GetCategories(context.Context, *Empty) (*GetCategoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategories not implemented")
+}
+func (UnimplementedListingServiceServer) GetListingFacets(context.Context, *GetListingFacetsRequest) (*ListingFacets, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListingFacets not implemented")
+}
+func (UnimplementedListingServiceServer) GetSimilarListings(context.Context, *GetSimilarListingsRequest) (*GetAllListingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimilarListings not implemented")
+}
+func (UnimplementedListingServiceServer) GetRecommendations(context.Context, *GetRecommendationsRequest) (*GetAllListingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecommendations not implemented")
+}
+func (UnimplementedListingServiceServer) RecordView(context.Context, *RecordViewRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordView not implemented")
+}
+func (UnimplementedListingServiceServer) CreatePromotion(context.Context, *CreatePromotionRequest) (*Promotion, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromotion not implemented")
+}
+func (UnimplementedListingServiceServer) GetPromotions(context.Context, *GetPromotionsRequest) (*GetPromotionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromotions not implemented")
+}
+func (UnimplementedListingServiceServer) GetDuplicates(context.Context, *GetDuplicatesRequest) (*GetDuplicatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDuplicates not implemented")
+}
+func (UnimplementedListingServiceServer) MakeOffer(context.Context, *MakeOfferRequest) (*Offer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MakeOffer not implemented")
+}
+func (UnimplementedListingServiceServer) RespondOffer(context.Context, *RespondOfferRequest) (*Offer, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondOffer not implemented")
+}
+func (UnimplementedListingServiceServer) GetOffers(context.Context, *GetOffersRequest) (*GetOffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOffers not implemented")
+}
+func (UnimplementedListingServiceServer) PlaceBid(context.Context, *PlaceBidRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlaceBid not implemented")
+}
+func (UnimplementedListingServiceServer) GetAuction(context.Context, *GetAuctionRequest) (*Auction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuction not implemented")
+}
+func (UnimplementedListingServiceServer) CreateCollection(context.Context, *CollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCollection not implemented")
+}
+func (UnimplementedListingServiceServer) RenameCollection(context.Context, *CollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameCollection not implemented")
+}
+func (UnimplementedListingServiceServer) DeleteCollection(context.Context, *CollectionRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCollection not implemented")
+}
+func (UnimplementedListingServiceServer) GetCollections(context.Context, *GetCollectionsRequest) (*GetCollectionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCollections not implemented")
+}
+func (UnimplementedListingServiceServer) AddToCollection(context.Context, *CollectionItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToCollection not implemented")
+}
+func (UnimplementedListingServiceServer) RemoveFromCollection(context.Context, *CollectionItemRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromCollection not implemented")
+}
+func (UnimplementedListingServiceServer) ShareCollection(context.Context, *ShareCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCollection not implemented")
+}
+func (UnimplementedListingServiceServer) GetSharedCollection(context.Context, *GetSharedCollectionRequest) (*Collection, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSharedCollection not implemented")
+}
+func (UnimplementedListingServiceServer) FollowUser(context.Context, *FollowRequest) (*FollowStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowUser not implemented")
+}
+func (UnimplementedListingServiceServer) UnfollowUser(context.Context, *FollowRequest) (*FollowStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowUser not implemented")
+}
+func (UnimplementedListingServiceServer) GetFollowStats(context.Context, *FollowRequest) (*FollowStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowStats not implemented")
+}
+func (UnimplementedListingServiceServer) AskQuestion(context.Context, *AskQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AskQuestion not implemented")
+}
+func (UnimplementedListingServiceServer) AnswerQuestion(context.Context, *AnswerQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerQuestion not implemented")
+}
+func (UnimplementedListingServiceServer) GetQuestions(context.Context, *GetQuestionsRequest) (*GetQuestionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestions not implemented")
+}
+func (UnimplementedListingServiceServer) HideQuestion(context.Context, *HideQuestionRequest) (*Question, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HideQuestion not implemented")
+}
+func (UnimplementedListingServiceServer) SuggestListings(context.Context, *SuggestRequest) (*SuggestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SuggestListings not implemented")
+}
+func (UnimplementedListingServiceServer) GetListingHistory(context.Context, *GetListingHistoryRequest) (*GetListingHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetListingHistory not implemented")
+}
+func (UnimplementedListingServiceServer) mustEmbedUnimplementedListingServiceServer() {}
+func (UnimplementedListingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeListingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ListingServiceServer will
+// result in compilation errors.
+type UnsafeListingServiceServer interface {
+	mustEmbedUnimplementedListingServiceServer()
+}
+
+func RegisterListingServiceServer(s grpc.ServiceRegistrar, srv ListingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedListingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ListingService_ServiceDesc, srv)
+}
+
+func _ListingService_GetAllListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllListingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetAllListings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetAllListings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetAllListings(ctx, req.(*GetAllListingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetListing(ctx, req.(*GetListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_AddListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).AddListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_AddListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).AddListing(ctx, req.(*AddListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_EditListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).EditListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_EditListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).EditListing(ctx, req.(*EditListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_DeleteListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).DeleteListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_DeleteListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).DeleteListing(ctx, req.(*DeleteListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_AddLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).AddLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_AddLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).AddLike(ctx, req.(*AddLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_RemoveLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RemoveLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RemoveLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RemoveLike(ctx, req.(*RemoveLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetTrash_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrashRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetTrash(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetTrash_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetTrash(ctx, req.(*GetTrashRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_RestoreListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreListingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RestoreListing(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RestoreListing_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RestoreListing(ctx, req.(*RestoreListingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_CreateImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).CreateImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_CreateImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).CreateImportJob(ctx, req.(*CreateImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_AppendImportRows_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendImportRowsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).AppendImportRows(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_AppendImportRows_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).AppendImportRows(ctx, req.(*AppendImportRowsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetImportJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetImportJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetImportJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetImportJob(ctx, req.(*GetImportJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetFeedToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetFeedToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetFeedToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetFeedToken(ctx, req.(*FeedTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_StreamFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ListingServiceServer).StreamFeed(m, &grpc.GenericServerStream[StreamFeedRequest, Listing]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ListingService_StreamFeedServer = grpc.ServerStreamingServer[Listing]
+
+func _ListingService_GetCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetCategories_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetCategories(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetListingFacets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListingFacetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetListingFacets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetListingFacets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetListingFacets(ctx, req.(*GetListingFacetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetSimilarListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSimilarListingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetSimilarListings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetSimilarListings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetSimilarListings(ctx, req.(*GetSimilarListingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetRecommendations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecommendationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetRecommendations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetRecommendations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetRecommendations(ctx, req.(*GetRecommendationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_RecordView_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordViewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RecordView(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RecordView_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RecordView(ctx, req.(*RecordViewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_CreatePromotion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromotionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).CreatePromotion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_CreatePromotion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).CreatePromotion(ctx, req.(*CreatePromotionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetPromotions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromotionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetPromotions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetPromotions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetPromotions(ctx, req.(*GetPromotionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetDuplicates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDuplicatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetDuplicates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetDuplicates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetDuplicates(ctx, req.(*GetDuplicatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_MakeOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MakeOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).MakeOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_MakeOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).MakeOffer(ctx, req.(*MakeOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_RespondOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RespondOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RespondOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RespondOffer(ctx, req.(*RespondOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetOffers(ctx, req.(*GetOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_PlaceBid_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceBidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).PlaceBid(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_PlaceBid_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).PlaceBid(ctx, req.(*PlaceBidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetAuction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuctionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetAuction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetAuction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetAuction(ctx, req.(*GetAuctionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_CreateCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).CreateCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_CreateCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).CreateCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_RenameCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RenameCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RenameCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RenameCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_DeleteCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).DeleteCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_DeleteCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).DeleteCollection(ctx, req.(*CollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ListingService_GetCollections_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCollectionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetCollections(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetCollections_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetCollections(ctx, req.(*GetCollectionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *listingServiceClient) EditListing(ctx context.Context, in *EditListingRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ListingService_EditListing_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ListingService_AddToCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionItemRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ListingServiceServer).AddToCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_AddToCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).AddToCollection(ctx, req.(*CollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *listingServiceClient) DeleteListing(ctx context.Context, in *DeleteListingRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ListingService_DeleteListing_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ListingService_RemoveFromCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectionItemRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ListingServiceServer).RemoveFromCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_RemoveFromCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).RemoveFromCollection(ctx, req.(*CollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *listingServiceClient) AddLike(ctx context.Context, in *AddLikeRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ListingService_AddLike_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ListingService_ShareCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCollectionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ListingServiceServer).ShareCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_ShareCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).ShareCollection(ctx, req.(*ShareCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *listingServiceClient) RemoveLike(ctx context.Context, in *RemoveLikeRequest, opts ...grpc.CallOption) (*Empty, error) {
-	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(Empty)
-	err := c.cc.Invoke(ctx, ListingService_RemoveLike_FullMethodName, in, out, cOpts...)
-	if err != nil {
+func _ListingService_GetSharedCollection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSharedCollectionRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
-}
-
-// ListingServiceServer is the server API for ListingService service.
-// All implementations must embed UnimplementedListingServiceServer
-// for forward compatibility.
-type ListingServiceServer interface {
-	GetAllListings(context.Context, *GetAllListingsRequest) (*GetAllListingsResponse, error)
-	AddListing(context.Context, *AddListingRequest) (*AddListingResponse, error)
-	EditListing(context.Context, *EditListingRequest) (*Empty, error)
-	DeleteListing(context.Context, *DeleteListingRequest) (*Empty, error)
-	AddLike(context.Context, *AddLikeRequest) (*Empty, error)
-	RemoveLike(context.Context, *RemoveLikeRequest) (*Empty, error)
-	mustEmbedUnimplementedListingServiceServer()
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetSharedCollection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetSharedCollection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetSharedCollection(ctx, req.(*GetSharedCollectionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// UnimplementedListingServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedListingServiceServer struct{}
-
-func (UnimplementedListingServiceServer) GetAllListings(context.Context, *GetAllListingsRequest) (*GetAllListingsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllListings not implemented")
-}
-func (UnimplementedListingServiceServer) AddListing(context.Context, *AddListingRequest) (*AddListingResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddListing not implemented")
-}
-func (UnimplementedListingServiceServer) EditListing(context.Context, *EditListingRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditListing not implemented")
-}
-func (UnimplementedListingServiceServer) DeleteListing(context.Context, *DeleteListingRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteListing not implemented")
-}
-func (UnimplementedListingServiceServer) AddLike(context.Context, *AddLikeRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddLike not implemented")
-}
-func (UnimplementedListingServiceServer) RemoveLike(context.Context, *RemoveLikeRequest) (*Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RemoveLike not implemented")
+func _ListingService_FollowUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).FollowUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_FollowUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).FollowUser(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedListingServiceServer) mustEmbedUnimplementedListingServiceServer() {}
-func (UnimplementedListingServiceServer) testEmbeddedByValue()                        {}
 
-// UnsafeListingServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ListingServiceServer will
-// result in compilation errors.
-type UnsafeListingServiceServer interface {
-	mustEmbedUnimplementedListingServiceServer()
+func _ListingService_UnfollowUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ListingServiceServer).UnfollowUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_UnfollowUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).UnfollowUser(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterListingServiceServer(s grpc.ServiceRegistrar, srv ListingServiceServer) {
-	// If the following call pancis, it indicates UnimplementedListingServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _ListingService_GetFollowStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&ListingService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(ListingServiceServer).GetFollowStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ListingService_GetFollowStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ListingServiceServer).GetFollowStats(ctx, req.(*FollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_GetAllListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetAllListingsRequest)
+func _ListingService_AskQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskQuestionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).GetAllListings(ctx, in)
+		return srv.(ListingServiceServer).AskQuestion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_GetAllListings_FullMethodName,
+		FullMethod: ListingService_AskQuestion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).GetAllListings(ctx, req.(*GetAllListingsRequest))
+		return srv.(ListingServiceServer).AskQuestion(ctx, req.(*AskQuestionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_AddListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddListingRequest)
+func _ListingService_AnswerQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerQuestionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).AddListing(ctx, in)
+		return srv.(ListingServiceServer).AnswerQuestion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_AddListing_FullMethodName,
+		FullMethod: ListingService_AnswerQuestion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).AddListing(ctx, req.(*AddListingRequest))
+		return srv.(ListingServiceServer).AnswerQuestion(ctx, req.(*AnswerQuestionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_EditListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EditListingRequest)
+func _ListingService_GetQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).EditListing(ctx, in)
+		return srv.(ListingServiceServer).GetQuestions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_EditListing_FullMethodName,
+		FullMethod: ListingService_GetQuestions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).EditListing(ctx, req.(*EditListingRequest))
+		return srv.(ListingServiceServer).GetQuestions(ctx, req.(*GetQuestionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_DeleteListing_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteListingRequest)
+func _ListingService_HideQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HideQuestionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).DeleteListing(ctx, in)
+		return srv.(ListingServiceServer).HideQuestion(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_DeleteListing_FullMethodName,
+		FullMethod: ListingService_HideQuestion_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).DeleteListing(ctx, req.(*DeleteListingRequest))
+		return srv.(ListingServiceServer).HideQuestion(ctx, req.(*HideQuestionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_AddLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AddLikeRequest)
+func _ListingService_SuggestListings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).AddLike(ctx, in)
+		return srv.(ListingServiceServer).SuggestListings(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_AddLike_FullMethodName,
+		FullMethod: ListingService_SuggestListings_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).AddLike(ctx, req.(*AddLikeRequest))
+		return srv.(ListingServiceServer).SuggestListings(ctx, req.(*SuggestRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ListingService_RemoveLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RemoveLikeRequest)
+func _ListingService_GetListingHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetListingHistoryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ListingServiceServer).RemoveLike(ctx, in)
+		return srv.(ListingServiceServer).GetListingHistory(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ListingService_RemoveLike_FullMethodName,
+		FullMethod: ListingService_GetListingHistory_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ListingServiceServer).RemoveLike(ctx, req.(*RemoveLikeRequest))
+		return srv.(ListingServiceServer).GetListingHistory(ctx, req.(*GetListingHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -285,6 +1579,10 @@ var ListingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllListings",
 			Handler:    _ListingService_GetAllListings_Handler,
 		},
+		{
+			MethodName: "GetListing",
+			Handler:    _ListingService_GetListing_Handler,
+		},
 		{
 			MethodName: "AddListing",
 			Handler:    _ListingService_AddListing_Handler,
@@ -305,7 +1603,157 @@ var ListingService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveLike",
 			Handler:    _ListingService_RemoveLike_Handler,
 		},
+		{
+			MethodName: "GetTrash",
+			Handler:    _ListingService_GetTrash_Handler,
+		},
+		{
+			MethodName: "RestoreListing",
+			Handler:    _ListingService_RestoreListing_Handler,
+		},
+		{
+			MethodName: "CreateImportJob",
+			Handler:    _ListingService_CreateImportJob_Handler,
+		},
+		{
+			MethodName: "AppendImportRows",
+			Handler:    _ListingService_AppendImportRows_Handler,
+		},
+		{
+			MethodName: "GetImportJob",
+			Handler:    _ListingService_GetImportJob_Handler,
+		},
+		{
+			MethodName: "GetFeedToken",
+			Handler:    _ListingService_GetFeedToken_Handler,
+		},
+		{
+			MethodName: "GetCategories",
+			Handler:    _ListingService_GetCategories_Handler,
+		},
+		{
+			MethodName: "GetListingFacets",
+			Handler:    _ListingService_GetListingFacets_Handler,
+		},
+		{
+			MethodName: "GetSimilarListings",
+			Handler:    _ListingService_GetSimilarListings_Handler,
+		},
+		{
+			MethodName: "GetRecommendations",
+			Handler:    _ListingService_GetRecommendations_Handler,
+		},
+		{
+			MethodName: "RecordView",
+			Handler:    _ListingService_RecordView_Handler,
+		},
+		{
+			MethodName: "CreatePromotion",
+			Handler:    _ListingService_CreatePromotion_Handler,
+		},
+		{
+			MethodName: "GetPromotions",
+			Handler:    _ListingService_GetPromotions_Handler,
+		},
+		{
+			MethodName: "GetDuplicates",
+			Handler:    _ListingService_GetDuplicates_Handler,
+		},
+		{
+			MethodName: "MakeOffer",
+			Handler:    _ListingService_MakeOffer_Handler,
+		},
+		{
+			MethodName: "RespondOffer",
+			Handler:    _ListingService_RespondOffer_Handler,
+		},
+		{
+			MethodName: "GetOffers",
+			Handler:    _ListingService_GetOffers_Handler,
+		},
+		{
+			MethodName: "PlaceBid",
+			Handler:    _ListingService_PlaceBid_Handler,
+		},
+		{
+			MethodName: "GetAuction",
+			Handler:    _ListingService_GetAuction_Handler,
+		},
+		{
+			MethodName: "CreateCollection",
+			Handler:    _ListingService_CreateCollection_Handler,
+		},
+		{
+			MethodName: "RenameCollection",
+			Handler:    _ListingService_RenameCollection_Handler,
+		},
+		{
+			MethodName: "DeleteCollection",
+			Handler:    _ListingService_DeleteCollection_Handler,
+		},
+		{
+			MethodName: "GetCollections",
+			Handler:    _ListingService_GetCollections_Handler,
+		},
+		{
+			MethodName: "AddToCollection",
+			Handler:    _ListingService_AddToCollection_Handler,
+		},
+		{
+			MethodName: "RemoveFromCollection",
+			Handler:    _ListingService_RemoveFromCollection_Handler,
+		},
+		{
+			MethodName: "ShareCollection",
+			Handler:    _ListingService_ShareCollection_Handler,
+		},
+		{
+			MethodName: "GetSharedCollection",
+			Handler:    _ListingService_GetSharedCollection_Handler,
+		},
+		{
+			MethodName: "FollowUser",
+			Handler:    _ListingService_FollowUser_Handler,
+		},
+		{
+			MethodName: "UnfollowUser",
+			Handler:    _ListingService_UnfollowUser_Handler,
+		},
+		{
+			MethodName: "GetFollowStats",
+			Handler:    _ListingService_GetFollowStats_Handler,
+		},
+		{
+			MethodName: "AskQuestion",
+			Handler:    _ListingService_AskQuestion_Handler,
+		},
+		{
+			MethodName: "AnswerQuestion",
+			Handler:    _ListingService_AnswerQuestion_Handler,
+		},
+		{
+			MethodName: "GetQuestions",
+			Handler:    _ListingService_GetQuestions_Handler,
+		},
+		{
+			MethodName: "HideQuestion",
+			Handler:    _ListingService_HideQuestion_Handler,
+		},
+		{
+			MethodName: "SuggestListings",
+			Handler:    _ListingService_SuggestListings_Handler,
+		},
+		{
+			MethodName: "GetListingHistory",
+			Handler:    _ListingService_GetListingHistory_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFeed",
+			Handler:       _ListingService_StreamFeed_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "listing.proto",
 }
@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
 // 	protoc-gen-go v1.36.6
-// 	protoc        v6.30.2
+// 	protoc        v6.31.1
 // source: session.proto
 
 package sessionpb
@@ -273,6 +273,102 @@ func (x *DeleteSessionResponse) GetUserId() string {
 	return ""
 }
 
+type DeleteUserSessionsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExceptSessionId string                 `protobuf:"bytes,2,opt,name=except_session_id,json=exceptSessionId,proto3" json:"except_session_id,omitempty"` // Сессия, которая остаётся активной, пусто - завершить все
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *DeleteUserSessionsRequest) Reset() {
+	*x = DeleteUserSessionsRequest{}
+	mi := &file_session_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserSessionsRequest) ProtoMessage() {}
+
+func (x *DeleteUserSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserSessionsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteUserSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_session_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DeleteUserSessionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeleteUserSessionsRequest) GetExceptSessionId() string {
+	if x != nil {
+		return x.ExceptSessionId
+	}
+	return ""
+}
+
+type DeleteUserSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int64                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteUserSessionsResponse) Reset() {
+	*x = DeleteUserSessionsResponse{}
+	mi := &file_session_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteUserSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteUserSessionsResponse) ProtoMessage() {}
+
+func (x *DeleteUserSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_session_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteUserSessionsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteUserSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_session_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteUserSessionsResponse) GetDeleted() int64 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
 var File_session_proto protoreflect.FileDescriptor
 
 const file_session_proto_rawDesc = "" +
@@ -295,13 +391,19 @@ const file_session_proto_rawDesc = "" +
 	"\n" +
 	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"0\n" +
 	"\x15DeleteSessionResponse\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId2\xe5\x01\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"`\n" +
+	"\x19DeleteUserSessionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12*\n" +
+	"\x11except_session_id\x18\x02 \x01(\tR\x0fexceptSessionId\"6\n" +
+	"\x1aDeleteUserSessionsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x03R\adeleted2\xc8\x02\n" +
 	"\x0eSessionService\x12E\n" +
 	"\n" +
 	"GetSession\x12\x1b.sessionpb.SessionIDRequest\x1a\x1a.sessionpb.SessionResponse\x12<\n" +
 	"\n" +
 	"SetSession\x12\x1c.sessionpb.SetSessionRequest\x1a\x10.sessionpb.Empty\x12N\n" +
-	"\rDeleteSession\x12\x1b.sessionpb.SessionIDRequest\x1a .sessionpb.DeleteSessionResponseB\rZ\v./sessionpbb\x06proto3"
+	"\rDeleteSession\x12\x1b.sessionpb.SessionIDRequest\x1a .sessionpb.DeleteSessionResponse\x12a\n" +
+	"\x12DeleteUserSessions\x12$.sessionpb.DeleteUserSessionsRequest\x1a%.sessionpb.DeleteUserSessionsResponseB\rZ\v./sessionpbb\x06proto3"
 
 var (
 	file_session_proto_rawDescOnce sync.Once
@@ -315,23 +417,27 @@ func file_session_proto_rawDescGZIP() []byte {
 	return file_session_proto_rawDescData
 }
 
-var file_session_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_session_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_session_proto_goTypes = []any{
-	(*Empty)(nil),                 // 0: sessionpb.Empty
-	(*SessionIDRequest)(nil),      // 1: sessionpb.SessionIDRequest
-	(*SessionResponse)(nil),       // 2: sessionpb.SessionResponse
-	(*SetSessionRequest)(nil),     // 3: sessionpb.SetSessionRequest
-	(*DeleteSessionResponse)(nil), // 4: sessionpb.DeleteSessionResponse
+	(*Empty)(nil),                      // 0: sessionpb.Empty
+	(*SessionIDRequest)(nil),           // 1: sessionpb.SessionIDRequest
+	(*SessionResponse)(nil),            // 2: sessionpb.SessionResponse
+	(*SetSessionRequest)(nil),          // 3: sessionpb.SetSessionRequest
+	(*DeleteSessionResponse)(nil),      // 4: sessionpb.DeleteSessionResponse
+	(*DeleteUserSessionsRequest)(nil),  // 5: sessionpb.DeleteUserSessionsRequest
+	(*DeleteUserSessionsResponse)(nil), // 6: sessionpb.DeleteUserSessionsResponse
 }
 var file_session_proto_depIdxs = []int32{
 	1, // 0: sessionpb.SessionService.GetSession:input_type -> sessionpb.SessionIDRequest
 	3, // 1: sessionpb.SessionService.SetSession:input_type -> sessionpb.SetSessionRequest
 	1, // 2: sessionpb.SessionService.DeleteSession:input_type -> sessionpb.SessionIDRequest
-	2, // 3: sessionpb.SessionService.GetSession:output_type -> sessionpb.SessionResponse
-	0, // 4: sessionpb.SessionService.SetSession:output_type -> sessionpb.Empty
-	4, // 5: sessionpb.SessionService.DeleteSession:output_type -> sessionpb.DeleteSessionResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
+	5, // 3: sessionpb.SessionService.DeleteUserSessions:input_type -> sessionpb.DeleteUserSessionsRequest
+	2, // 4: sessionpb.SessionService.GetSession:output_type -> sessionpb.SessionResponse
+	0, // 5: sessionpb.SessionService.SetSession:output_type -> sessionpb.Empty
+	4, // 6: sessionpb.SessionService.DeleteSession:output_type -> sessionpb.DeleteSessionResponse
+	6, // 7: sessionpb.SessionService.DeleteUserSessions:output_type -> sessionpb.DeleteUserSessionsResponse
+	4, // [4:8] is the sub-list for method output_type
+	0, // [0:4] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -348,7 +454,7 @@ func file_session_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_session_proto_rawDesc), len(file_session_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1,6 +1,7 @@
 package repo
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,11 +14,55 @@ const (
 
 // UserRepo определяет методы для работы с пользователями в системе
 type UserRepo interface {
-	// CheckPass проверяет учетные данные пользователя
-	CheckPass(username string, pass string) (userID uuid.UUID, err error)
+	// CheckPass проверяет учетные данные пользователя. legacyPass - пароль в прежнем обратимом шифровании
+	CheckPass(username string, pass string, legacyPass string) (userID uuid.UUID, err error)
 
 	// CreateAccount создает новую учетную запись
 	CreateAccount(username string, pass string) (userID uuid.UUID, err error)
+
+	// GetUser возвращает публичный профиль пользователя
+	GetUser(userID uuid.UUID) (UserProfile, error)
+
+	// UpdateProfile изменяет заданные поля профиля и возвращает профиль после изменения
+	UpdateProfile(userID uuid.UUID, update ProfileUpdate) (UserProfile, error)
+
+	// ChangePassword меняет пароль пользователя по старому паролю
+	ChangePassword(userID uuid.UUID, oldPass string, legacyOldPass string, newPass string) error
+
+	// RequestPasswordReset отправляет пользователю письмо со ссылкой сброса пароля
+	RequestPasswordReset(username string) error
+
+	// ResetPassword задаёт новый пароль по токену из письма
+	ResetPassword(token string, newPass string) (userID uuid.UUID, err error)
+}
+
+// UserProfile публичный профиль пользователя
+type UserProfile struct {
+	ID           uuid.UUID `json:"id"`
+	Username     string    `json:"username"`
+	DisplayName  string    `json:"display_name"`
+	AvatarURL    string    `json:"avatar_url"`
+	Bio          string    `json:"bio"`
+	City         string    `json:"city"`
+	RegisteredAt time.Time `json:"registered_at"`
+	Email        string    `json:"email,omitempty"` // Видна только самому пользователю
+}
+
+// Name возвращает отображаемое имя пользователя, а если оно не задано - логин
+func (p UserProfile) Name() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.Username
+}
+
+// ProfileUpdate изменение профиля, поля со значением nil не изменяются
+type ProfileUpdate struct {
+	DisplayName *string `json:"display_name"`
+	AvatarURL   *string `json:"-"`
+	Bio         *string `json:"bio"`
+	City        *string `json:"city"`
+	Email       *string `json:"email"`
 }
 
 // SessionRepo определяет методы для работы с сессиями
@@ -30,21 +75,249 @@ type SessionRepo interface {
 
 	// DeleteSession удаляет сессию
 	DeleteSession(sessionID uuid.UUID) (userID uuid.UUID, err error)
+
+	// DeleteUserSessions удаляет все сессии пользователя, кроме exceptSessionID. uuid.Nil - удалить все
+	DeleteUserSessions(userID uuid.UUID, exceptSessionID uuid.UUID) (deleted int, err error)
 }
 
 type ListingType struct {
-	ID          uuid.UUID `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Address     string    `json:"address"`
-	Price       int       `json:"price"`
-	AuthorID    uuid.UUID `json:"author_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	ImageURL    string    `json:"image_url"`
-	Likes       int       `json:"likes"`
-	IsYours     bool      `json:"is_yours"`
-	IsLiked     bool      `json:"is_liked"`
-	AuthorLogin string    `json:"author_login"`
+	ID          uuid.UUID         `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description"`
+	Address     string            `json:"address"`
+	Price       int               `json:"price"`
+	AuthorID    uuid.UUID         `json:"author_id"`
+	CreatedAt   time.Time         `json:"created_at"`
+	ImageURL    string            `json:"image_url"`
+	Likes       int               `json:"likes"`
+	IsYours     bool              `json:"is_yours"`
+	IsLiked     bool              `json:"is_liked"`
+	AuthorLogin string            `json:"author_login"`
+	DeletedAt   *time.Time        `json:"deleted_at,omitempty"`
+	CategoryID  int               `json:"category_id,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	Status      string            `json:"status,omitempty"`
+	Promoted    bool              `json:"promoted"`
+	ImageHash   uint64            `json:"-"`                 // Перцептивный хэш изображения для поиска дубликатов
+	Auction     *AuctionSettings  `json:"auction,omitempty"` // Настройки аукциона, если объявление продаётся с аукциона
+}
+
+// AuctionSettings настройки аукциона при создании объявления
+type AuctionSettings struct {
+	StartPrice   int       `json:"start_price"`   // Стартовая цена, по умолчанию равна цене объявления
+	MinIncrement int       `json:"min_increment"` // Минимальный шаг ставки
+	EndsAt       time.Time `json:"ends_at"`
+}
+
+// AttributeFilter фильтр по атрибуту категории: точное значение или диапазон для числовых атрибутов
+type AttributeFilter struct {
+	Name  string
+	Value *string
+	Min   *int64
+	Max   *int64
+}
+
+// AttributeSchema описание атрибута категории
+type AttributeSchema struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	EnumValues []string `json:"enum_values,omitempty"`
+	Required   bool     `json:"required"`
+}
+
+// Category категория объявлений со схемой атрибутов
+type Category struct {
+	ID         int               `json:"id"`
+	Name       string            `json:"name"`
+	Attributes []AttributeSchema `json:"attributes"`
+}
+
+// PriceBucket интервал гистограммы цен [From, To), To не задан у последнего открытого интервала
+type PriceBucket struct {
+	From  int64  `json:"from"`
+	To    *int64 `json:"to,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// CategoryFacet количество объявлений в категории, CategoryID 0 - объявления без категории
+type CategoryFacet struct {
+	CategoryID int    `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+// StatusFacet количество объявлений в статусе
+type StatusFacet struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// ListingFacets агрегаты по объявлениям, подходящим под фильтр
+type ListingFacets struct {
+	Total          int64           `json:"total"`
+	MinPrice       int64           `json:"min_price"`
+	MaxPrice       int64           `json:"max_price"`
+	PriceHistogram []PriceBucket   `json:"price_histogram"`
+	Categories     []CategoryFacet `json:"categories"`
+	Statuses       []StatusFacet   `json:"statuses"`
+}
+
+// FacetBuckets настройка гистограммы цен: явные границы интервалов или их количество
+type FacetBuckets struct {
+	Bounds []int64
+	Count  int
+}
+
+// Promotion продвижение объявления
+type Promotion struct {
+	ID        uuid.UUID `json:"id"`
+	ListingID uuid.UUID `json:"listing_id"`
+	Type      string    `json:"type"`
+	Status    string    `json:"status"`
+	StartsAt  time.Time `json:"starts_at"`
+	EndsAt    time.Time `json:"ends_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Offer предложение цены по объявлению
+type Offer struct {
+	ID          uuid.UUID  `json:"id"`
+	ListingID   uuid.UUID  `json:"listing_id"`
+	BuyerID     uuid.UUID  `json:"buyer_id"`
+	SellerID    uuid.UUID  `json:"seller_id"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"` // Предложение, на которое это предложение встречное
+	FromSeller  bool       `json:"from_seller"`         // Встречное предложение продавца
+	Amount      int        `json:"amount"`
+	Message     string     `json:"message,omitempty"`
+	Status      string     `json:"status"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+
+	ReservedUntil *time.Time `json:"reserved_until,omitempty"` // До какого момента принятое предложение держит резерв без заказа
+}
+
+// Collection именованная подборка избранных объявлений
+type Collection struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"user_id"`
+	Name            string    `json:"name"`
+	ShareToken      string    `json:"share_token,omitempty"` // Есть, только если подборка открыта по ссылке
+	ShareURL        string    `json:"share_url,omitempty"`
+	ListingsCount   int       `json:"listings_count"`
+	ContainsListing bool      `json:"contains_listing,omitempty"` // Объявление из запроса уже в подборке
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FollowStats подписчики и подписки пользователя
+type FollowStats struct {
+	UserID         uuid.UUID `json:"user_id"`
+	FollowersCount int64     `json:"followers_count"`
+	FollowingCount int64     `json:"following_count"`
+	IsFollowing    bool      `json:"is_following"` // Текущий пользователь подписан на этого
+}
+
+// Question публичный вопрос покупателя по объявлению
+type Question struct {
+	ID         uuid.UUID  `json:"id"`
+	ListingID  uuid.UUID  `json:"listing_id"`
+	AskerID    uuid.UUID  `json:"asker_id"`
+	AskerLogin string     `json:"asker_login"`
+	Text       string     `json:"text"`
+	Answer     string     `json:"answer,omitempty"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	Hidden     bool       `json:"hidden,omitempty"` // Скрыт модератором, такие вопросы видит только модератор
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// HistoryEntry запись истории изменений объявления
+type HistoryEntry struct {
+	ID         uuid.UUID     `json:"id"`
+	ListingID  uuid.UUID     `json:"listing_id"`
+	ActorID    *uuid.UUID    `json:"actor_id,omitempty"` // Нет, если изменение сделал сервис, например закрыл аукцион
+	ActorLogin string        `json:"actor_login,omitempty"`
+	Action     string        `json:"action"`
+	Changes    []FieldChange `json:"changes"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// FieldChange значения поля объявления до и после изменения в том виде, в каком они хранятся в истории
+type FieldChange struct {
+	Field  string          `json:"field"`
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// Suggestion подсказка поиска: популярный прошлый запрос или название объявления
+type Suggestion struct {
+	Text      string     `json:"text"`
+	Kind      string     `json:"kind"`                 // query или listing
+	ListingID *uuid.UUID `json:"listing_id,omitempty"` // Объявление, если подсказка - его название
+}
+
+// Auction состояние аукциона по объявлению
+type Auction struct {
+	ListingID    uuid.UUID  `json:"listing_id"`
+	StartPrice   int        `json:"start_price"`
+	MinIncrement int        `json:"min_increment"`
+	CurrentPrice *int       `json:"current_price,omitempty"` // Нет, пока не сделано ни одной ставки
+	MinBid       int        `json:"min_bid"`                 // Минимальная ставка, которую можно сделать сейчас
+	LeaderID     *uuid.UUID `json:"leader_id,omitempty"`
+	WinnerID     *uuid.UUID `json:"winner_id,omitempty"`
+	BidsCount    int        `json:"bids_count"`
+	Status       string     `json:"status"`
+	EndsAt       time.Time  `json:"ends_at"`
+	ClosedAt     *time.Time `json:"closed_at,omitempty"`
+	Bids         []Bid      `json:"bids"` // Последние ставки, начиная с новых
+}
+
+// Bid ставка на аукционе
+type Bid struct {
+	ID        uuid.UUID `json:"id"`
+	BidderID  uuid.UUID `json:"bidder_id"`
+	Amount    int       `json:"amount"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// DuplicateMatch найденное при публикации совпадение объявления с уже существующим
+type DuplicateMatch struct {
+	ListingID           uuid.UUID `json:"listing_id"`
+	ListingTitle        string    `json:"listing_title"`
+	ListingAuthorID     uuid.UUID `json:"listing_author_id"`
+	ListingDeleted      bool      `json:"listing_deleted"`
+	DuplicateOfID       uuid.UUID `json:"duplicate_of_id"`
+	DuplicateOfTitle    string    `json:"duplicate_of_title"`
+	DuplicateOfAuthorID uuid.UUID `json:"duplicate_of_author_id"`
+	DuplicateOfDeleted  bool      `json:"duplicate_of_deleted"`
+	SameAuthor          bool      `json:"same_author"`
+	ImageDistance       *int64    `json:"image_distance,omitempty"` // Расстояние Хэмминга между хэшами изображений
+	TextDistance        *int64    `json:"text_distance,omitempty"`  // Расстояние Хэмминга между отпечатками текстов
+	DetectedAt          time.Time `json:"detected_at"`
+}
+
+// ImportRow строка импорта, прошедшая валидацию
+type ImportRow struct {
+	Row     int         // Номер строки в файле
+	Listing ListingType // Данные объявления
+}
+
+// ImportRowError ошибка обработки строки импорта
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Message string `json:"message"`
+}
+
+// ImportJob состояние задачи импорта объявлений
+type ImportJob struct {
+	ID            uuid.UUID        `json:"id"`
+	Status        string           `json:"status"`
+	TotalRows     int              `json:"total_rows"`
+	ProcessedRows int              `json:"processed_rows"`
+	ImportedRows  int              `json:"imported_rows"`
+	FailedRows    int              `json:"failed_rows"`
+	Errors        []ImportRowError `json:"errors"`
+	CreatedAt     time.Time        `json:"created_at"`
+	FinishedAt    *time.Time       `json:"finished_at,omitempty"`
 }
 
 type ListingFilter struct {
@@ -56,6 +329,20 @@ type ListingFilter struct {
 	Page       int
 	MinPrice   int
 	MaxPrice   int
+	CategoryID int
+	Attributes []AttributeFilter
+
+	CollectionID uuid.UUID // Только объявления из подборки
+	ShareToken   string    // Токен публичной ссылки для просмотра чужой подборки
+	Following    bool      // Лента подписок: объявления продавцов, на которых подписан пользователь
+	PageSize     int       // Размер страницы для выгрузок, без продвигаемых объявлений. 0 - размер по умолчанию
+	Query        string    // Поиск по названию, запросы с результатами попадают в подсказки
+
+	CreatedAfter  *time.Time  // Опубликованы не раньше этого момента
+	CreatedBefore *time.Time  // Опубликованы раньше этого момента
+	AuthorIDs     []uuid.UUID // Объявления любого из этих авторов
+	MinLikes      int
+	HasImage      bool
 }
 
 // ListingRepo определяет методы для работы с объявлениями
@@ -63,6 +350,9 @@ type ListingRepo interface {
 	// GetAllListings получает все объявления
 	GetAllListings(filter ListingFilter) (listing []ListingType, totalPages int64, cuurentPage int64, err error)
 
+	// GetListing получает одно объявление, userID может быть нулевым
+	GetListing(listingID uuid.UUID, userID uuid.UUID) (ListingType, error)
+
 	// AddListing добавляет новое объявление
 	AddListing(listing ListingType) (id uuid.UUID, err error)
 
@@ -77,4 +367,163 @@ type ListingRepo interface {
 
 	// RemoveLike удаляет объявление из списка избранного
 	RemoveLike(listingID uuid.UUID, userID uuid.UUID) error
+
+	// GetTrash получает удалённые объявления пользователя
+	GetTrash(userID uuid.UUID, page int) (listing []ListingType, totalPages int64, currentPage int64, err error)
+
+	// RestoreListing восстанавливает объявление из корзины
+	RestoreListing(id uuid.UUID, userID uuid.UUID) error
+
+	// CreateImportJob создает задачу импорта объявлений
+	CreateImportJob(authorID uuid.UUID, totalRows int, rowErrors []ImportRowError) (ImportJob, error)
+
+	// AppendImportRows передает в задачу импорта очередную порцию строк
+	AppendImportRows(jobID uuid.UUID, authorID uuid.UUID, rows []ImportRow, rowErrors []ImportRowError, final bool) ([]ImportRowError, error)
+
+	// GetImportJob получает состояние задачи импорта
+	GetImportJob(id uuid.UUID, userID uuid.UUID) (ImportJob, error)
+
+	// GetFeedToken получает токен фида пользователя, regenerate выпускает новый токен
+	GetFeedToken(userID uuid.UUID, regenerate bool) (token string, err error)
+
+	// StreamFeed передает активные объявления продавца, подходящие под фильтр, в fn по одному
+	StreamFeed(userID uuid.UUID, token string, filter ListingFilter, fn func(ListingType) error) error
+
+	// GetCategories получает категории со схемами атрибутов
+	GetCategories() ([]Category, error)
+
+	// GetListingFacets считает гистограмму цен и количество объявлений по категориям и статусам для фильтра
+	GetListingFacets(filter ListingFilter, buckets FacetBuckets) (ListingFacets, error)
+
+	// GetSimilarListings получает объявления, похожие на данное
+	GetSimilarListings(listingID uuid.UUID, userID uuid.UUID, limit int) ([]ListingType, error)
+
+	// GetRecommendations получает ленту «для вас» на основе лайков пользователя
+	GetRecommendations(userID uuid.UUID, limit int) ([]ListingType, error)
+
+	// RecordView учитывает просмотр объявления в трендовой сортировке
+	RecordView(listingID uuid.UUID, userID uuid.UUID) error
+
+	// CreatePromotion создаёт продвижение объявления, startsAt может быть нулевым - тогда продвижение начинается сразу
+	CreatePromotion(listingID uuid.UUID, userID uuid.UUID, promotionType string, startsAt time.Time, endsAt time.Time) (Promotion, error)
+
+	// GetPromotions получает продвижения объявлений пользователя
+	GetPromotions(userID uuid.UUID) ([]Promotion, error)
+
+	// GetDuplicates получает для модератора найденные совпадения объявлений
+	GetDuplicates(userID uuid.UUID, page int) (matches []DuplicateMatch, totalPages int64, currentPage int64, err error)
+
+	// MakeOffer создаёт предложение цены покупателя по объявлению
+	MakeOffer(listingID uuid.UUID, userID uuid.UUID, amount int, message string, expiresAt time.Time) (Offer, error)
+
+	// RespondOffer принимает, отклоняет предложение или делает встречное
+	RespondOffer(offerID uuid.UUID, userID uuid.UUID, action string, amount int, message string, expiresAt time.Time) (Offer, error)
+
+	// GetOffers получает предложения пользователя, при непустом listingID - только по этому объявлению
+	GetOffers(userID uuid.UUID, listingID uuid.UUID) ([]Offer, error)
+
+	// PlaceBid делает ставку на аукционе
+	PlaceBid(listingID uuid.UUID, userID uuid.UUID, amount int) (Auction, error)
+
+	// GetAuction получает состояние аукциона и последние ставки
+	GetAuction(listingID uuid.UUID) (Auction, error)
+
+	// CreateCollection создаёт подборку избранного
+	CreateCollection(userID uuid.UUID, name string) (Collection, error)
+
+	// RenameCollection переименовывает подборку
+	RenameCollection(collectionID uuid.UUID, userID uuid.UUID, name string) (Collection, error)
+
+	// DeleteCollection удаляет подборку
+	DeleteCollection(collectionID uuid.UUID, userID uuid.UUID) error
+
+	// GetCollections получает подборки пользователя, при непустом listingID отмечает подборки с этим объявлением
+	GetCollections(userID uuid.UUID, listingID uuid.UUID) ([]Collection, error)
+
+	// AddToCollection добавляет объявление в подборку
+	AddToCollection(collectionID uuid.UUID, userID uuid.UUID, listingID uuid.UUID) error
+
+	// RemoveFromCollection убирает объявление из подборки
+	RemoveFromCollection(collectionID uuid.UUID, userID uuid.UUID, listingID uuid.UUID) error
+
+	// ShareCollection открывает или закрывает доступ к подборке по ссылке
+	ShareCollection(collectionID uuid.UUID, userID uuid.UUID, shared bool) (Collection, error)
+
+	// GetSharedCollection получает подборку по токену публичной ссылки
+	GetSharedCollection(shareToken string) (Collection, error)
+
+	// FollowUser подписывает пользователя на продавца
+	FollowUser(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error)
+
+	// UnfollowUser отменяет подписку на продавца
+	UnfollowUser(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error)
+
+	// GetFollowStats получает количество подписчиков и подписок продавца, followerID может быть нулевым
+	GetFollowStats(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error)
+
+	// AskQuestion задаёт вопрос по объявлению
+	AskQuestion(listingID uuid.UUID, userID uuid.UUID, text string) (Question, error)
+
+	// AnswerQuestion отвечает на вопрос, доступно только автору объявления
+	AnswerQuestion(questionID uuid.UUID, userID uuid.UUID, text string) (Question, error)
+
+	// GetQuestions получает видимые пользователю вопросы по объявлению
+	GetQuestions(listingID uuid.UUID, userID uuid.UUID, page int) (questions []Question, totalPages int64, currentPage int64, err error)
+
+	// HideQuestion скрывает вопрос или возвращает его в выдачу, доступно только модератору
+	HideQuestion(questionID uuid.UUID, userID uuid.UUID, hidden bool) (Question, error)
+
+	// GetListingHistory получает историю изменений объявления, доступно автору и модераторам
+	GetListingHistory(listingID uuid.UUID, userID uuid.UUID, page int) (entries []HistoryEntry, totalPages int64, currentPage int64, err error)
+
+	// SuggestListings подсказывает поисковые запросы и названия объявлений по началу ввода
+	SuggestListings(prefix string, limit int) ([]Suggestion, error)
+}
+
+// Order заказ покупателя на объявление
+type Order struct {
+	ID                uuid.UUID  `json:"id"`
+	ListingID         *uuid.UUID `json:"listing_id,omitempty"` // Нет, если объявление удалено из корзины
+	BuyerID           uuid.UUID  `json:"buyer_id"`
+	SellerID          uuid.UUID  `json:"seller_id"`
+	OfferID           *uuid.UUID `json:"offer_id,omitempty"` // Принятое предложение цены, по которому оформлен заказ
+	Amount            int        `json:"amount"`
+	Status            string     `json:"status"`
+	PaymentID         string     `json:"payment_id,omitempty"`
+	PaymentURL        string     `json:"payment_url,omitempty"` // Страница оплаты, если провайдер её предоставляет
+	CreatedAt         time.Time  `json:"created_at"`
+	ExpiresAt         time.Time  `json:"expires_at"` // До какого времени заказ нужно оплатить
+	PaidAt            *time.Time `json:"paid_at,omitempty"`
+	CancelledAt       *time.Time `json:"cancelled_at,omitempty"`
+	RefundRequestedAt *time.Time `json:"refund_requested_at,omitempty"`
+	RefundedAt        *time.Time `json:"refunded_at,omitempty"`
+}
+
+// PaymentEvent уведомление платёжного провайдера
+type PaymentEvent struct {
+	EventID   string `json:"event_id"`
+	Type      string `json:"type"`
+	PaymentID string `json:"payment_id"`
+	Amount    int    `json:"amount"`
+}
+
+// OrderRepo определяет методы для работы с заказами
+type OrderRepo interface {
+	// CreateOrder оформляет заказ на объявление, при непустом offerID - по принятому предложению цены
+	CreateOrder(listingID uuid.UUID, buyerID uuid.UUID, offerID uuid.UUID) (Order, error)
+
+	// GetOrder получает заказ, в котором пользователь покупатель или продавец
+	GetOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error)
+
+	// GetOrders получает заказы пользователя, роль buyer или seller оставляет только покупки или продажи
+	GetOrders(userID uuid.UUID, role string) ([]Order, error)
+
+	// CancelOrder отменяет неоплаченный заказ
+	CancelOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error)
+
+	// RefundOrder запрашивает возврат оплаченного заказа
+	RefundOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error)
+
+	// HandlePaymentWebhook передаёт уведомление платёжного провайдера сервису заказов
+	HandlePaymentWebhook(event PaymentEvent) (order Order, duplicate bool, err error)
 }
@@ -3,10 +3,19 @@ package repo
 import (
 	"api/internal/proto/listingpb"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ListingRepoGRPC struct {
@@ -26,6 +35,125 @@ const (
 	listingToken = "listing-token"
 )
 
+// ErrInvalidFeedToken возвращается, если токен фида не совпадает с токеном продавца
+var ErrInvalidFeedToken = errors.New("invalid feed token")
+
+// ErrInvalidAttributes возвращается, если категория или атрибуты не соответствуют схеме категории
+var ErrInvalidAttributes = errors.New("invalid attributes")
+
+// ErrInvalidRequest возвращается, если сервис объявлений отклонил запрос по другой причине,
+// например из-за параметров аукциона или фильтра
+var ErrInvalidRequest = errors.New("invalid request")
+
+// attributeErrorPrefix начинает сообщения ошибок проверки категории и атрибутов сервиса объявлений
+const attributeErrorPrefix = "invalid attributes: "
+
+// ErrListingNotFound возвращается, если объявление не существует или удалено
+var ErrListingNotFound = errors.New("listing not found")
+
+// ErrNotListingOwner возвращается, если пользователь не является автором объявления
+var ErrNotListingOwner = errors.New("not the owner of the listing")
+
+// ErrNotRestorable возвращается, если объявление не удалено или срок его хранения в корзине истёк
+var ErrNotRestorable = errors.New("listing cannot be restored")
+
+// ErrDuplicateListing возвращается, если объявление совпадает с уже опубликованным
+var ErrDuplicateListing = errors.New("duplicate listing")
+
+// ErrNotModerator возвращается, если у пользователя нет роли модератора
+var ErrNotModerator = errors.New("moderator role required")
+
+// ErrInvalidPromotion возвращается при неверном типе или сроках продвижения
+var ErrInvalidPromotion = errors.New("invalid promotion")
+
+// ErrImportJobNotFound возвращается, если задача импорта не существует
+var ErrImportJobNotFound = errors.New("import job not found")
+
+// ErrImportJobForbidden возвращается, если задача импорта принадлежит другому пользователю
+var ErrImportJobForbidden = errors.New("import job belongs to another user")
+
+// ErrOfferNotFound возвращается, если предложение не существует или пользователь в нём не участвует
+var ErrOfferNotFound = errors.New("offer not found")
+
+// ErrInvalidOffer возвращается при неверной сумме, сроке или действии с предложением
+var ErrInvalidOffer = errors.New("invalid offer")
+
+// ErrOfferForbidden возвращается, если пользователь не может сделать предложение или ответить на него
+var ErrOfferForbidden = errors.New("offer action forbidden")
+
+// ErrOfferConflict возвращается, если предложение уже не ожидает ответа или объявление недоступно
+var ErrOfferConflict = errors.New("offer conflict")
+
+// ErrAuctionNotFound возвращается, если объявление не продаётся с аукциона
+var ErrAuctionNotFound = errors.New("auction not found")
+
+// ErrInvalidBid возвращается, если ставка меньше минимально допустимой
+var ErrInvalidBid = errors.New("invalid bid")
+
+// ErrBidForbidden возвращается при ставке на собственное объявление
+var ErrBidForbidden = errors.New("bid forbidden")
+
+// ErrAuctionClosed возвращается при ставке на завершённый аукцион
+var ErrAuctionClosed = errors.New("auction closed")
+
+// ErrBidLeading возвращается, если ставку делает текущий лидер аукциона
+var ErrBidLeading = errors.New("bidder already leads")
+
+// ErrBidConflict возвращается, если ставку не удалось сделать из-за параллельных ставок
+var ErrBidConflict = errors.New("concurrent bid conflict")
+
+// ErrCollectionNotFound возвращается, если подборка не существует или недоступна пользователю
+var ErrCollectionNotFound = errors.New("collection not found")
+
+// ErrInvalidCollection возвращается при неверном названии подборки
+var ErrInvalidCollection = errors.New("invalid collection")
+
+// ErrCollectionExists возвращается, если у пользователя уже есть подборка с таким названием
+var ErrCollectionExists = errors.New("collection already exists")
+
+// ErrCollectionLimit возвращается, если пользователь создал максимальное количество подборок
+var ErrCollectionLimit = errors.New("collection limit reached")
+
+// ErrUserNotFound возвращается, если пользователь не существует
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrInvalidFollow возвращается при попытке подписаться на самого себя
+var ErrInvalidFollow = errors.New("invalid follow")
+
+// ErrQuestionNotFound возвращается, если вопрос не существует или скрыт
+var ErrQuestionNotFound = errors.New("question not found")
+
+// ErrInvalidQuestion возвращается при неверном тексте вопроса или ответа
+var ErrInvalidQuestion = errors.New("invalid question")
+
+// ErrQuestionForbidden возвращается, если пользователь не может задать вопрос или ответить на него
+var ErrQuestionForbidden = errors.New("question action forbidden")
+
+// ErrHistoryForbidden возвращается, если историю объявления запрашивает не автор и не модератор
+var ErrHistoryForbidden = errors.New("listing history forbidden")
+
+// wrapInvalidAttributes преобразует ошибку валидации сервиса объявлений: ошибки проверки атрибутов
+// в ErrInvalidAttributes, остальные InvalidArgument в ErrInvalidRequest
+func wrapInvalidAttributes(err error) error {
+	if status.Code(err) != codes.InvalidArgument {
+		return err
+	}
+	msg := status.Convert(err).Message()
+	if strings.HasPrefix(msg, attributeErrorPrefix) {
+		return fmt.Errorf("%w: %s", ErrInvalidAttributes, strings.TrimPrefix(msg, attributeErrorPrefix))
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidRequest, msg)
+}
+
+// wrapFilterError преобразует ошибку выдачи по фильтру. NotFound сервис объявлений возвращает,
+// только если подборка из фильтра не существует или недоступна
+func wrapFilterError(err error) error {
+	if status.Code(err) == codes.NotFound {
+		return ErrCollectionNotFound
+	}
+	return wrapInvalidAttributes(err)
+}
+
 // GetAllListings получает все объявления
 // userID - ID пользователя, для которого получаем объявления
 // targetUser - ID пользователя, чьи объявления получаем
@@ -35,46 +163,18 @@ func (r *ListingRepoGRPC) GetAllListings(filter ListingFilter) (listing []Listin
 	})
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
 
-	resp, err := r.service.GetAllListings(ctx, &listingpb.GetAllListingsRequest{
-		TargetUserId: filter.TargetUser.String(),
-		SortField:    filter.SortField,
-		SortOrder:    filter.SortOrder,
-		OnlyLiked:    filter.OnlyLiked,
-		UserId:       filter.UserID.String(),
-		Page:         int64(filter.Page),
-		MinPrice:     int64(filter.MinPrice),
-		MaxPrice:     int64(filter.MaxPrice),
-	})
+	resp, err := r.service.GetAllListings(ctx, filterToProto(filter))
 
 	if err != nil {
-		return nil, 0, 0, err
+		return nil, 0, 0, wrapFilterError(err)
 	}
 
 	for _, item := range resp.Listings {
-		parsedID, err := uuid.Parse(item.Id)
-		if err != nil {
-			continue
-		}
-
-		parsedAuthorID, err := uuid.Parse(item.AuthorId)
+		parsed, err := listingFromProto(item)
 		if err != nil {
 			continue
 		}
-
-		listing = append(listing, ListingType{
-			ID:          parsedID,
-			Title:       item.Title,
-			Description: item.Description,
-			Address:     item.Address,
-			Price:       int(item.Price),
-			AuthorID:    parsedAuthorID,
-			CreatedAt:   item.CreatedAt.AsTime(),
-			ImageURL:    item.ImageUrl,
-			Likes:       int(item.Likes),
-			IsYours:     item.IsYours,
-			IsLiked:     item.IsLiked,
-			AuthorLogin: item.AuthorLogin,
-		})
+		listing = append(listing, parsed)
 	}
 
 	if len(listing) == 0 {
@@ -84,6 +184,122 @@ func (r *ListingRepoGRPC) GetAllListings(filter ListingFilter) (listing []Listin
 	return listing, resp.TotalPages, resp.CurrentPage, nil
 }
 
+// GetListing получает одно объявление, userID может быть нулевым
+func (r *ListingRepoGRPC) GetListing(listingID uuid.UUID, userID uuid.UUID) (ListingType, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetListing(ctx, &listingpb.GetListingRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return ListingType{}, ErrListingNotFound
+	}
+	if err != nil {
+		return ListingType{}, err
+	}
+	return listingFromProto(resp)
+}
+
+// filterToProto преобразует фильтр объявлений в gRPC запрос
+func filterToProto(filter ListingFilter) *listingpb.GetAllListingsRequest {
+	var attrFilters []*listingpb.AttributeFilter
+	for _, f := range filter.Attributes {
+		attrFilters = append(attrFilters, &listingpb.AttributeFilter{
+			Name:  f.Name,
+			Value: f.Value,
+			Min:   f.Min,
+			Max:   f.Max,
+		})
+	}
+
+	authorIDs := make([]string, 0, len(filter.AuthorIDs))
+	for _, id := range filter.AuthorIDs {
+		authorIDs = append(authorIDs, id.String())
+	}
+
+	return &listingpb.GetAllListingsRequest{
+		TargetUserId:     filter.TargetUser.String(),
+		SortField:        filter.SortField,
+		SortOrder:        filter.SortOrder,
+		OnlyLiked:        filter.OnlyLiked,
+		UserId:           filter.UserID.String(),
+		Page:             int64(filter.Page),
+		MinPrice:         int64(filter.MinPrice),
+		MaxPrice:         int64(filter.MaxPrice),
+		CategoryId:       int64(filter.CategoryID),
+		AttributeFilters: attrFilters,
+		CollectionId:     uuidOrEmpty(filter.CollectionID),
+		ShareToken:       filter.ShareToken,
+		Following:        filter.Following,
+		PageSize:         int64(filter.PageSize),
+		Query:            filter.Query,
+		CreatedAfter:     timestampOrNil(filter.CreatedAfter),
+		CreatedBefore:    timestampOrNil(filter.CreatedBefore),
+		AuthorIds:        authorIDs,
+		MinLikes:         int64(filter.MinLikes),
+		HasImage:         filter.HasImage,
+	}
+}
+
+// timestampOrNil переводит необязательное время в формат protobuf
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// uuidOrEmpty возвращает пустую строку для нулевого идентификатора
+func uuidOrEmpty(id uuid.UUID) string {
+	if id == uuid.Nil {
+		return ""
+	}
+	return id.String()
+}
+
+// listingFromProto преобразует объявление из gRPC ответа во внутреннюю структуру
+func listingFromProto(item *listingpb.Listing) (ListingType, error) {
+	parsedID, err := uuid.Parse(item.Id)
+	if err != nil {
+		return ListingType{}, err
+	}
+
+	parsedAuthorID, err := uuid.Parse(item.AuthorId)
+	if err != nil {
+		return ListingType{}, err
+	}
+
+	listing := ListingType{
+		ID:          parsedID,
+		Title:       item.Title,
+		Description: item.Description,
+		Address:     item.Address,
+		Price:       int(item.Price),
+		AuthorID:    parsedAuthorID,
+		CreatedAt:   item.CreatedAt.AsTime(),
+		ImageURL:    item.ImageUrl,
+		Likes:       int(item.Likes),
+		IsYours:     item.IsYours,
+		IsLiked:     item.IsLiked,
+		AuthorLogin: item.AuthorLogin,
+		CategoryID:  int(item.CategoryId),
+		Attributes:  item.Attributes,
+		Status:      item.Status,
+		Promoted:    item.Promoted,
+	}
+
+	if item.DeletedAt != nil {
+		deletedAt := item.DeletedAt.AsTime()
+		listing.DeletedAt = &deletedAt
+	}
+
+	return listing, nil
+}
+
 // AddListing добавляет новое объявление
 func (r *ListingRepoGRPC) AddListing(listing ListingType) (id uuid.UUID, err error) {
 	md := metadata.New(map[string]string{
@@ -98,10 +314,17 @@ func (r *ListingRepoGRPC) AddListing(listing ListingType) (id uuid.UUID, err err
 		Price:       int64(listing.Price),
 		AuthorId:    listing.AuthorID.String(),
 		ImageUrl:    listing.ImageURL,
+		CategoryId:  int64(listing.CategoryID),
+		Attributes:  listing.Attributes,
+		ImageHash:   listing.ImageHash,
+		Auction:     auctionSettingsToProto(listing.Auction),
 	})
 
+	if status.Code(err) == codes.AlreadyExists {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrDuplicateListing, status.Convert(err).Message())
+	}
 	if err != nil {
-		return uuid.Nil, err
+		return uuid.Nil, wrapInvalidAttributes(err)
 	}
 
 	return uuid.Parse(resp.Id)
@@ -122,9 +345,12 @@ func (r *ListingRepoGRPC) EditListing(listing ListingType, userID uuid.UUID) err
 		Price:       int64(listing.Price),
 		ImageUrl:    listing.ImageURL,
 		UserId:      userID.String(),
+		CategoryId:  int64(listing.CategoryID),
+		Attributes:  listing.Attributes,
+		ImageHash:   listing.ImageHash,
 	})
 
-	return err
+	return wrapInvalidAttributes(err)
 }
 
 // DeleteListing удаляет объявление
@@ -138,8 +364,16 @@ func (r *ListingRepoGRPC) DeleteListing(id uuid.UUID, userID uuid.UUID) error {
 		Id:     id.String(),
 		UserId: userID.String(),
 	})
-
-	return err
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		return ErrListingNotFound
+	case codes.PermissionDenied:
+		return ErrNotListingOwner
+	default:
+		return err
+	}
 }
 
 // AddLike добавляет объявление в список избранного
@@ -171,3 +405,1251 @@ func (r *ListingRepoGRPC) RemoveLike(listingID uuid.UUID, userID uuid.UUID) erro
 
 	return err
 }
+
+// GetTrash получает удалённые объявления пользователя, которые ещё можно восстановить
+func (r *ListingRepoGRPC) GetTrash(userID uuid.UUID, page int) (listing []ListingType, totalPages int64, currentPage int64, err error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetTrash(ctx, &listingpb.GetTrashRequest{
+		UserId: userID.String(),
+		Page:   int64(page),
+	})
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	for _, item := range resp.Listings {
+		parsed, err := listingFromProto(item)
+		if err != nil {
+			continue
+		}
+		listing = append(listing, parsed)
+	}
+
+	if len(listing) == 0 {
+		listing = []ListingType{}
+	}
+
+	return listing, resp.TotalPages, resp.CurrentPage, nil
+}
+
+// RestoreListing восстанавливает объявление из корзины
+func (r *ListingRepoGRPC) RestoreListing(id uuid.UUID, userID uuid.UUID) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := r.service.RestoreListing(ctx, &listingpb.RestoreListingRequest{
+		Id:     id.String(),
+		UserId: userID.String(),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.NotFound:
+		return ErrListingNotFound
+	case codes.PermissionDenied:
+		return ErrNotListingOwner
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", ErrNotRestorable, status.Convert(err).Message())
+	default:
+		return err
+	}
+}
+
+// CreateImportJob создает задачу импорта объявлений
+// rowErrors - строки, не прошедшие разбор, они учитываются в статистике задачи
+func (r *ListingRepoGRPC) CreateImportJob(authorID uuid.UUID, totalRows int, rowErrors []ImportRowError) (ImportJob, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.CreateImportJob(ctx, &listingpb.CreateImportJobRequest{
+		AuthorId:  authorID.String(),
+		TotalRows: int64(totalRows),
+		Errors:    importRowErrorsToProto(rowErrors),
+	})
+	if err != nil {
+		return ImportJob{}, err
+	}
+
+	return importJobFromProto(resp)
+}
+
+// AppendImportRows передает в задачу импорта очередную порцию строк
+// Возвращает строки, отклоненные сервисом объявлений, final завершает задачу
+func (r *ListingRepoGRPC) AppendImportRows(jobID uuid.UUID, authorID uuid.UUID, rows []ImportRow, rowErrors []ImportRowError, final bool) ([]ImportRowError, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &listingpb.AppendImportRowsRequest{
+		JobId:    jobID.String(),
+		AuthorId: authorID.String(),
+		Errors:   importRowErrorsToProto(rowErrors),
+		Final:    final,
+	}
+
+	for _, row := range rows {
+		req.Rows = append(req.Rows, &listingpb.ImportRow{
+			Row:         int64(row.Row),
+			Title:       row.Listing.Title,
+			Description: row.Listing.Description,
+			Address:     row.Listing.Address,
+			Price:       int64(row.Listing.Price),
+			ImageUrl:    row.Listing.ImageURL,
+			ImageHash:   row.Listing.ImageHash,
+		})
+	}
+
+	resp, err := r.service.AppendImportRows(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected := make([]ImportRowError, 0, len(resp.Errors))
+	for _, rowErr := range resp.Errors {
+		rejected = append(rejected, ImportRowError{
+			Row:     int(rowErr.Row),
+			Message: rowErr.Message,
+		})
+	}
+
+	return rejected, nil
+}
+
+func importRowErrorsToProto(rowErrors []ImportRowError) []*listingpb.ImportRowError {
+	var result []*listingpb.ImportRowError
+	for _, rowErr := range rowErrors {
+		result = append(result, &listingpb.ImportRowError{
+			Row:     int64(rowErr.Row),
+			Message: rowErr.Message,
+		})
+	}
+	return result
+}
+
+// GetImportJob получает состояние задачи импорта
+func (r *ListingRepoGRPC) GetImportJob(id uuid.UUID, userID uuid.UUID) (ImportJob, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetImportJob(ctx, &listingpb.GetImportJobRequest{
+		Id:     id.String(),
+		UserId: userID.String(),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return ImportJob{}, ErrImportJobNotFound
+	case codes.PermissionDenied:
+		return ImportJob{}, ErrImportJobForbidden
+	default:
+		return ImportJob{}, err
+	}
+
+	return importJobFromProto(resp)
+}
+
+// importJobFromProto преобразует задачу импорта из gRPC ответа во внутреннюю структуру
+func importJobFromProto(resp *listingpb.ImportJob) (ImportJob, error) {
+	id, err := uuid.Parse(resp.Id)
+	if err != nil {
+		return ImportJob{}, err
+	}
+
+	job := ImportJob{
+		ID:            id,
+		Status:        resp.Status,
+		TotalRows:     int(resp.TotalRows),
+		ProcessedRows: int(resp.ProcessedRows),
+		ImportedRows:  int(resp.ImportedRows),
+		FailedRows:    int(resp.FailedRows),
+		Errors:        []ImportRowError{},
+		CreatedAt:     resp.CreatedAt.AsTime(),
+	}
+
+	for _, rowErr := range resp.Errors {
+		job.Errors = append(job.Errors, ImportRowError{
+			Row:     int(rowErr.Row),
+			Message: rowErr.Message,
+		})
+	}
+
+	if resp.FinishedAt != nil {
+		finishedAt := resp.FinishedAt.AsTime()
+		job.FinishedAt = &finishedAt
+	}
+
+	return job, nil
+}
+
+// GetFeedToken получает токен фида пользователя
+func (r *ListingRepoGRPC) GetFeedToken(userID uuid.UUID, regenerate bool) (string, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetFeedToken(ctx, &listingpb.FeedTokenRequest{
+		UserId:     userID.String(),
+		Regenerate: regenerate,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Token, nil
+}
+
+// StreamFeed получает объявления фида потоком и передает их в fn по мере поступления
+// Если fn возвращает ошибку, поток закрывается
+func (r *ListingRepoGRPC) StreamFeed(userID uuid.UUID, token string, filter ListingFilter, fn func(ListingType) error) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), md))
+	defer cancel()
+
+	stream, err := r.service.StreamFeed(ctx, &listingpb.StreamFeedRequest{
+		UserId: userID.String(),
+		Token:  token,
+		Filter: filterToProto(filter),
+	})
+	if err != nil {
+		return err
+	}
+
+	for {
+		item, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if status.Code(err) == codes.PermissionDenied {
+			return ErrInvalidFeedToken
+		}
+		if err != nil {
+			return wrapFilterError(err)
+		}
+
+		listing, err := listingFromProto(item)
+		if err != nil {
+			continue
+		}
+
+		if err := fn(listing); err != nil {
+			return err
+		}
+	}
+}
+
+// GetCategories получает категории со схемами атрибутов
+func (r *ListingRepoGRPC) GetCategories() ([]Category, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetCategories(ctx, &listingpb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]Category, 0, len(resp.Categories))
+	for _, c := range resp.Categories {
+		category := Category{
+			ID:         int(c.Id),
+			Name:       c.Name,
+			Attributes: []AttributeSchema{},
+		}
+		for _, a := range c.Attributes {
+			category.Attributes = append(category.Attributes, AttributeSchema{
+				Name:       a.Name,
+				Type:       a.Type,
+				EnumValues: a.EnumValues,
+				Required:   a.Required,
+			})
+		}
+		categories = append(categories, category)
+	}
+
+	return categories, nil
+}
+
+// GetListingFacets считает агрегаты по объявлениям, подходящим под фильтр
+func (r *ListingRepoGRPC) GetListingFacets(filter ListingFilter, buckets FacetBuckets) (ListingFacets, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetListingFacets(ctx, &listingpb.GetListingFacetsRequest{
+		Filter:       filterToProto(filter),
+		PriceBounds:  buckets.Bounds,
+		PriceBuckets: int64(buckets.Count),
+	})
+	if err != nil {
+		return ListingFacets{}, wrapFilterError(err)
+	}
+
+	facets := ListingFacets{
+		Total:          resp.Total,
+		MinPrice:       resp.MinPrice,
+		MaxPrice:       resp.MaxPrice,
+		PriceHistogram: make([]PriceBucket, 0, len(resp.PriceHistogram)),
+		Categories:     make([]CategoryFacet, 0, len(resp.Categories)),
+		Statuses:       make([]StatusFacet, 0, len(resp.Statuses)),
+	}
+	for _, b := range resp.PriceHistogram {
+		facets.PriceHistogram = append(facets.PriceHistogram, PriceBucket{From: b.From, To: b.To, Count: b.Count})
+	}
+	for _, c := range resp.Categories {
+		facets.Categories = append(facets.Categories, CategoryFacet{CategoryID: int(c.CategoryId), Name: c.Name, Count: c.Count})
+	}
+	for _, st := range resp.Statuses {
+		facets.Statuses = append(facets.Statuses, StatusFacet{Status: st.Status, Count: st.Count})
+	}
+
+	return facets, nil
+}
+
+// GetSimilarListings получает объявления, похожие на данное
+func (r *ListingRepoGRPC) GetSimilarListings(listingID uuid.UUID, userID uuid.UUID, limit int) ([]ListingType, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetSimilarListings(ctx, &listingpb.GetSimilarListingsRequest{
+		Id:     listingID.String(),
+		UserId: userID.String(),
+		Limit:  int64(limit),
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, ErrListingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return listingsFromProto(resp.Listings), nil
+}
+
+// GetRecommendations получает ленту «для вас» на основе лайков пользователя
+func (r *ListingRepoGRPC) GetRecommendations(userID uuid.UUID, limit int) ([]ListingType, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetRecommendations(ctx, &listingpb.GetRecommendationsRequest{
+		UserId: userID.String(),
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return listingsFromProto(resp.Listings), nil
+}
+
+// listingsFromProto преобразует список объявлений из gRPC ответа, пропуская некорректные записи
+func listingsFromProto(items []*listingpb.Listing) []ListingType {
+	listings := make([]ListingType, 0, len(items))
+	for _, item := range items {
+		parsed, err := listingFromProto(item)
+		if err != nil {
+			continue
+		}
+		listings = append(listings, parsed)
+	}
+	return listings
+}
+
+// RecordView учитывает просмотр объявления в трендовой сортировке
+func (r *ListingRepoGRPC) RecordView(listingID uuid.UUID, userID uuid.UUID) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := r.service.RecordView(ctx, &listingpb.RecordViewRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+	})
+	if status.Code(err) == codes.NotFound {
+		return ErrListingNotFound
+	}
+	return err
+}
+
+// CreatePromotion создаёт продвижение объявления
+func (r *ListingRepoGRPC) CreatePromotion(listingID uuid.UUID, userID uuid.UUID, promotionType string, startsAt time.Time, endsAt time.Time) (Promotion, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &listingpb.CreatePromotionRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Type:      promotionType,
+		EndsAt:    timestamppb.New(endsAt),
+	}
+	if !startsAt.IsZero() {
+		req.StartsAt = timestamppb.New(startsAt)
+	}
+
+	resp, err := r.service.CreatePromotion(ctx, req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			return Promotion{}, fmt.Errorf("%w: %s", ErrInvalidPromotion, status.Convert(err).Message())
+		case codes.NotFound:
+			return Promotion{}, ErrListingNotFound
+		case codes.PermissionDenied:
+			return Promotion{}, ErrNotListingOwner
+		}
+		return Promotion{}, err
+	}
+
+	return promotionFromProto(resp)
+}
+
+// GetPromotions получает продвижения объявлений пользователя
+func (r *ListingRepoGRPC) GetPromotions(userID uuid.UUID) ([]Promotion, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetPromotions(ctx, &listingpb.GetPromotionsRequest{UserId: userID.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	promotions := make([]Promotion, 0, len(resp.Promotions))
+	for _, item := range resp.Promotions {
+		promotion, err := promotionFromProto(item)
+		if err != nil {
+			continue
+		}
+		promotions = append(promotions, promotion)
+	}
+	return promotions, nil
+}
+
+// promotionFromProto преобразует продвижение из gRPC ответа во внутреннюю структуру
+func promotionFromProto(item *listingpb.Promotion) (Promotion, error) {
+	id, err := uuid.Parse(item.Id)
+	if err != nil {
+		return Promotion{}, err
+	}
+
+	listingID, err := uuid.Parse(item.ListingId)
+	if err != nil {
+		return Promotion{}, err
+	}
+
+	return Promotion{
+		ID:        id,
+		ListingID: listingID,
+		Type:      item.Type,
+		Status:    item.Status,
+		StartsAt:  item.StartsAt.AsTime(),
+		EndsAt:    item.EndsAt.AsTime(),
+		CreatedAt: item.CreatedAt.AsTime(),
+	}, nil
+}
+
+// GetDuplicates получает для модератора найденные совпадения объявлений
+func (r *ListingRepoGRPC) GetDuplicates(userID uuid.UUID, page int) (matches []DuplicateMatch, totalPages int64, currentPage int64, err error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetDuplicates(ctx, &listingpb.GetDuplicatesRequest{
+		UserId: userID.String(),
+		Page:   int64(page),
+	})
+	if status.Code(err) == codes.PermissionDenied {
+		return nil, 0, 0, ErrNotModerator
+	}
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	matches = make([]DuplicateMatch, 0, len(resp.Matches))
+	for _, item := range resp.Matches {
+		listingID, err := uuid.Parse(item.ListingId)
+		if err != nil {
+			continue
+		}
+		duplicateOfID, err := uuid.Parse(item.DuplicateOfId)
+		if err != nil {
+			continue
+		}
+		listingAuthorID, _ := uuid.Parse(item.ListingAuthorId)
+		duplicateOfAuthorID, _ := uuid.Parse(item.DuplicateOfAuthorId)
+
+		matches = append(matches, DuplicateMatch{
+			ListingID:           listingID,
+			ListingTitle:        item.ListingTitle,
+			ListingAuthorID:     listingAuthorID,
+			ListingDeleted:      item.ListingDeleted,
+			DuplicateOfID:       duplicateOfID,
+			DuplicateOfTitle:    item.DuplicateOfTitle,
+			DuplicateOfAuthorID: duplicateOfAuthorID,
+			DuplicateOfDeleted:  item.DuplicateOfDeleted,
+			SameAuthor:          item.SameAuthor,
+			ImageDistance:       item.ImageDistance,
+			TextDistance:        item.TextDistance,
+			DetectedAt:          item.DetectedAt.AsTime(),
+		})
+	}
+
+	return matches, resp.TotalPages, resp.CurrentPage, nil
+}
+
+// wrapOfferError преобразует ошибки сервиса объявлений при работе с предложениями,
+// notFound - ошибка для отсутствующего объявления или предложения
+func wrapOfferError(err error, notFound error) error {
+	msg := status.Convert(err).Message()
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidOffer, msg)
+	case codes.NotFound:
+		return notFound
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", ErrOfferForbidden, msg)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", ErrOfferConflict, msg)
+	}
+	return err
+}
+
+// MakeOffer создаёт предложение цены покупателя по объявлению
+func (r *ListingRepoGRPC) MakeOffer(listingID uuid.UUID, userID uuid.UUID, amount int, message string, expiresAt time.Time) (Offer, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &listingpb.MakeOfferRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Amount:    int64(amount),
+		Message:   message,
+	}
+	if !expiresAt.IsZero() {
+		req.ExpiresAt = timestamppb.New(expiresAt)
+	}
+
+	resp, err := r.service.MakeOffer(ctx, req)
+	if err != nil {
+		return Offer{}, wrapOfferError(err, ErrListingNotFound)
+	}
+	return offerFromProto(resp)
+}
+
+// RespondOffer принимает, отклоняет предложение или делает встречное
+func (r *ListingRepoGRPC) RespondOffer(offerID uuid.UUID, userID uuid.UUID, action string, amount int, message string, expiresAt time.Time) (Offer, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &listingpb.RespondOfferRequest{
+		OfferId: offerID.String(),
+		UserId:  userID.String(),
+		Action:  action,
+		Amount:  int64(amount),
+		Message: message,
+	}
+	if !expiresAt.IsZero() {
+		req.ExpiresAt = timestamppb.New(expiresAt)
+	}
+
+	resp, err := r.service.RespondOffer(ctx, req)
+	if err != nil {
+		return Offer{}, wrapOfferError(err, ErrOfferNotFound)
+	}
+	return offerFromProto(resp)
+}
+
+// GetOffers получает предложения пользователя, при непустом listingID - только по этому объявлению
+func (r *ListingRepoGRPC) GetOffers(userID uuid.UUID, listingID uuid.UUID) ([]Offer, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &listingpb.GetOffersRequest{UserId: userID.String()}
+	if listingID != uuid.Nil {
+		req.ListingId = listingID.String()
+	}
+
+	resp, err := r.service.GetOffers(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	offers := make([]Offer, 0, len(resp.Offers))
+	for _, item := range resp.Offers {
+		offer, err := offerFromProto(item)
+		if err != nil {
+			continue
+		}
+		offers = append(offers, offer)
+	}
+	return offers, nil
+}
+
+func offerFromProto(item *listingpb.Offer) (Offer, error) {
+	offer := Offer{
+		FromSeller: item.FromSeller,
+		Amount:     int(item.Amount),
+		Message:    item.Message,
+		Status:     item.Status,
+		ExpiresAt:  item.ExpiresAt.AsTime(),
+		CreatedAt:  item.CreatedAt.AsTime(),
+	}
+
+	var err error
+	for _, f := range []struct {
+		dst *uuid.UUID
+		src string
+	}{
+		{&offer.ID, item.Id},
+		{&offer.ListingID, item.ListingId},
+		{&offer.BuyerID, item.BuyerId},
+		{&offer.SellerID, item.SellerId},
+	} {
+		if *f.dst, err = uuid.Parse(f.src); err != nil {
+			return Offer{}, err
+		}
+	}
+
+	if item.ParentId != "" {
+		parentID, err := uuid.Parse(item.ParentId)
+		if err != nil {
+			return Offer{}, err
+		}
+		offer.ParentID = &parentID
+	}
+	if item.RespondedAt != nil {
+		respondedAt := item.RespondedAt.AsTime()
+		offer.RespondedAt = &respondedAt
+	}
+	if item.ReservedUntil != nil {
+		reservedUntil := item.ReservedUntil.AsTime()
+		offer.ReservedUntil = &reservedUntil
+	}
+	return offer, nil
+}
+
+func auctionSettingsToProto(settings *AuctionSettings) *listingpb.AuctionSettings {
+	if settings == nil {
+		return nil
+	}
+	return &listingpb.AuctionSettings{
+		StartPrice:   int64(settings.StartPrice),
+		MinIncrement: int64(settings.MinIncrement),
+		EndsAt:       timestamppb.New(settings.EndsAt),
+	}
+}
+
+// wrapBidError преобразует ошибку ставки сервиса объявлений в ошибку репозитория
+func wrapBidError(err error) error {
+	msg := status.Convert(err).Message()
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidBid, msg)
+	case codes.NotFound:
+		return ErrAuctionNotFound
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", ErrBidForbidden, msg)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", ErrAuctionClosed, msg)
+	case codes.AlreadyExists:
+		return fmt.Errorf("%w: %s", ErrBidLeading, msg)
+	case codes.Aborted:
+		return fmt.Errorf("%w: %s", ErrBidConflict, msg)
+	}
+	return err
+}
+
+// PlaceBid делает ставку на аукционе
+func (r *ListingRepoGRPC) PlaceBid(listingID uuid.UUID, userID uuid.UUID, amount int) (Auction, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.PlaceBid(ctx, &listingpb.PlaceBidRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Amount:    int64(amount),
+	})
+	if err != nil {
+		return Auction{}, wrapBidError(err)
+	}
+	return auctionFromProto(resp)
+}
+
+// GetAuction получает состояние аукциона и последние ставки
+func (r *ListingRepoGRPC) GetAuction(listingID uuid.UUID) (Auction, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetAuction(ctx, &listingpb.GetAuctionRequest{ListingId: listingID.String()})
+	if status.Code(err) == codes.NotFound {
+		return Auction{}, ErrAuctionNotFound
+	}
+	if err != nil {
+		return Auction{}, err
+	}
+	return auctionFromProto(resp)
+}
+
+func auctionFromProto(item *listingpb.Auction) (Auction, error) {
+	listingID, err := uuid.Parse(item.ListingId)
+	if err != nil {
+		return Auction{}, err
+	}
+
+	auction := Auction{
+		ListingID:    listingID,
+		StartPrice:   int(item.StartPrice),
+		MinIncrement: int(item.MinIncrement),
+		MinBid:       int(item.MinBid),
+		BidsCount:    int(item.BidsCount),
+		Status:       item.Status,
+		EndsAt:       item.EndsAt.AsTime(),
+		Bids:         make([]Bid, 0, len(item.Bids)),
+	}
+	if item.CurrentPrice != nil {
+		price := int(*item.CurrentPrice)
+		auction.CurrentPrice = &price
+	}
+	if item.LeaderId != "" {
+		leaderID, err := uuid.Parse(item.LeaderId)
+		if err != nil {
+			return Auction{}, err
+		}
+		auction.LeaderID = &leaderID
+	}
+	if item.WinnerId != "" {
+		winnerID, err := uuid.Parse(item.WinnerId)
+		if err != nil {
+			return Auction{}, err
+		}
+		auction.WinnerID = &winnerID
+	}
+	if item.ClosedAt != nil {
+		closedAt := item.ClosedAt.AsTime()
+		auction.ClosedAt = &closedAt
+	}
+
+	for _, b := range item.Bids {
+		id, err := uuid.Parse(b.Id)
+		if err != nil {
+			continue
+		}
+		bidderID, err := uuid.Parse(b.BidderId)
+		if err != nil {
+			continue
+		}
+		auction.Bids = append(auction.Bids, Bid{
+			ID:        id,
+			BidderID:  bidderID,
+			Amount:    int(b.Amount),
+			CreatedAt: b.CreatedAt.AsTime(),
+		})
+	}
+	return auction, nil
+}
+
+// wrapCollectionError преобразует ошибку сервиса объявлений при работе с подборками
+func wrapCollectionError(err error) error {
+	msg := status.Convert(err).Message()
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidCollection, msg)
+	case codes.NotFound:
+		return ErrCollectionNotFound
+	case codes.FailedPrecondition:
+		// Сервис отвечает FailedPrecondition, если добавляемого объявления нет
+		return ErrListingNotFound
+	case codes.AlreadyExists:
+		return fmt.Errorf("%w: %s", ErrCollectionExists, msg)
+	case codes.ResourceExhausted:
+		return fmt.Errorf("%w: %s", ErrCollectionLimit, msg)
+	}
+	return err
+}
+
+// CreateCollection создаёт подборку избранного
+func (r *ListingRepoGRPC) CreateCollection(userID uuid.UUID, name string) (Collection, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.CreateCollection(ctx, &listingpb.CollectionRequest{
+		UserId: userID.String(),
+		Name:   name,
+	})
+	if err != nil {
+		return Collection{}, wrapCollectionError(err)
+	}
+	return collectionFromProto(resp)
+}
+
+// RenameCollection переименовывает подборку
+func (r *ListingRepoGRPC) RenameCollection(collectionID uuid.UUID, userID uuid.UUID, name string) (Collection, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.RenameCollection(ctx, &listingpb.CollectionRequest{
+		CollectionId: collectionID.String(),
+		UserId:       userID.String(),
+		Name:         name,
+	})
+	if err != nil {
+		return Collection{}, wrapCollectionError(err)
+	}
+	return collectionFromProto(resp)
+}
+
+// DeleteCollection удаляет подборку
+func (r *ListingRepoGRPC) DeleteCollection(collectionID uuid.UUID, userID uuid.UUID) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := r.service.DeleteCollection(ctx, &listingpb.CollectionRequest{
+		CollectionId: collectionID.String(),
+		UserId:       userID.String(),
+	})
+	if err != nil {
+		return wrapCollectionError(err)
+	}
+	return nil
+}
+
+// GetCollections получает подборки пользователя, при непустом listingID отмечает подборки с этим объявлением
+func (r *ListingRepoGRPC) GetCollections(userID uuid.UUID, listingID uuid.UUID) ([]Collection, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetCollections(ctx, &listingpb.GetCollectionsRequest{
+		UserId:    userID.String(),
+		ListingId: uuidOrEmpty(listingID),
+	})
+	if err != nil {
+		return nil, wrapCollectionError(err)
+	}
+
+	collections := make([]Collection, 0, len(resp.Collections))
+	for _, item := range resp.Collections {
+		collection, err := collectionFromProto(item)
+		if err != nil {
+			continue
+		}
+		collections = append(collections, collection)
+	}
+	return collections, nil
+}
+
+// AddToCollection добавляет объявление в подборку
+func (r *ListingRepoGRPC) AddToCollection(collectionID uuid.UUID, userID uuid.UUID, listingID uuid.UUID) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := r.service.AddToCollection(ctx, &listingpb.CollectionItemRequest{
+		CollectionId: collectionID.String(),
+		UserId:       userID.String(),
+		ListingId:    listingID.String(),
+	})
+	if err != nil {
+		return wrapCollectionError(err)
+	}
+	return nil
+}
+
+// RemoveFromCollection убирает объявление из подборки
+func (r *ListingRepoGRPC) RemoveFromCollection(collectionID uuid.UUID, userID uuid.UUID, listingID uuid.UUID) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := r.service.RemoveFromCollection(ctx, &listingpb.CollectionItemRequest{
+		CollectionId: collectionID.String(),
+		UserId:       userID.String(),
+		ListingId:    listingID.String(),
+	})
+	if err != nil {
+		return wrapCollectionError(err)
+	}
+	return nil
+}
+
+// ShareCollection открывает или закрывает доступ к подборке по ссылке
+func (r *ListingRepoGRPC) ShareCollection(collectionID uuid.UUID, userID uuid.UUID, shared bool) (Collection, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.ShareCollection(ctx, &listingpb.ShareCollectionRequest{
+		CollectionId: collectionID.String(),
+		UserId:       userID.String(),
+		Shared:       shared,
+	})
+	if err != nil {
+		return Collection{}, wrapCollectionError(err)
+	}
+	return collectionFromProto(resp)
+}
+
+// GetSharedCollection получает подборку по токену публичной ссылки
+func (r *ListingRepoGRPC) GetSharedCollection(shareToken string) (Collection, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetSharedCollection(ctx, &listingpb.GetSharedCollectionRequest{ShareToken: shareToken})
+	if err != nil {
+		return Collection{}, wrapCollectionError(err)
+	}
+	return collectionFromProto(resp)
+}
+
+func collectionFromProto(item *listingpb.Collection) (Collection, error) {
+	id, err := uuid.Parse(item.Id)
+	if err != nil {
+		return Collection{}, err
+	}
+	userID, err := uuid.Parse(item.UserId)
+	if err != nil {
+		return Collection{}, err
+	}
+
+	return Collection{
+		ID:              id,
+		UserID:          userID,
+		Name:            item.Name,
+		ShareToken:      item.ShareToken,
+		ListingsCount:   int(item.ListingsCount),
+		ContainsListing: item.ContainsListing,
+		CreatedAt:       item.CreatedAt.AsTime(),
+	}, nil
+}
+
+// wrapFollowError преобразует ошибку сервиса объявлений при работе с подписками
+func wrapFollowError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidFollow, status.Convert(err).Message())
+	case codes.NotFound:
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// FollowUser подписывает пользователя на продавца
+func (r *ListingRepoGRPC) FollowUser(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.FollowUser(ctx, &listingpb.FollowRequest{
+		FollowerId: followerID.String(),
+		SellerId:   sellerID.String(),
+	})
+	if err != nil {
+		return FollowStats{}, wrapFollowError(err)
+	}
+	return followStatsFromProto(resp)
+}
+
+// UnfollowUser отменяет подписку на продавца
+func (r *ListingRepoGRPC) UnfollowUser(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.UnfollowUser(ctx, &listingpb.FollowRequest{
+		FollowerId: followerID.String(),
+		SellerId:   sellerID.String(),
+	})
+	if err != nil {
+		return FollowStats{}, wrapFollowError(err)
+	}
+	return followStatsFromProto(resp)
+}
+
+// GetFollowStats получает количество подписчиков и подписок продавца
+func (r *ListingRepoGRPC) GetFollowStats(followerID uuid.UUID, sellerID uuid.UUID) (FollowStats, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetFollowStats(ctx, &listingpb.FollowRequest{
+		FollowerId: followerID.String(),
+		SellerId:   sellerID.String(),
+	})
+	if err != nil {
+		return FollowStats{}, wrapFollowError(err)
+	}
+	return followStatsFromProto(resp)
+}
+
+func followStatsFromProto(item *listingpb.FollowStats) (FollowStats, error) {
+	userID, err := uuid.Parse(item.UserId)
+	if err != nil {
+		return FollowStats{}, err
+	}
+	return FollowStats{
+		UserID:         userID,
+		FollowersCount: item.FollowersCount,
+		FollowingCount: item.FollowingCount,
+		IsFollowing:    item.IsFollowing,
+	}, nil
+}
+
+// wrapQuestionError преобразует ошибку сервиса объявлений при работе с вопросами,
+// notFound - ошибка для отсутствующего объявления или вопроса
+func wrapQuestionError(err error, notFound error) error {
+	msg := status.Convert(err).Message()
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidQuestion, msg)
+	case codes.NotFound:
+		return notFound
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", ErrQuestionForbidden, msg)
+	}
+	return err
+}
+
+// AskQuestion задаёт вопрос по объявлению
+func (r *ListingRepoGRPC) AskQuestion(listingID uuid.UUID, userID uuid.UUID, text string) (Question, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.AskQuestion(ctx, &listingpb.AskQuestionRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Text:      text,
+	})
+	if err != nil {
+		return Question{}, wrapQuestionError(err, ErrListingNotFound)
+	}
+	return questionFromProto(resp)
+}
+
+// AnswerQuestion отвечает на вопрос, доступно только автору объявления
+func (r *ListingRepoGRPC) AnswerQuestion(questionID uuid.UUID, userID uuid.UUID, text string) (Question, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.AnswerQuestion(ctx, &listingpb.AnswerQuestionRequest{
+		QuestionId: questionID.String(),
+		UserId:     userID.String(),
+		Text:       text,
+	})
+	if err != nil {
+		return Question{}, wrapQuestionError(err, ErrQuestionNotFound)
+	}
+	return questionFromProto(resp)
+}
+
+// GetQuestions получает видимые пользователю вопросы по объявлению
+func (r *ListingRepoGRPC) GetQuestions(listingID uuid.UUID, userID uuid.UUID, page int) (questions []Question, totalPages int64, currentPage int64, err error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetQuestions(ctx, &listingpb.GetQuestionsRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Page:      int64(page),
+	})
+	if err != nil {
+		return nil, 0, 0, wrapQuestionError(err, ErrListingNotFound)
+	}
+
+	questions = make([]Question, 0, len(resp.Questions))
+	for _, item := range resp.Questions {
+		question, err := questionFromProto(item)
+		if err != nil {
+			continue
+		}
+		questions = append(questions, question)
+	}
+	return questions, resp.TotalPages, resp.CurrentPage, nil
+}
+
+// HideQuestion скрывает вопрос или возвращает его в выдачу, доступно только модератору
+func (r *ListingRepoGRPC) HideQuestion(questionID uuid.UUID, userID uuid.UUID, hidden bool) (Question, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.HideQuestion(ctx, &listingpb.HideQuestionRequest{
+		QuestionId: questionID.String(),
+		UserId:     userID.String(),
+		Hidden:     hidden,
+	})
+	if status.Code(err) == codes.PermissionDenied {
+		return Question{}, ErrNotModerator
+	}
+	if err != nil {
+		return Question{}, wrapQuestionError(err, ErrQuestionNotFound)
+	}
+	return questionFromProto(resp)
+}
+
+// SuggestListings подсказывает поисковые запросы и названия объявлений по началу ввода
+func (r *ListingRepoGRPC) SuggestListings(prefix string, limit int) ([]Suggestion, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.SuggestListings(ctx, &listingpb.SuggestRequest{
+		Prefix: prefix,
+		Limit:  int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	suggestions := make([]Suggestion, 0, len(resp.Suggestions))
+	for _, item := range resp.Suggestions {
+		suggestion := Suggestion{Text: item.Text, Kind: item.Kind}
+		if item.ListingId != "" {
+			id, err := uuid.Parse(item.ListingId)
+			if err != nil {
+				continue
+			}
+			suggestion.ListingID = &id
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+// GetListingHistory получает историю изменений объявления, доступно автору и модераторам
+func (r *ListingRepoGRPC) GetListingHistory(listingID uuid.UUID, userID uuid.UUID, page int) (entries []HistoryEntry, totalPages int64, currentPage int64, err error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + listingToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := r.service.GetListingHistory(ctx, &listingpb.GetListingHistoryRequest{
+		ListingId: listingID.String(),
+		UserId:    userID.String(),
+		Page:      int64(page),
+	})
+	switch status.Code(err) {
+	case codes.OK:
+	case codes.NotFound:
+		return nil, 0, 0, ErrListingNotFound
+	case codes.PermissionDenied:
+		return nil, 0, 0, ErrHistoryForbidden
+	default:
+		return nil, 0, 0, err
+	}
+
+	entries = make([]HistoryEntry, 0, len(resp.Entries))
+	for _, item := range resp.Entries {
+		entry := HistoryEntry{
+			ActorLogin: item.ActorLogin,
+			Action:     item.Action,
+			Changes:    make([]FieldChange, 0, len(item.Changes)),
+		}
+		if entry.ID, err = uuid.Parse(item.Id); err != nil {
+			continue
+		}
+		if entry.ListingID, err = uuid.Parse(item.ListingId); err != nil {
+			continue
+		}
+		if item.ActorId != "" {
+			actorID, err := uuid.Parse(item.ActorId)
+			if err != nil {
+				continue
+			}
+			entry.ActorID = &actorID
+		}
+		for _, change := range item.Changes {
+			entry.Changes = append(entry.Changes, FieldChange{
+				Field:  change.Field,
+				Before: json.RawMessage(change.Before),
+				After:  json.RawMessage(change.After),
+			})
+		}
+		if item.CreatedAt != nil {
+			entry.CreatedAt = item.CreatedAt.AsTime()
+		}
+		entries = append(entries, entry)
+	}
+	return entries, resp.TotalPages, resp.CurrentPage, nil
+}
+
+func questionFromProto(item *listingpb.Question) (Question, error) {
+	question := Question{
+		AskerLogin: item.AskerLogin,
+		Text:       item.Text,
+		Answer:     item.Answer,
+		AnsweredAt: timeOrNil(item.AnsweredAt),
+		Hidden:     item.Hidden,
+		CreatedAt:  item.CreatedAt.AsTime(),
+	}
+
+	var err error
+	for _, f := range []struct {
+		dst *uuid.UUID
+		src string
+	}{
+		{&question.ID, item.Id},
+		{&question.ListingID, item.ListingId},
+		{&question.AskerID, item.AskerId},
+	} {
+		if *f.dst, err = uuid.Parse(f.src); err != nil {
+			return Question{}, err
+		}
+	}
+	return question, nil
+}
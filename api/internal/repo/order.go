@@ -0,0 +1,227 @@
+package repo
+
+import (
+	"api/internal/proto/orderpb"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// OrderRepoGRPC реализует взаимодействие с сервисом заказов через gRPC
+type OrderRepoGRPC struct {
+	service orderpb.OrderServiceClient // gRPC клиент для взаимодействия с сервисом заказов
+}
+
+// Проверка реализации интерфейса OrderRepo
+var _ OrderRepo = &OrderRepoGRPC{}
+
+// NewOrderRepo создает новый экземпляр репозитория заказов
+func NewOrderRepo(conn *grpc.ClientConn) *OrderRepoGRPC {
+	return &OrderRepoGRPC{
+		service: orderpb.NewOrderServiceClient(conn),
+	}
+}
+
+const (
+	orderToken = "order-token"
+)
+
+// ErrOrderNotFound возвращается, если заказ не существует или пользователь в нём не участвует
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrInvalidOrder возвращается при неверных параметрах заказа или уведомления о платеже
+var ErrInvalidOrder = errors.New("invalid order")
+
+// ErrOrderForbidden возвращается, если пользователь не может оформить заказ или выполнить действие с ним
+var ErrOrderForbidden = errors.New("order action forbidden")
+
+// ErrOrderConflict возвращается, если заказ или объявление в неподходящем для действия состоянии
+var ErrOrderConflict = errors.New("order conflict")
+
+// ErrPaymentUnavailable возвращается, если платёжный провайдер не ответил
+var ErrPaymentUnavailable = errors.New("payment provider unavailable")
+
+// ErrPaymentNotFound возвращается на уведомление о неизвестном платеже
+var ErrPaymentNotFound = errors.New("payment not found")
+
+// wrapOrderError преобразует ошибку сервиса заказов в ошибку репозитория
+func wrapOrderError(err error, notFound error) error {
+	msg := status.Convert(err).Message()
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidOrder, msg)
+	case codes.NotFound:
+		return fmt.Errorf("%w: %s", notFound, msg)
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %s", ErrOrderForbidden, msg)
+	case codes.FailedPrecondition:
+		return fmt.Errorf("%w: %s", ErrOrderConflict, msg)
+	case codes.Unavailable:
+		return fmt.Errorf("%w: %s", ErrPaymentUnavailable, msg)
+	}
+	return err
+}
+
+func orderContext() context.Context {
+	md := metadata.New(map[string]string{
+		authorization: bearer + orderToken,
+	})
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
+// CreateOrder оформляет заказ на объявление, при непустом offerID - по принятому предложению цены
+func (r *OrderRepoGRPC) CreateOrder(listingID uuid.UUID, buyerID uuid.UUID, offerID uuid.UUID) (Order, error) {
+	req := &orderpb.CreateOrderRequest{
+		ListingId: listingID.String(),
+		BuyerId:   buyerID.String(),
+	}
+	if offerID != uuid.Nil {
+		req.OfferId = offerID.String()
+	}
+
+	resp, err := r.service.CreateOrder(orderContext(), req)
+	if err != nil {
+		// Сервис заказов отвечает NotFound и на отсутствующее объявление, и на чужое предложение
+		return Order{}, wrapOrderError(err, ErrListingNotFound)
+	}
+	return orderFromProto(resp)
+}
+
+// GetOrder получает заказ, в котором пользователь покупатель или продавец
+func (r *OrderRepoGRPC) GetOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error) {
+	resp, err := r.service.GetOrder(orderContext(), &orderpb.GetOrderRequest{
+		OrderId: orderID.String(),
+		UserId:  userID.String(),
+	})
+	if err != nil {
+		return Order{}, wrapOrderError(err, ErrOrderNotFound)
+	}
+	return orderFromProto(resp)
+}
+
+// GetOrders получает заказы пользователя, роль buyer или seller оставляет только покупки или продажи
+func (r *OrderRepoGRPC) GetOrders(userID uuid.UUID, role string) ([]Order, error) {
+	resp, err := r.service.GetOrders(orderContext(), &orderpb.GetOrdersRequest{
+		UserId: userID.String(),
+		Role:   role,
+	})
+	if err != nil {
+		return nil, wrapOrderError(err, ErrOrderNotFound)
+	}
+
+	orders := make([]Order, 0, len(resp.Orders))
+	for _, item := range resp.Orders {
+		order, err := orderFromProto(item)
+		if err != nil {
+			continue
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// CancelOrder отменяет неоплаченный заказ
+func (r *OrderRepoGRPC) CancelOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error) {
+	resp, err := r.service.CancelOrder(orderContext(), &orderpb.OrderActionRequest{
+		OrderId: orderID.String(),
+		UserId:  userID.String(),
+	})
+	if err != nil {
+		return Order{}, wrapOrderError(err, ErrOrderNotFound)
+	}
+	return orderFromProto(resp)
+}
+
+// RefundOrder запрашивает возврат оплаченного заказа
+func (r *OrderRepoGRPC) RefundOrder(orderID uuid.UUID, userID uuid.UUID) (Order, error) {
+	resp, err := r.service.RefundOrder(orderContext(), &orderpb.OrderActionRequest{
+		OrderId: orderID.String(),
+		UserId:  userID.String(),
+	})
+	if err != nil {
+		return Order{}, wrapOrderError(err, ErrOrderNotFound)
+	}
+	return orderFromProto(resp)
+}
+
+// HandlePaymentWebhook передаёт уведомление платёжного провайдера сервису заказов
+func (r *OrderRepoGRPC) HandlePaymentWebhook(event PaymentEvent) (Order, bool, error) {
+	resp, err := r.service.HandlePaymentWebhook(orderContext(), &orderpb.PaymentWebhookRequest{
+		EventId:   event.EventID,
+		Type:      event.Type,
+		PaymentId: event.PaymentID,
+		Amount:    int64(event.Amount),
+	})
+	if err != nil {
+		return Order{}, false, wrapOrderError(err, ErrPaymentNotFound)
+	}
+
+	order, err := orderFromProto(resp.Order)
+	if err != nil {
+		return Order{}, false, err
+	}
+	return order, resp.Duplicate, nil
+}
+
+func orderFromProto(item *orderpb.Order) (Order, error) {
+	order := Order{
+		Amount:            int(item.Amount),
+		Status:            item.Status,
+		PaymentID:         item.PaymentId,
+		PaymentURL:        item.PaymentUrl,
+		CreatedAt:         item.CreatedAt.AsTime(),
+		ExpiresAt:         item.ExpiresAt.AsTime(),
+		PaidAt:            timeOrNil(item.PaidAt),
+		CancelledAt:       timeOrNil(item.CancelledAt),
+		RefundRequestedAt: timeOrNil(item.RefundRequestedAt),
+		RefundedAt:        timeOrNil(item.RefundedAt),
+	}
+
+	var err error
+	for _, f := range []struct {
+		dst *uuid.UUID
+		src string
+	}{
+		{&order.ID, item.Id},
+		{&order.BuyerID, item.BuyerId},
+		{&order.SellerID, item.SellerId},
+	} {
+		if *f.dst, err = uuid.Parse(f.src); err != nil {
+			return Order{}, err
+		}
+	}
+
+	for _, f := range []struct {
+		dst **uuid.UUID
+		src string
+	}{
+		{&order.ListingID, item.ListingId},
+		{&order.OfferID, item.OfferId},
+	} {
+		if f.src == "" {
+			continue
+		}
+		id, err := uuid.Parse(f.src)
+		if err != nil {
+			return Order{}, err
+		}
+		*f.dst = &id
+	}
+	return order, nil
+}
+
+func timeOrNil(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
@@ -81,3 +81,21 @@ func (r *SessionRepoGRPC) DeleteSession(sessionID uuid.UUID) (userID uuid.UUID,
 
 	return uuid.Parse(resp.UserId)
 }
+
+// DeleteUserSessions удаляет все сессии пользователя, кроме exceptSessionID, и возвращает их количество
+func (r *SessionRepoGRPC) DeleteUserSessions(userID uuid.UUID, exceptSessionID uuid.UUID) (int, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + sessionToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	req := &sessionpb.DeleteUserSessionsRequest{UserId: userID.String()}
+	if exceptSessionID != uuid.Nil {
+		req.ExceptSessionId = exceptSessionID.String()
+	}
+	resp, err := r.db.DeleteUserSessions(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+	return int(resp.Deleted), nil
+}
@@ -3,10 +3,14 @@ package repo
 import (
 	"api/internal/proto/userpb"
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 // UserRepoGRPC реализует взаимодействие с сервисом пользователей через gRPC
@@ -28,6 +32,15 @@ const (
 	userToken = "user-token"
 )
 
+// ErrInvalidProfile возвращается, если поля профиля не прошли проверку сервиса пользователей
+var ErrInvalidProfile = errors.New("invalid profile")
+
+// ErrWrongPassword возвращается, если старый пароль при смене пароля не совпал
+var ErrWrongPassword = errors.New("wrong password")
+
+// ErrInvalidResetToken возвращается, если токен сброса пароля не найден, уже использован или истёк
+var ErrInvalidResetToken = errors.New("invalid password reset token")
+
 // CreateAccount создает новую учетную запись
 func (r *UserRepoGRPC) CreateAccount(username string, pass string) (uuid.UUID, error) {
 	md := metadata.New(map[string]string{
@@ -51,18 +64,129 @@ func (r *UserRepoGRPC) CreateAccount(username string, pass string) (uuid.UUID, e
 	return uuid.MustParse(resp.Id), nil
 }
 
-// CheckPass проверяет учетные данные пользователя
-func (r *UserRepoGRPC) CheckPass(username string, pass string) (uuid.UUID, error) {
+// CheckPass проверяет учетные данные пользователя. legacyPass - пароль в прежнем обратимом
+// шифровании, по нему проверяются учётные записи, пароль которых ещё не заменён хэшем
+func (r *UserRepoGRPC) CheckPass(username string, pass string, legacyPass string) (uuid.UUID, error) {
 	md := metadata.New(map[string]string{
 		authorization: bearer + userToken,
 	})
 	ctx := metadata.NewOutgoingContext(context.Background(), md)
 	resp, err := r.db.CheckCredentials(ctx, &userpb.CredentialsRequest{
-		Username: username,
-		Password: pass,
+		Username:       username,
+		Password:       pass,
+		LegacyPassword: legacyPass,
 	})
 	if err != nil {
 		return uuid.Nil, err
 	}
 	return uuid.MustParse(resp.Id), nil
 }
+
+// wrapProfileError преобразует ошибку сервиса пользователей при работе с профилем
+func wrapProfileError(err error) error {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		return fmt.Errorf("%w: %s", ErrInvalidProfile, status.Convert(err).Message())
+	case codes.NotFound:
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// profileFromProto преобразует профиль из ответа сервиса пользователей
+func profileFromProto(p *userpb.UserProfile) UserProfile {
+	return UserProfile{
+		ID:           uuid.MustParse(p.Id),
+		Username:     p.Username,
+		DisplayName:  p.DisplayName,
+		AvatarURL:    p.AvatarUrl,
+		Bio:          p.Bio,
+		City:         p.City,
+		RegisteredAt: p.RegisteredAt.AsTime(),
+		Email:        p.Email,
+	}
+}
+
+// GetUser возвращает публичный профиль пользователя
+func (r *UserRepoGRPC) GetUser(userID uuid.UUID) (UserProfile, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + userToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := r.db.GetUser(ctx, &userpb.GetUserRequest{Id: userID.String()})
+	if err != nil {
+		return UserProfile{}, wrapProfileError(err)
+	}
+	return profileFromProto(resp), nil
+}
+
+// UpdateProfile изменяет заданные поля профиля и возвращает профиль после изменения
+func (r *UserRepoGRPC) UpdateProfile(userID uuid.UUID, update ProfileUpdate) (UserProfile, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + userToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := r.db.UpdateProfile(ctx, &userpb.UpdateProfileRequest{
+		Id:          userID.String(),
+		DisplayName: update.DisplayName,
+		AvatarUrl:   update.AvatarURL,
+		Bio:         update.Bio,
+		City:        update.City,
+		Email:       update.Email,
+	})
+	if err != nil {
+		return UserProfile{}, wrapProfileError(err)
+	}
+	return profileFromProto(resp), nil
+}
+
+// ChangePassword меняет пароль пользователя по старому паролю
+func (r *UserRepoGRPC) ChangePassword(userID uuid.UUID, oldPass string, legacyOldPass string, newPass string) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + userToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	_, err := r.db.ChangePassword(ctx, &userpb.ChangePasswordRequest{
+		UserId:            userID.String(),
+		OldPassword:       oldPass,
+		LegacyOldPassword: legacyOldPass,
+		NewPassword:       newPass,
+	})
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return ErrWrongPassword
+	case codes.NotFound:
+		return ErrUserNotFound
+	}
+	return err
+}
+
+// RequestPasswordReset отправляет пользователю письмо со ссылкой сброса пароля. Если пользователя
+// нет или у него не указана почта, ошибки нет
+func (r *UserRepoGRPC) RequestPasswordReset(username string) error {
+	md := metadata.New(map[string]string{
+		authorization: bearer + userToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	_, err := r.db.RequestPasswordReset(ctx, &userpb.UsernameRequest{Username: username})
+	return err
+}
+
+// ResetPassword задаёт новый пароль по токену из письма
+func (r *UserRepoGRPC) ResetPassword(token string, newPass string) (uuid.UUID, error) {
+	md := metadata.New(map[string]string{
+		authorization: bearer + userToken,
+	})
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+	resp, err := r.db.ResetPassword(ctx, &userpb.ResetPasswordRequest{
+		Token:       token,
+		NewPassword: newPass,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return uuid.Nil, ErrInvalidResetToken
+		}
+		return uuid.Nil, err
+	}
+	return uuid.Parse(resp.Id)
+}
@@ -1,6 +1,7 @@
 package response
 
 import (
+	"api/internal/i18n"
 	"api/internal/logger"
 	"encoding/json"
 	"net/http"
@@ -8,14 +9,15 @@ import (
 
 // APIResponse определяет структуру ответа API
 type APIResponse struct {
-	Success bool        `json:"success"`        // Флаг успешности операции
-	Code    int         `json:"code"`           // HTTP код ответа
-	Message string      `json:"message"`        // Сообщение для пользователя
-	Data    interface{} `json:"data,omitempty"` // Данные ответа (опционально)
+	Success     bool        `json:"success"`                // Флаг успешности операции
+	Code        int         `json:"code"`                   // HTTP код ответа
+	Message     string      `json:"message"`                // Сообщение для пользователя на его языке
+	MessageCode string      `json:"message_code,omitempty"` // Код сообщения, не зависящий от языка
+	Data        interface{} `json:"data,omitempty"`         // Данные ответа (опционально)
 }
 
 // WriteAPIResponse формирует и отправляет JSON-ответ клиенту
-// Устанавливает заголовки ответа, сериализует данные и логирует ошибки при неудаче
+// Устанавливает заголовки ответа, переводит сообщение на язык запроса, сериализует данные и логирует ошибки при неудаче
 func WriteAPIResponse(w http.ResponseWriter, statusCode int, success bool, message string, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -23,9 +25,12 @@ func WriteAPIResponse(w http.ResponseWriter, statusCode int, success bool, messa
 	resp := APIResponse{
 		Success: success,
 		Code:    statusCode,
-		Message: message,
+		Message: i18n.Translate(i18n.FromWriter(w), message),
 		Data:    data,
 	}
+	if i18n.Known(message) {
+		resp.MessageCode = message
+	}
 
 	err := json.NewEncoder(w).Encode(resp)
 	if err != nil {
@@ -1,9 +1,11 @@
 package router
 
 import (
+	"api/internal/contentfilter"
 	"api/internal/encryption"
 	"api/internal/handlers"
 	"api/internal/healthcheck"
+	"api/internal/i18n"
 	"api/internal/logger"
 	"api/internal/middleware"
 	"api/internal/repo"
@@ -28,7 +30,7 @@ var coef1 int // коэффициент для таймаута соединен
 var coef2 int // коэффициент для частоты проверки состояния соединений
 
 // Адреса микросервисов
-var userAddr, sessionAddr, listingAddr string
+var userAddr, sessionAddr, listingAddr, orderAddr string
 
 // init инициализирует секретный ключ для JWT токенов
 func init() {
@@ -38,6 +40,7 @@ func init() {
 	userAddr = viper.GetString("user.addr")
 	sessionAddr = viper.GetString("session.addr")
 	listingAddr = viper.GetString("listing.addr")
+	orderAddr = viper.GetString("order.addr")
 }
 
 func gracefulStop(healthcheck *healthcheck.GrpcHealthChecker) {
@@ -76,6 +79,13 @@ func CreateNewRouter() *mux.Router {
 		log.Fatalf("failed to connect to listing service: %v", err)
 	}
 
+	orderConn, err := grpc.DialContext(ctx, orderAddr, //nolint:staticcheck
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithBlock()) //nolint:staticcheck
+	if err != nil {
+		log.Fatalf("failed to connect to order service: %v", err)
+	}
+
 	// Инициализируем проверку здоровья сервисов
 	healthChecker := healthcheck.NewHealthChecker(time.Duration(coef2) * time.Second)
 
@@ -85,6 +95,7 @@ func CreateNewRouter() *mux.Router {
 	healthChecker.AddConnection("user-service", userConn)
 	healthChecker.AddConnection("session-service", sessionConn)
 	healthChecker.AddConnection("listing-service", listingConn)
+	healthChecker.AddConnection("order-service", orderConn)
 
 	logger.InitLogger("logs")
 
@@ -92,6 +103,7 @@ func CreateNewRouter() *mux.Router {
 	userRepo := repo.NewUserRepo(userConn)
 	sessionRepo := repo.NewSessionRepo(sessionConn)
 	listingRepo := repo.NewListingRepo(listingConn)
+	orderRepo := repo.NewOrderRepo(orderConn)
 
 	// Создаем обработчики запросов
 	authHandler := &handlers.AuthHandler{
@@ -106,13 +118,42 @@ func CreateNewRouter() *mux.Router {
 		Token:   tokenRepo,
 	}
 
+	// Загружаем правила фильтра содержимого объявлений, файл перечитывается при изменении
+	var contentFilter *contentfilter.Filter
+	if path := viper.GetString("contentFilter.path"); path != "" {
+		contentFilter, err = contentfilter.New(path)
+		if err != nil {
+			log.Fatalf("failed to load content filter: %v", err)
+		}
+	}
+
+	// Шаблоны страниц разбираются один раз при запуске
+	if err := handlers.LoadPages("assets/html"); err != nil {
+		log.Fatalf("failed to load page templates: %v", err)
+	}
+
 	listingHandler := &handlers.ListingHandler{
 		Listing: listingRepo,
+		User:    userRepo,
+		Filter:  contentFilter,
+		Feeds:   handlers.NewFeedCache(time.Duration(viper.GetInt("api.feedsCacheTTL")) * time.Second),
+	}
+
+	userHandler := &handlers.UserHandler{
+		User: userRepo,
+	}
+
+	orderHandler := &handlers.OrderHandler{
+		Order:         orderRepo,
+		WebhookSecret: []byte(viper.GetString("payment.webhookSecret")),
 	}
 
 	// Создаем основной роутер
 	router := mux.NewRouter()
 
+	// Определяем язык каждого запроса для перевода ответов и страниц
+	router.Use(i18n.Middleware)
+
 	// Настраиваем раздачу статических файлов
 	router.PathPrefix("/assets/").Handler(http.StripPrefix("/assets/", http.FileServer(http.Dir("assets"))))
 	router.PathPrefix("/uploads/").Handler(http.StripPrefix("/uploads/", http.FileServer(http.Dir("uploads"))))
@@ -124,13 +165,46 @@ func CreateNewRouter() *mux.Router {
 	router.HandleFunc("/api/login", authHandler.LogIN).Methods("POST")
 	router.HandleFunc("/api/register", authHandler.Register).Methods("POST")
 	router.HandleFunc("/api/logout", authHandler.LogOUT).Methods("DELETE")
+	router.HandleFunc("/api/password/reset-request", authHandler.RequestPasswordReset).Methods("POST")
+	router.HandleFunc("/api/password/reset", authHandler.ResetPassword).Methods("POST")
 
 	// Маршруты для авторизованных пользователей
 	userRouter := router.NewRoute().Subrouter()
 	userRouter.Use(middlewareHandler.CheckSes)
 	userRouter.HandleFunc("/api/listings", listingHandler.AddListing).Methods("POST")
 	userRouter.HandleFunc("/api/edit", listingHandler.EditListing).Methods("POST")
+	userRouter.HandleFunc("/api/listings/trash", listingHandler.GetTrash).Methods("GET")
+	userRouter.HandleFunc("/api/listings/import", listingHandler.ImportListings).Methods("POST")
+	userRouter.HandleFunc("/api/listings/import/{id}", listingHandler.GetImportJob).Methods("GET")
+	userRouter.HandleFunc("/api/feeds/token", listingHandler.GetFeedToken).Methods("GET")
+	userRouter.HandleFunc("/api/feeds/token", listingHandler.RegenerateFeedToken).Methods("POST")
 	userRouter.HandleFunc("/api/listings/{id}", listingHandler.DeleteListing).Methods("DELETE")
+	userRouter.HandleFunc("/api/listings/{id}/restore", listingHandler.RestoreListing).Methods("POST")
+	userRouter.HandleFunc("/api/listings/{id}/history", listingHandler.GetListingHistory).Methods("GET")
+	userRouter.HandleFunc("/api/listings/for-you", listingHandler.GetRecommendations).Methods("GET")
+	userRouter.HandleFunc("/api/listings/{id}/promotions", listingHandler.CreatePromotion).Methods("POST")
+	userRouter.HandleFunc("/api/promotions", listingHandler.GetPromotions).Methods("GET")
+	userRouter.HandleFunc("/api/moderation/duplicates", listingHandler.GetDuplicates).Methods("GET")
+	userRouter.HandleFunc("/api/listings/{id}/offers", listingHandler.MakeOffer).Methods("POST")
+	userRouter.HandleFunc("/api/offers", listingHandler.GetOffers).Methods("GET")
+	userRouter.HandleFunc("/api/offers/{id}/{action:accept|reject|counter}", listingHandler.RespondOffer).Methods("POST")
+	userRouter.HandleFunc("/api/listings/{id}/bids", listingHandler.PlaceBid).Methods("POST")
+	userRouter.HandleFunc("/api/orders", orderHandler.CreateOrder).Methods("POST")
+	userRouter.HandleFunc("/api/orders", orderHandler.GetOrders).Methods("GET")
+	userRouter.HandleFunc("/api/orders/{id}", orderHandler.GetOrder).Methods("GET")
+	userRouter.HandleFunc("/api/orders/{id}/{action:cancel|refund}", orderHandler.UpdateOrder).Methods("POST")
+	userRouter.HandleFunc("/api/collections", listingHandler.CreateCollection).Methods("POST")
+	userRouter.HandleFunc("/api/collections", listingHandler.GetCollections).Methods("GET")
+	userRouter.HandleFunc("/api/collections/{id}", listingHandler.RenameCollection).Methods("PATCH")
+	userRouter.HandleFunc("/api/collections/{id}", listingHandler.DeleteCollection).Methods("DELETE")
+	userRouter.HandleFunc("/api/collections/{id}/listings/{listing_id}", listingHandler.UpdateCollectionListing).Methods("POST", "DELETE")
+	userRouter.HandleFunc("/api/collections/{id}/share", listingHandler.ShareCollection).Methods("POST")
+	userRouter.HandleFunc("/api/users/{id}/follow", listingHandler.UpdateFollow).Methods("POST", "DELETE")
+	userRouter.HandleFunc("/api/listings/{id}/questions", listingHandler.AskQuestion).Methods("POST")
+	userRouter.HandleFunc("/api/questions/{id}/answer", listingHandler.AnswerQuestion).Methods("POST")
+	userRouter.HandleFunc("/api/moderation/questions/{id}/{action:hide|show}", listingHandler.ModerateQuestion).Methods("POST")
+	userRouter.HandleFunc("/api/me", userHandler.UpdateProfile).Methods("PATCH")
+	userRouter.HandleFunc("/api/me/password", authHandler.ChangePassword).Methods("POST")
 	userRouter.HandleFunc("/api/addlike", listingHandler.AddLike).Methods("POST")
 	userRouter.HandleFunc("/api/removelike", listingHandler.RemoveLike).Methods("POST")
 
@@ -138,6 +212,37 @@ func CreateNewRouter() *mux.Router {
 	allUserRouter := router.NewRoute().Subrouter()
 	allUserRouter.Use(middlewareHandler.CheckSesWithNilOnError)
 	allUserRouter.HandleFunc("/api/listings", listingHandler.GetAllListings).Methods("GET")
+	allUserRouter.HandleFunc("/api/listings/facets", listingHandler.GetListingFacets).Methods("GET")
+	allUserRouter.HandleFunc("/api/listings/suggest", listingHandler.SuggestListings).Methods("GET")
+	allUserRouter.HandleFunc("/api/listings/{id}/similar", listingHandler.GetSimilarListings).Methods("GET")
+	allUserRouter.HandleFunc("/api/listings/{id}/view", listingHandler.RecordView).Methods("POST")
+	allUserRouter.HandleFunc("/api/listings/{id}/auction", listingHandler.GetAuction).Methods("GET")
+	allUserRouter.HandleFunc("/api/users/{id}/follow", listingHandler.GetFollowStats).Methods("GET")
+	allUserRouter.HandleFunc("/api/listings/{id}/questions", listingHandler.GetQuestions).Methods("GET")
+	allUserRouter.HandleFunc("/api/categories", listingHandler.GetCategories).Methods("GET")
+
+	// Публичные профили пользователей
+	router.HandleFunc("/api/users/{id}", userHandler.GetUser).Methods("GET")
+
+	// Уведомления платёжного провайдера, доступ по подписи
+	router.HandleFunc("/api/payments/webhook", orderHandler.PaymentWebhook).Methods("POST")
+
+	// Открытые подборки, доступ по токену ссылки
+	router.HandleFunc("/api/collections/shared/{token}", listingHandler.GetSharedCollection).Methods("GET")
+
+	// Фиды для маркетплейсов, доступ по токену фида
+	router.HandleFunc("/api/feeds/{user_id}/avito.xml", listingHandler.AvitoFeed).Methods("GET")
+	router.HandleFunc("/api/feeds/{user_id}/yandex.yml", listingHandler.YandexFeed).Methods("GET")
+
+	// Страницы объявлений и продавцов, собранные на сервере
+	allUserRouter.HandleFunc("/listings/{id}", listingHandler.ListingPage).Methods("GET")
+	allUserRouter.HandleFunc("/sellers/{id}", listingHandler.SellerPage).Methods("GET")
+
+	// Карта сайта и RSS/Atom ленты для поисковиков и агрегаторов
+	router.HandleFunc("/sitemap.xml", listingHandler.Sitemap).Methods("GET", "HEAD")
+	router.HandleFunc("/sitemaps/listings-{shard:[0-9]+}.xml", listingHandler.SitemapShard).Methods("GET", "HEAD")
+	allUserRouter.HandleFunc("/feeds/listings.{format:rss|atom}", listingHandler.ListingsFeed).Methods("GET", "HEAD")
+	allUserRouter.HandleFunc("/feeds/sellers/{id}.{format:rss|atom}", listingHandler.SellerFeed).Methods("GET", "HEAD")
 
 	// Маршруты для статических страниц
 	router.HandleFunc("/", handlers.OutIndex)
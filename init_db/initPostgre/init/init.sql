@@ -2,9 +2,76 @@ CREATE TABLE IF NOT EXISTS users (
     id UUID PRIMARY KEY,
     username TEXT UNIQUE,
     pass TEXT,
-    liked_listings UUID[] DEFAULT '{}'
+    liked_listings UUID[] DEFAULT '{}',
+    role TEXT NOT NULL DEFAULT 'user',
+    display_name TEXT NOT NULL DEFAULT '',
+    avatar_url TEXT NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    city TEXT NOT NULL DEFAULT '',
+    registered_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    email TEXT NOT NULL DEFAULT ''
 );
 
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (lower(email)) WHERE email <> '';
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    token_hash TEXT PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS password_reset_tokens_user_idx ON password_reset_tokens (user_id);
+
+CREATE TABLE IF NOT EXISTS mail_outbox (
+    id UUID PRIMARY KEY,
+    recipient TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS mail_outbox_pending_idx ON mail_outbox (created_at) WHERE sent_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS categories (
+    id SERIAL PRIMARY KEY,
+    name TEXT UNIQUE NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS category_attributes (
+    category_id INT REFERENCES categories(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL CHECK (type IN ('string', 'int', 'bool', 'enum')),
+    enum_values TEXT[] DEFAULT '{}',
+    required BOOLEAN DEFAULT FALSE,
+    position INT DEFAULT 0,
+    PRIMARY KEY (category_id, name)
+);
+
+INSERT INTO categories (id, name) VALUES
+    (1, 'Электроника'),
+    (2, 'Одежда'),
+    (3, 'Автомобили'),
+    (4, 'Другое')
+ON CONFLICT DO NOTHING;
+
+SELECT setval('categories_id_seq', (SELECT MAX(id) FROM categories));
+
+INSERT INTO category_attributes (category_id, name, type, enum_values, required, position) VALUES
+    (1, 'condition', 'enum', '{new,used}', TRUE, 1),
+    (1, 'brand', 'string', '{}', FALSE, 2),
+    (2, 'condition', 'enum', '{new,used}', TRUE, 1),
+    (2, 'size', 'enum', '{XS,S,M,L,XL,XXL}', TRUE, 2),
+    (3, 'mileage', 'int', '{}', TRUE, 1),
+    (3, 'year', 'int', '{}', TRUE, 2),
+    (3, 'condition', 'enum', '{new,used,damaged}', FALSE, 3),
+    (3, 'customs_cleared', 'bool', '{}', FALSE, 4)
+ON CONFLICT DO NOTHING;
+
 CREATE TABLE IF NOT EXISTS listings (
     id UUID PRIMARY KEY,
     title TEXT NOT NULL,
@@ -14,5 +81,268 @@ CREATE TABLE IF NOT EXISTS listings (
     price INT NOT NULL,
     author_id UUID REFERENCES users(id) ON DELETE CASCADE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-    image_url TEXT
+    image_url TEXT,
+    deleted_at TIMESTAMP,
+    category_id INT REFERENCES categories(id),
+    status TEXT NOT NULL DEFAULT 'active',
+    trend_score DOUBLE PRECISION,
+    image_hash BIGINT,
+    text_hash BIGINT
+);
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id UUID PRIMARY KEY,
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active',
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS promotions_active_idx ON promotions (listing_id) WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS listing_duplicates (
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    duplicate_of UUID REFERENCES listings(id) ON DELETE CASCADE,
+    same_author BOOLEAN NOT NULL,
+    image_distance INT,
+    text_distance INT,
+    detected_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (listing_id, duplicate_of)
+);
+
+CREATE INDEX IF NOT EXISTS listing_duplicates_detected_idx ON listing_duplicates (detected_at DESC);
+
+CREATE TABLE IF NOT EXISTS offers (
+    id UUID PRIMARY KEY,
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    buyer_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    seller_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES offers(id) ON DELETE CASCADE,
+    from_seller BOOLEAN NOT NULL DEFAULT FALSE,
+    amount INT NOT NULL,
+    message TEXT,
+    status TEXT NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    responded_at TIMESTAMP,
+    reserved_until TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS offers_listing_idx ON offers (listing_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS offers_pending_idx ON offers (expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS offers_reserved_idx ON offers (reserved_until) WHERE status = 'accepted';
+
+CREATE TABLE IF NOT EXISTS auctions (
+    listing_id UUID PRIMARY KEY REFERENCES listings(id) ON DELETE CASCADE,
+    start_price INT NOT NULL,
+    min_increment INT NOT NULL,
+    current_price INT,
+    leader_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    winner_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    bids_count INT NOT NULL DEFAULT 0,
+    status TEXT NOT NULL DEFAULT 'open',
+    ends_at TIMESTAMP NOT NULL,
+    closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS auctions_open_idx ON auctions (ends_at) WHERE status = 'open';
+
+CREATE TABLE IF NOT EXISTS bids (
+    id UUID PRIMARY KEY,
+    listing_id UUID REFERENCES auctions(listing_id) ON DELETE CASCADE,
+    bidder_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    amount INT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS bids_listing_idx ON bids (listing_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS orders (
+    id UUID PRIMARY KEY,
+    listing_id UUID REFERENCES listings(id) ON DELETE SET NULL,
+    buyer_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    seller_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    offer_id UUID REFERENCES offers(id) ON DELETE SET NULL,
+    amount INT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    payment_id TEXT UNIQUE,
+    payment_url TEXT,
+    created_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    paid_at TIMESTAMP,
+    cancelled_at TIMESTAMP,
+    refund_requested_at TIMESTAMP,
+    refunded_at TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS orders_listing_active_idx ON orders (listing_id) WHERE status IN ('pending', 'paid');
+CREATE INDEX IF NOT EXISTS orders_buyer_idx ON orders (buyer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS orders_seller_idx ON orders (seller_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS orders_pending_idx ON orders (expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    event_id TEXT PRIMARY KEY,
+    order_id UUID REFERENCES orders(id) ON DELETE CASCADE,
+    type TEXT NOT NULL,
+    payment_id TEXT NOT NULL,
+    received_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS collections (
+    id UUID PRIMARY KEY,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    share_token TEXT UNIQUE,
+    created_at TIMESTAMP NOT NULL,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS collection_listings (
+    collection_id UUID REFERENCES collections(id) ON DELETE CASCADE,
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    added_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (collection_id, listing_id)
+);
+
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    seller_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (follower_id, seller_id),
+    CHECK (follower_id <> seller_id)
+);
+
+CREATE INDEX IF NOT EXISTS follows_seller_idx ON follows (seller_id);
+
+CREATE TABLE IF NOT EXISTS listing_questions (
+    id UUID PRIMARY KEY,
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    asker_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    text TEXT NOT NULL,
+    answer TEXT,
+    answered_at TIMESTAMP,
+    hidden_at TIMESTAMP,
+    hidden_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS listing_questions_listing_idx ON listing_questions (listing_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS search_queries (
+    query TEXT PRIMARY KEY,
+    trend_score DOUBLE PRECISION NOT NULL,
+    searches BIGINT NOT NULL DEFAULT 1,
+    users BIGINT NOT NULL DEFAULT 0,
+    last_searched_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS search_query_users (
+    query TEXT REFERENCES search_queries(query) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (query, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS search_queries_prefix_idx ON search_queries (query text_pattern_ops);
+CREATE INDEX IF NOT EXISTS search_queries_trend_idx ON search_queries (trend_score DESC);
+
+CREATE TABLE IF NOT EXISTS listing_history (
+    id UUID PRIMARY KEY,
+    listing_id UUID NOT NULL REFERENCES listings(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action TEXT NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS listing_history_listing_idx ON listing_history (listing_id, created_at DESC);
+
+-- Добавляет запись в историю объявления. before и after - значения изменившихся полей,
+-- в changes они хранятся как {"поле": {"before": ..., "after": ...}}. Историю пишут сервисы
+-- объявлений и заказов, формат записи задаётся только здесь
+CREATE OR REPLACE FUNCTION add_listing_history(
+    p_listing_id UUID, p_actor_id UUID, p_action TEXT, p_before JSONB, p_after JSONB, p_at TIMESTAMP
+) RETURNS VOID AS $$
+    INSERT INTO listing_history (id, listing_id, actor_id, action, changes, created_at)
+    SELECT gen_random_uuid(), p_listing_id, p_actor_id, p_action,
+        COALESCE(jsonb_object_agg(a.key, jsonb_build_object(
+            'before', COALESCE(p_before -> a.key, 'null'::jsonb),
+            'after', a.value
+        )), '{}'::jsonb),
+        p_at
+    FROM jsonb_each(COALESCE(p_after, '{}'::jsonb)) a;
+$$ LANGUAGE sql;
+
+CREATE TABLE IF NOT EXISTS listing_similarities (
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    similar_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    score DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (listing_id, similar_id)
+);
+
+CREATE TABLE IF NOT EXISTS listing_attributes (
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    value_string TEXT,
+    value_int BIGINT,
+    value_bool BOOLEAN,
+    PRIMARY KEY (listing_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS trend_likes (
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (listing_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS listing_views (
+    listing_id UUID REFERENCES listings(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (listing_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS listings_category_idx ON listings (category_id);
+CREATE INDEX IF NOT EXISTS listings_trend_idx ON listings (trend_score DESC NULLS LAST) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS listings_created_at_idx ON listings (created_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS listings_author_idx ON listings (author_id, created_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS listings_likes_idx ON listings (likes DESC, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS listing_attributes_string_idx ON listing_attributes (name, value_string);
+CREATE INDEX IF NOT EXISTS listing_attributes_int_idx ON listing_attributes (name, value_int);
+
+CREATE INDEX IF NOT EXISTS listings_deleted_at_idx ON listings (deleted_at) WHERE deleted_at IS NOT NULL;
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS listings_title_trgm_idx ON listings USING gin (lower(title) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS listings_title_prefix_idx ON listings (lower(title) text_pattern_ops) WHERE deleted_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id UUID PRIMARY KEY,
+    author_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    status TEXT NOT NULL,
+    total_rows INT NOT NULL,
+    processed_rows INT DEFAULT 0,
+    imported_rows INT DEFAULT 0,
+    failed_rows INT DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    finished_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS import_jobs_active_idx ON import_jobs (updated_at) WHERE status IN ('pending', 'running');
+
+CREATE TABLE IF NOT EXISTS import_job_errors (
+    job_id UUID REFERENCES import_jobs(id) ON DELETE CASCADE,
+    row_number INT NOT NULL,
+    message TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS import_job_errors_job_idx ON import_job_errors (job_id, row_number);
+
+CREATE TABLE IF NOT EXISTS feed_tokens (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    token TEXT UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
\ No newline at end of file
@@ -38,6 +38,14 @@ box.once('sessions_space_init', function()
                                {if_not_exists = true})
     end)
 
+box.once('sessions_user_index', function()
+        box.space.sessions:create_index('user', {
+            parts = {{field = 'user_id', type = 'string'}},
+            unique = false,
+            if_not_exists = true
+        })
+    end)
+
 vshard.storage.cfg({
     bucket_count = 100,
     sharding = {
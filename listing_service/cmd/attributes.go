@@ -0,0 +1,338 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"listingService/listingpb"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Типы атрибутов категорий
+const (
+	attrString = "string"
+	attrInt    = "int"
+	attrBool   = "bool"
+	attrEnum   = "enum"
+)
+
+// querier — общий интерфейс пула соединений и транзакции
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// typedAttribute значение атрибута, приведённое к типу из схемы категории
+type typedAttribute struct {
+	name        string
+	valueString *string
+	valueInt    *int64
+	valueBool   *bool
+}
+
+// GetCategories возвращает категории вместе со схемами их атрибутов
+func (s *server) GetCategories(ctx context.Context, _ *listingpb.Empty) (*listingpb.GetCategoriesResponse, error) {
+	rows, err := s.sql.Query(ctx, `
+        SELECT c.id, c.name, a.name, a.type, a.enum_values, a.required
+        FROM categories c
+        LEFT JOIN category_attributes a ON a.category_id = c.id
+        ORDER BY c.id, a.position
+    `)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetCategoriesResponse{}
+	var current *listingpb.Category
+	for rows.Next() {
+		var id int64
+		var name string
+		var attrName, attrType *string
+		var enumValues []string
+		var required *bool
+
+		if err := rows.Scan(&id, &name, &attrName, &attrType, &enumValues, &required); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+
+		if current == nil || current.Id != id {
+			current = &listingpb.Category{Id: id, Name: name}
+			resp.Categories = append(resp.Categories, current)
+		}
+
+		if attrName != nil {
+			current.Attributes = append(current.Attributes, &listingpb.AttributeSchema{
+				Name:       *attrName,
+				Type:       *attrType,
+				EnumValues: enumValues,
+				Required:   *required,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	return resp, nil
+}
+
+// attributeErrorPrefix начинает сообщения ошибок проверки категории и атрибутов, по нему API
+// отличает их от других ошибок InvalidArgument
+const attributeErrorPrefix = "invalid attributes: "
+
+// attributeError возвращает ошибку проверки категории или атрибутов
+func attributeError(format string, args ...any) error {
+	return status.Errorf(codes.InvalidArgument, attributeErrorPrefix+format, args...)
+}
+
+// loadCategorySchema загружает схему атрибутов категории
+func loadCategorySchema(ctx context.Context, q querier, categoryID int64) (map[string]*listingpb.AttributeSchema, error) {
+	var exists bool
+	err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM categories WHERE id = $1)`, categoryID).Scan(&exists)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query category: %v", err)
+	}
+	if !exists {
+		return nil, attributeError("unknown category %d", categoryID)
+	}
+
+	rows, err := q.Query(ctx, `
+        SELECT name, type, enum_values, required FROM category_attributes WHERE category_id = $1
+    `, categoryID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query category attributes: %v", err)
+	}
+	defer rows.Close()
+
+	schema := make(map[string]*listingpb.AttributeSchema)
+	for rows.Next() {
+		var attr listingpb.AttributeSchema
+		if err := rows.Scan(&attr.Name, &attr.Type, &attr.EnumValues, &attr.Required); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		schema[attr.Name] = &attr
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query category attributes: %v", err)
+	}
+	return schema, nil
+}
+
+// validateAttributes проверяет значения атрибутов по схеме категории и приводит их к типам схемы
+func validateAttributes(ctx context.Context, q querier, categoryID int64, attrs map[string]string) ([]typedAttribute, error) {
+	if categoryID == 0 {
+		if len(attrs) > 0 {
+			return nil, attributeError("attributes require a category")
+		}
+		return nil, nil
+	}
+
+	schema, err := loadCategorySchema(ctx, q, categoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	for name, attr := range schema {
+		if _, ok := attrs[name]; attr.Required && !ok {
+			return nil, attributeError("attribute %q is required", name)
+		}
+	}
+
+	typed := make([]typedAttribute, 0, len(attrs))
+	for name, value := range attrs {
+		attr, ok := schema[name]
+		if !ok {
+			return nil, attributeError("unknown attribute %q", name)
+		}
+
+		t, err := parseAttribute(attr, value)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, t)
+	}
+	return typed, nil
+}
+
+// parseAttribute приводит строковое значение атрибута к типу из схемы
+func parseAttribute(attr *listingpb.AttributeSchema, value string) (typedAttribute, error) {
+	t := typedAttribute{name: attr.Name}
+	value = strings.TrimSpace(value)
+
+	switch attr.Type {
+	case attrString:
+		if value == "" {
+			return t, attributeError("attribute %q must not be empty", attr.Name)
+		}
+		t.valueString = &value
+	case attrEnum:
+		if !slices.Contains(attr.EnumValues, value) {
+			return t, attributeError("attribute %q must be one of %s", attr.Name, strings.Join(attr.EnumValues, ", "))
+		}
+		t.valueString = &value
+	case attrInt:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return t, attributeError("attribute %q must be an integer", attr.Name)
+		}
+		t.valueInt = &v
+	case attrBool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return t, attributeError("attribute %q must be a boolean", attr.Name)
+		}
+		t.valueBool = &v
+	default:
+		return t, status.Errorf(codes.Internal, "attribute %q has unknown type %q", attr.Name, attr.Type)
+	}
+	return t, nil
+}
+
+// nullCategory возвращает nil для объявлений без категории
+func nullCategory(categoryID int64) *int64 {
+	if categoryID == 0 {
+		return nil
+	}
+	return &categoryID
+}
+
+// saveAttributes заменяет значения атрибутов объявления
+func saveAttributes(ctx context.Context, tx pgx.Tx, listingID string, attrs []typedAttribute) error {
+	_, err := tx.Exec(ctx, `DELETE FROM listing_attributes WHERE listing_id = $1`, listingID)
+	if err != nil {
+		return err
+	}
+
+	for _, attr := range attrs {
+		_, err := tx.Exec(ctx, `
+            INSERT INTO listing_attributes (listing_id, name, value_string, value_int, value_bool)
+            VALUES ($1, $2, $3, $4, $5)
+        `, listingID, attr.name, attr.valueString, attr.valueInt, attr.valueBool)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadAttributes загружает атрибуты объявлений в строковом виде
+func (s *server) loadAttributes(ctx context.Context, listings []*listingpb.Listing) error {
+	if len(listings) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*listingpb.Listing, len(listings))
+	ids := make([]string, 0, len(listings))
+	for _, l := range listings {
+		byID[l.Id] = l
+		ids = append(ids, l.Id)
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT listing_id, name, value_string, value_int, value_bool
+        FROM listing_attributes WHERE listing_id = ANY($1)
+    `, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var listingID, name string
+		var valueString *string
+		var valueInt *int64
+		var valueBool *bool
+		if err := rows.Scan(&listingID, &name, &valueString, &valueInt, &valueBool); err != nil {
+			return err
+		}
+
+		l := byID[listingID]
+		if l.Attributes == nil {
+			l.Attributes = make(map[string]string)
+		}
+
+		switch {
+		case valueString != nil:
+			l.Attributes[name] = *valueString
+		case valueInt != nil:
+			l.Attributes[name] = strconv.FormatInt(*valueInt, 10)
+		case valueBool != nil:
+			l.Attributes[name] = strconv.FormatBool(*valueBool)
+		}
+	}
+	return rows.Err()
+}
+
+// attributeConditions строит условия фильтрации по атрибутам для GetAllListings.
+// Возвращает условия, аргументы и следующий номер параметра запроса
+func attributeConditions(ctx context.Context, q querier, categoryID int64, filters []*listingpb.AttributeFilter, argIdx int) ([]string, []interface{}, int, error) {
+	if len(filters) == 0 {
+		return nil, nil, argIdx, nil
+	}
+	if categoryID == 0 {
+		return nil, nil, argIdx, attributeError("attribute filters require a category")
+	}
+
+	schema, err := loadCategorySchema(ctx, q, categoryID)
+	if err != nil {
+		return nil, nil, argIdx, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, f := range filters {
+		attr, ok := schema[f.Name]
+		if !ok {
+			return nil, nil, argIdx, attributeError("unknown attribute %q", f.Name)
+		}
+
+		clauses := []string{fmt.Sprintf("a.name = $%d", argIdx)}
+		args = append(args, f.Name)
+		argIdx++
+
+		if f.Value != nil {
+			t, err := parseAttribute(attr, *f.Value)
+			if err != nil {
+				return nil, nil, argIdx, err
+			}
+			switch {
+			case t.valueString != nil:
+				clauses = append(clauses, fmt.Sprintf("a.value_string = $%d", argIdx))
+				args = append(args, *t.valueString)
+			case t.valueInt != nil:
+				clauses = append(clauses, fmt.Sprintf("a.value_int = $%d", argIdx))
+				args = append(args, *t.valueInt)
+			case t.valueBool != nil:
+				clauses = append(clauses, fmt.Sprintf("a.value_bool = $%d", argIdx))
+				args = append(args, *t.valueBool)
+			}
+			argIdx++
+		}
+
+		if (f.Min != nil || f.Max != nil) && attr.Type != attrInt {
+			return nil, nil, argIdx, attributeError("attribute %q does not support range filters", f.Name)
+		}
+		if f.Min != nil {
+			clauses = append(clauses, fmt.Sprintf("a.value_int >= $%d", argIdx))
+			args = append(args, *f.Min)
+			argIdx++
+		}
+		if f.Max != nil {
+			clauses = append(clauses, fmt.Sprintf("a.value_int <= $%d", argIdx))
+			args = append(args, *f.Max)
+			argIdx++
+		}
+
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM listing_attributes a WHERE a.listing_id = l.id AND %s)",
+			strings.Join(clauses, " AND "),
+		))
+	}
+	return conditions, args, argIdx, nil
+}
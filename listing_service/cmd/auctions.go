@@ -0,0 +1,291 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Статусы аукционов
+const (
+	auctionOpen   = "open"
+	auctionClosed = "closed"
+)
+
+// Статусы объявлений после окончания аукциона
+const (
+	listingSold   = "sold"
+	listingClosed = "closed"
+)
+
+// maxAuctionDuration - максимальная длительность аукциона
+const maxAuctionDuration = 30 * 24 * time.Hour
+
+// auctionBidsLimit - сколько последних ставок возвращается вместе с аукционом
+const auctionBidsLimit = 20
+
+// bidRetries - сколько раз ставка повторяется при конфликте сериализуемых транзакций
+const bidRetries = 3
+
+// sqlStateSerializationFailure - код ошибки PostgreSQL при конфликте сериализуемых транзакций
+const sqlStateSerializationFailure = "40001"
+
+// validateAuction проверяет настройки аукциона, стартовая цена по умолчанию равна цене объявления
+func validateAuction(settings *listingpb.AuctionSettings, price int64, now time.Time) (startPrice int64, endsAt time.Time, err error) {
+	startPrice = settings.StartPrice
+	if startPrice == 0 {
+		startPrice = price
+	}
+	if startPrice <= 0 || startPrice > maxOfferAmount {
+		return 0, endsAt, status.Errorf(codes.InvalidArgument, "start_price must be between 1 and %d", maxOfferAmount)
+	}
+	if settings.MinIncrement <= 0 || settings.MinIncrement > maxOfferAmount {
+		return 0, endsAt, status.Errorf(codes.InvalidArgument, "min_increment must be between 1 and %d", maxOfferAmount)
+	}
+	if settings.EndsAt == nil {
+		return 0, endsAt, status.Error(codes.InvalidArgument, "ends_at is required")
+	}
+	endsAt = settings.EndsAt.AsTime()
+	if !endsAt.After(now) || endsAt.Sub(now) > maxAuctionDuration {
+		return 0, endsAt, status.Errorf(codes.InvalidArgument, "ends_at must be in the future and within %s", maxAuctionDuration)
+	}
+	return startPrice, endsAt, nil
+}
+
+// insertAuction создаёт аукцион для нового объявления
+func insertAuction(ctx context.Context, tx pgx.Tx, listingID string, startPrice, minIncrement int64, endsAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO auctions (listing_id, start_price, min_increment, ends_at, status)
+        VALUES ($1, $2, $3, $4, $5)
+    `, listingID, startPrice, minIncrement, endsAt, auctionOpen)
+	return err
+}
+
+// minBid возвращает минимальную допустимую ставку: стартовую цену для первой ставки и текущую цену с шагом для остальных
+func minBid(a *listingpb.Auction) int64 {
+	if a.CurrentPrice == nil {
+		return a.StartPrice
+	}
+	return *a.CurrentPrice + a.MinIncrement
+}
+
+func scanAuction(row pgx.Row) (*listingpb.Auction, error) {
+	var a listingpb.Auction
+	var leaderID, winnerID *string
+	var endsAt time.Time
+	var closedAt *time.Time
+	err := row.Scan(&a.ListingId, &a.StartPrice, &a.MinIncrement, &a.CurrentPrice, &leaderID, &winnerID,
+		&a.BidsCount, &a.Status, &endsAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if leaderID != nil {
+		a.LeaderId = *leaderID
+	}
+	if winnerID != nil {
+		a.WinnerId = *winnerID
+	}
+	a.EndsAt = timestamppb.New(endsAt)
+	if closedAt != nil {
+		a.ClosedAt = timestamppb.New(*closedAt)
+	}
+	a.MinBid = minBid(&a)
+	return &a, nil
+}
+
+const auctionColumns = `a.listing_id, a.start_price, a.min_increment, a.current_price, a.leader_id, a.winner_id,
+    a.bids_count, a.status, a.ends_at, a.closed_at`
+
+// isAuction сообщает, продаётся ли объявление с аукциона
+func isAuction(ctx context.Context, q querier, listingID string) (bool, error) {
+	var exists bool
+	err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auctions WHERE listing_id = $1)`, listingID).Scan(&exists)
+	return exists, err
+}
+
+// GetAuction возвращает состояние аукциона и последние ставки
+func (s *server) GetAuction(ctx context.Context, req *listingpb.GetAuctionRequest) (*listingpb.Auction, error) {
+	auction, err := scanAuction(s.sql.QueryRow(ctx, `
+        SELECT `+auctionColumns+` FROM auctions a
+        JOIN listings l ON l.id = a.listing_id
+        WHERE a.listing_id = $1 AND l.deleted_at IS NULL
+    `, req.ListingId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "auction not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query auction: %v", err)
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT id, bidder_id, amount, created_at FROM bids
+        WHERE listing_id = $1
+        ORDER BY created_at DESC
+        LIMIT $2
+    `, req.ListingId, auctionBidsLimit)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bid listingpb.Bid
+		var createdAt time.Time
+		if err := rows.Scan(&bid.Id, &bid.BidderId, &bid.Amount, &createdAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		bid.CreatedAt = timestamppb.New(createdAt)
+		auction.Bids = append(auction.Bids, &bid)
+	}
+	return auction, nil
+}
+
+// PlaceBid делает ставку на аукционе.
+// Ставка выполняется в сериализуемой транзакции и повторяется, если параллельная ставка изменила аукцион.
+// Ставка в последние auctionExtension минут продлевает аукцион, чтобы остальные участники успели ответить
+func (s *server) PlaceBid(ctx context.Context, req *listingpb.PlaceBidRequest) (*listingpb.Auction, error) {
+	if err := validateOfferAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		auction, err := s.placeBid(ctx, req)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == sqlStateSerializationFailure {
+			if attempt < bidRetries {
+				continue
+			}
+			return nil, status.Error(codes.Aborted, "auction was changed by a concurrent bid, try again")
+		}
+		if err != nil {
+			if _, ok := status.FromError(err); ok {
+				return nil, err
+			}
+			return nil, status.Errorf(codes.Internal, "failed to place bid: %v", err)
+		}
+		return auction, nil
+	}
+}
+
+func (s *server) placeBid(ctx context.Context, req *listingpb.PlaceBidRequest) (*listingpb.Auction, error) {
+	var auction *listingpb.Auction
+	err := pgx.BeginTxFunc(ctx, s.sql, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		var authorID string
+		err := tx.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL`, req.ListingId).Scan(&authorID)
+		if err == nil {
+			auction, err = scanAuction(tx.QueryRow(ctx, `SELECT `+auctionColumns+` FROM auctions a WHERE a.listing_id = $1`, req.ListingId))
+		}
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return status.Error(codes.NotFound, "auction not found")
+			}
+			return err
+		}
+
+		now := time.Now()
+		if auction.Status != auctionOpen || !auction.EndsAt.AsTime().After(now) {
+			return status.Error(codes.FailedPrecondition, "auction is closed")
+		}
+		if authorID == req.UserId {
+			return status.Error(codes.PermissionDenied, "you cannot bid on your own listing")
+		}
+		if auction.LeaderId == req.UserId {
+			return status.Error(codes.AlreadyExists, "your bid is already the highest")
+		}
+		if req.Amount < auction.MinBid {
+			return status.Errorf(codes.InvalidArgument, "bid must be at least %d", auction.MinBid)
+		}
+
+		endsAt := auction.EndsAt.AsTime()
+		if endsAt.Sub(now) < auctionExtension {
+			endsAt = now.Add(auctionExtension)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO bids (id, listing_id, bidder_id, amount, created_at) VALUES ($1, $2, $3, $4, $5)
+        `, uuid.New(), req.ListingId, req.UserId, req.Amount, now)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            UPDATE auctions SET current_price = $1, leader_id = $2, bids_count = bids_count + 1, ends_at = $3
+            WHERE listing_id = $4
+        `, req.Amount, req.UserId, endsAt, req.ListingId)
+		if err != nil {
+			return err
+		}
+		err = withHistory(ctx, tx, req.ListingId, req.UserId, historyBid, func() error {
+			_, err := tx.Exec(ctx, `UPDATE listings SET price = $1 WHERE id = $2`, req.Amount, req.ListingId)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		auction.CurrentPrice = &req.Amount
+		auction.LeaderId = req.UserId
+		auction.BidsCount++
+		auction.EndsAt = timestamppb.New(endsAt)
+		auction.MinBid = minBid(auction)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return auction, nil
+}
+
+// closeAuctions периодически закрывает закончившиеся аукционы: победителем становится лидер,
+// объявление с победителем помечается проданным, без ставок - закрытым
+func (s *server) closeAuctions(ctx context.Context) {
+	ticker := time.NewTicker(auctionCloseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			var closed int64
+			err := s.sql.QueryRow(ctx, `
+                WITH closed AS (
+                    UPDATE auctions SET status = $1, winner_id = leader_id, closed_at = $2
+                    WHERE status = $3 AND ends_at <= $2
+                    RETURNING listing_id, winner_id
+                ), updated AS (
+                    UPDATE listings l
+                    SET status = CASE WHEN c.winner_id IS NULL THEN $4 ELSE $5 END
+                    FROM closed c
+                    WHERE l.id = c.listing_id
+                    RETURNING l.id, l.status
+                ), history AS (
+                    -- Подзапрос к listings видит строки до изменения, поэтому даёт прежний статус
+                    SELECT add_listing_history(u.id, NULL, $6::text,
+                        jsonb_build_object('status', l.status), jsonb_build_object('status', u.status), $2)
+                    FROM updated u JOIN listings l ON l.id = u.id
+                    WHERE l.status <> u.status
+                )
+                -- CTE без изменения данных выполняется, только если на него ссылается запрос
+                SELECT COUNT(*) FROM updated, (SELECT COUNT(*) FROM history) h
+            `, auctionClosed, now, auctionOpen, listingClosed, listingSold, historyAuctionClosed).Scan(&closed)
+			if err != nil {
+				log.Printf("failed to close auctions: %v", err)
+				continue
+			}
+			if closed > 0 {
+				log.Printf("closed %d auctions", closed)
+			}
+		}
+	}
+}
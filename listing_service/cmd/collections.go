@@ -0,0 +1,301 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"listingService/listingpb"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxCollectionName - максимальная длина названия подборки в символах
+const maxCollectionName = 50
+
+// maxCollections - сколько подборок может создать один пользователь
+const maxCollections = 50
+
+// sqlStateUniqueViolation - код ошибки PostgreSQL при нарушении уникальности
+const sqlStateUniqueViolation = "23505"
+
+const collectionColumns = `c.id, c.user_id, c.name, c.share_token, c.created_at,
+    (SELECT COUNT(*) FROM collection_listings cl JOIN listings l ON l.id = cl.listing_id
+     WHERE cl.collection_id = c.id AND l.deleted_at IS NULL)`
+
+func scanCollection(row pgx.Row) (*listingpb.Collection, error) {
+	var c listingpb.Collection
+	var shareToken *string
+	var createdAt time.Time
+	if err := row.Scan(&c.Id, &c.UserId, &c.Name, &shareToken, &createdAt, &c.ListingsCount); err != nil {
+		return nil, err
+	}
+	if shareToken != nil {
+		c.ShareToken = *shareToken
+	}
+	c.CreatedAt = timestamppb.New(createdAt)
+	return &c, nil
+}
+
+// collectionName проверяет и нормализует название подборки
+func collectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionName {
+		return "", status.Errorf(codes.InvalidArgument, "name must be between 1 and %d characters", maxCollectionName)
+	}
+	return name, nil
+}
+
+// isUniqueViolation сообщает, что запрос нарушил ограничение уникальности
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation
+}
+
+// getCollection возвращает подборку пользователя. Чужая подборка для него не существует
+func getCollection(ctx context.Context, q querier, collectionID, userID string) (*listingpb.Collection, error) {
+	c, err := scanCollection(q.QueryRow(ctx, `
+        SELECT `+collectionColumns+` FROM collections c WHERE c.id = $1 AND c.user_id = $2
+    `, collectionID, userID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "collection not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query collection: %v", err)
+	}
+	return c, nil
+}
+
+// CreateCollection создаёт именованную подборку избранного
+func (s *server) CreateCollection(ctx context.Context, req *listingpb.CollectionRequest) (*listingpb.Collection, error) {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &listingpb.Collection{
+		Id:        uuid.New().String(),
+		UserId:    req.UserId,
+		Name:      name,
+		CreatedAt: timestamppb.Now(),
+	}
+
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		// Подборки пользователя блокируются, чтобы параллельные запросы не превысили ограничение
+		var count int
+		err := tx.QueryRow(ctx, `
+            SELECT COUNT(*) FROM (SELECT 1 FROM collections WHERE user_id = $1 FOR UPDATE) c
+        `, req.UserId).Scan(&count)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to count collections: %v", err)
+		}
+		if count >= maxCollections {
+			return status.Errorf(codes.ResourceExhausted, "you cannot have more than %d collections", maxCollections)
+		}
+
+		_, err = tx.Exec(ctx, `
+            INSERT INTO collections (id, user_id, name, created_at) VALUES ($1, $2, $3, $4)
+        `, c.Id, c.UserId, c.Name, c.CreatedAt.AsTime())
+		if isUniqueViolation(err) {
+			return status.Error(codes.AlreadyExists, "collection with this name already exists")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create collection: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// RenameCollection переименовывает подборку
+func (s *server) RenameCollection(ctx context.Context, req *listingpb.CollectionRequest) (*listingpb.Collection, error) {
+	name, err := collectionName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tag, err := s.sql.Exec(ctx, `
+        UPDATE collections SET name = $1 WHERE id = $2 AND user_id = $3
+    `, name, req.CollectionId, req.UserId)
+	if isUniqueViolation(err) {
+		return nil, status.Error(codes.AlreadyExists, "collection with this name already exists")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to rename collection: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	return getCollection(ctx, s.sql, req.CollectionId, req.UserId)
+}
+
+// DeleteCollection удаляет подборку, сами объявления и лайки не затрагиваются
+func (s *server) DeleteCollection(ctx context.Context, req *listingpb.CollectionRequest) (*listingpb.Empty, error) {
+	tag, err := s.sql.Exec(ctx, `DELETE FROM collections WHERE id = $1 AND user_id = $2`, req.CollectionId, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete collection: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	return &listingpb.Empty{}, nil
+}
+
+// GetCollections возвращает подборки пользователя по алфавиту.
+// При переданном listing_id отмечает подборки, в которые это объявление уже добавлено
+func (s *server) GetCollections(ctx context.Context, req *listingpb.GetCollectionsRequest) (*listingpb.GetCollectionsResponse, error) {
+	var listingID *string
+	if req.ListingId != "" {
+		listingID = &req.ListingId
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT `+collectionColumns+`,
+            $2::uuid IS NOT NULL AND EXISTS (
+                SELECT 1 FROM collection_listings WHERE collection_id = c.id AND listing_id = $2
+            )
+        FROM collections c
+        WHERE c.user_id = $1
+        ORDER BY c.name
+    `, req.UserId, listingID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetCollectionsResponse{}
+	for rows.Next() {
+		var c listingpb.Collection
+		var shareToken *string
+		var createdAt time.Time
+		err := rows.Scan(&c.Id, &c.UserId, &c.Name, &shareToken, &createdAt, &c.ListingsCount, &c.ContainsListing)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		if shareToken != nil {
+			c.ShareToken = *shareToken
+		}
+		c.CreatedAt = timestamppb.New(createdAt)
+		resp.Collections = append(resp.Collections, &c)
+	}
+	return resp, nil
+}
+
+// AddToCollection добавляет объявление в подборку, повторное добавление ничего не меняет
+func (s *server) AddToCollection(ctx context.Context, req *listingpb.CollectionItemRequest) (*listingpb.Empty, error) {
+	if _, err := getCollection(ctx, s.sql, req.CollectionId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	tag, err := s.sql.Exec(ctx, `
+        INSERT INTO collection_listings (collection_id, listing_id, added_at)
+        SELECT $1, id, $3 FROM listings WHERE id = $2 AND deleted_at IS NULL
+        ON CONFLICT DO NOTHING
+    `, req.CollectionId, req.ListingId, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to add listing to collection: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		err := s.sql.QueryRow(ctx, `
+            SELECT EXISTS (SELECT 1 FROM listings WHERE id = $1 AND deleted_at IS NULL)
+        `, req.ListingId).Scan(&exists)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+		}
+		if !exists {
+			// NotFound означает отсутствующую подборку, поэтому недоступное объявление - это нарушение условия
+			return nil, status.Error(codes.FailedPrecondition, "listing not found")
+		}
+	}
+	return &listingpb.Empty{}, nil
+}
+
+// RemoveFromCollection убирает объявление из подборки
+func (s *server) RemoveFromCollection(ctx context.Context, req *listingpb.CollectionItemRequest) (*listingpb.Empty, error) {
+	if _, err := getCollection(ctx, s.sql, req.CollectionId, req.UserId); err != nil {
+		return nil, err
+	}
+
+	_, err := s.sql.Exec(ctx, `
+        DELETE FROM collection_listings WHERE collection_id = $1 AND listing_id = $2
+    `, req.CollectionId, req.ListingId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove listing from collection: %v", err)
+	}
+	return &listingpb.Empty{}, nil
+}
+
+// ShareCollection открывает доступ к подборке по ссылке или закрывает его.
+// Повторное открытие выдаёт новый токен, и старые ссылки перестают работать
+func (s *server) ShareCollection(ctx context.Context, req *listingpb.ShareCollectionRequest) (*listingpb.Collection, error) {
+	var token *string
+	if req.Shared {
+		t, err := newToken()
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate share token: %v", err)
+		}
+		token = &t
+	}
+
+	tag, err := s.sql.Exec(ctx, `
+        UPDATE collections SET share_token = $1 WHERE id = $2 AND user_id = $3
+    `, token, req.CollectionId, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to share collection: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+	return getCollection(ctx, s.sql, req.CollectionId, req.UserId)
+}
+
+// GetSharedCollection возвращает подборку по токену публичной ссылки
+func (s *server) GetSharedCollection(ctx context.Context, req *listingpb.GetSharedCollectionRequest) (*listingpb.Collection, error) {
+	if req.ShareToken == "" {
+		return nil, status.Error(codes.NotFound, "collection not found")
+	}
+
+	c, err := scanCollection(s.sql.QueryRow(ctx, `
+        SELECT `+collectionColumns+` FROM collections c WHERE c.share_token = $1
+    `, req.ShareToken))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "collection not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query collection: %v", err)
+	}
+	return c, nil
+}
+
+// canViewCollection проверяет доступ к подборке для фильтра выдачи: свою подборку пользователь видит всегда,
+// чужую - только по действующему токену публичной ссылки
+func (s *server) canViewCollection(ctx context.Context, collectionID, userID, shareToken string) error {
+	var ownerID string
+	var token *string
+	err := s.sql.QueryRow(ctx, `SELECT user_id, share_token FROM collections WHERE id = $1`, collectionID).Scan(&ownerID, &token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Error(codes.NotFound, "collection not found")
+		}
+		return status.Errorf(codes.Internal, "failed to query collection: %v", err)
+	}
+
+	if ownerID == userID {
+		return nil
+	}
+	if token != nil && shareToken != "" && subtle.ConstantTimeCompare([]byte(*token), []byte(shareToken)) == 1 {
+		return nil
+	}
+	return status.Error(codes.NotFound, "collection not found")
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"context"
+	"hash/fnv"
+	"listingService/listingpb"
+	"math/bits"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Режимы обработки найденных дубликатов
+const (
+	duplicateFlag   = "flag"   // объявление создаётся, совпадения сохраняются для модераторов
+	duplicateReject = "reject" // объявление отклоняется
+)
+
+// maxDuplicateMatches ограничивает количество сохраняемых совпадений для одного объявления
+const maxDuplicateMatches = 10
+
+// duplicateMatch найденное похожее объявление
+type duplicateMatch struct {
+	listingID     string
+	sameAuthor    bool
+	imageDistance *int
+	textDistance  *int
+}
+
+// textFingerprint считает simhash нормализованного текста объявления: регистр, пунктуация,
+// порядок и повторы слов не влияют на результат, а близкие тексты дают близкие по Хэммингу отпечатки
+func textFingerprint(title, description string) uint64 {
+	text := strings.ReplaceAll(strings.ToLower(title+" "+description), "ё", "е")
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	var weights [64]int
+	for _, word := range words {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+
+		h := fnv.New64a()
+		h.Write([]byte(word))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<i) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	var fingerprint uint64
+	for i, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << i
+		}
+	}
+	return fingerprint
+}
+
+// findDuplicates ищет активные объявления с похожим изображением или текстом.
+// Хэши хранятся в BIGINT, поэтому передаются как int64 с тем же набором бит;
+// без хэша изображения сравнивается только текст
+func (s *server) findDuplicates(ctx context.Context, authorID string, imageHash, textHash uint64) ([]duplicateMatch, error) {
+	rows, err := s.sql.Query(ctx, `
+        SELECT id, author_id = $1,
+            bit_count((image_hash # $2)::bit(64))::int AS image_distance,
+            bit_count((text_hash # $3)::bit(64))::int AS text_distance
+        FROM listings
+        WHERE deleted_at IS NULL AND status = $7
+            AND (bit_count((image_hash # $2)::bit(64)) <= $4 OR bit_count((text_hash # $3)::bit(64)) <= $5)
+        ORDER BY LEAST(bit_count((image_hash # $2)::bit(64)), bit_count((text_hash # $3)::bit(64)))
+        LIMIT $6
+    `, authorID, nullImageHash(imageHash), int64(textHash), duplicateImageDistance, duplicateTextDistance, maxDuplicateMatches, listingActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matches []duplicateMatch
+	for rows.Next() {
+		var m duplicateMatch
+		if err := rows.Scan(&m.listingID, &m.sameAuthor, &m.imageDistance, &m.textDistance); err != nil {
+			return nil, err
+		}
+		matches = append(matches, m)
+	}
+	return matches, rows.Err()
+}
+
+// matchFingerprints сравнивает отпечатки двух объявлений, ещё не сохранённых в базе,
+// по тем же порогам, что и findDuplicates
+func matchFingerprints(listingID string, imageHash, textHash, otherImageHash, otherTextHash uint64) (duplicateMatch, bool) {
+	m := duplicateMatch{listingID: listingID, sameAuthor: true}
+	matched := false
+
+	if imageHash != 0 && otherImageHash != 0 {
+		d := bits.OnesCount64(imageHash ^ otherImageHash)
+		m.imageDistance = &d
+		matched = d <= duplicateImageDistance
+	}
+	d := bits.OnesCount64(textHash ^ otherTextHash)
+	m.textDistance = &d
+	return m, matched || d <= duplicateTextDistance
+}
+
+// nullImageHash возвращает nil, если хэш изображения не передан
+func nullImageHash(hash uint64) *int64 {
+	if hash == 0 {
+		return nil
+	}
+	v := int64(hash)
+	return &v
+}
+
+const insertDuplicateQuery = `
+    INSERT INTO listing_duplicates (listing_id, duplicate_of, same_author, image_distance, text_distance, detected_at)
+    VALUES ($1, $2, $3, $4, $5, $6)
+    ON CONFLICT DO NOTHING
+`
+
+// saveDuplicates сохраняет найденные совпадения для просмотра модераторами
+func saveDuplicates(ctx context.Context, tx pgx.Tx, listingID string, matches []duplicateMatch) error {
+	for _, m := range matches {
+		_, err := tx.Exec(ctx, insertDuplicateQuery, listingID, m.listingID, m.sameAuthor, m.imageDistance, m.textDistance, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDuplicates возвращает модератору найденные совпадения объявлений, начиная с последних
+func (s *server) GetDuplicates(ctx context.Context, req *listingpb.GetDuplicatesRequest) (*listingpb.GetDuplicatesResponse, error) {
+	if _, err := uuid.Parse(req.UserId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	if err := s.requireModerator(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	var totalItems int
+	err := s.sql.QueryRow(ctx, `SELECT COUNT(*) FROM listing_duplicates`).Scan(&totalItems)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count duplicates: %v", err)
+	}
+
+	totalPages := int64((totalItems + limit - 1) / limit)
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if req.Page > totalPages {
+		req.Page = totalPages
+	}
+	offset := (req.Page - 1) * int64(limit)
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT
+            d.listing_id, l.title, l.author_id, l.deleted_at IS NOT NULL,
+            d.duplicate_of, o.title, o.author_id, o.deleted_at IS NOT NULL,
+            d.same_author, d.image_distance, d.text_distance, d.detected_at
+        FROM listing_duplicates d
+        JOIN listings l ON l.id = d.listing_id
+        JOIN listings o ON o.id = d.duplicate_of
+        ORDER BY d.detected_at DESC
+        LIMIT $1 OFFSET $2
+    `, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetDuplicatesResponse{TotalPages: totalPages, CurrentPage: req.Page}
+	for rows.Next() {
+		var m listingpb.DuplicateMatch
+		var imageDistance, textDistance *int64
+		var detectedAt time.Time
+
+		if err := rows.Scan(
+			&m.ListingId, &m.ListingTitle, &m.ListingAuthorId, &m.ListingDeleted,
+			&m.DuplicateOfId, &m.DuplicateOfTitle, &m.DuplicateOfAuthorId, &m.DuplicateOfDeleted,
+			&m.SameAuthor, &imageDistance, &textDistance, &detectedAt,
+		); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+
+		m.ImageDistance = imageDistance
+		m.TextDistance = textDistance
+		m.DetectedAt = timestamppb.New(detectedAt)
+		resp.Matches = append(resp.Matches, &m)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"listingService/listingpb"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxFacetBuckets ограничивает количество интервалов гистограммы цен
+const maxFacetBuckets = 100
+
+// histogramRow интервал гистограммы цен в ответе агрегирующего запроса
+type histogramRow struct {
+	Bucket int64 `json:"bucket"`
+	Count  int64 `json:"count"`
+}
+
+// categoryRow количество объявлений категории в ответе агрегирующего запроса
+type categoryRow struct {
+	CategoryID *int64  `json:"category_id"`
+	Name       *string `json:"name"`
+	Count      int64   `json:"count"`
+}
+
+// statusRow количество объявлений в статусе в ответе агрегирующего запроса
+type statusRow struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// GetListingFacets считает агрегаты по объявлениям, подходящим под фильтр GetAllListings:
+// гистограмму цен, минимальную и максимальную цену, количество по категориям и статусам.
+// Все агрегаты считаются одним запросом к базе
+func (s *server) GetListingFacets(ctx context.Context, req *listingpb.GetListingFacetsRequest) (*listingpb.ListingFacets, error) {
+	filter := req.Filter
+	if filter == nil {
+		filter = &listingpb.GetAllListingsRequest{}
+	}
+
+	buckets := req.PriceBuckets
+	if buckets == 0 {
+		buckets = int64(facetBuckets)
+	}
+	if err := validateBuckets(req.PriceBounds, buckets); err != nil {
+		return nil, err
+	}
+
+	where, err := s.listingWhere(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if where.empty {
+		return &listingpb.ListingFacets{}, nil
+	}
+
+	args := where.args
+	var bucketExpr string
+	if len(req.PriceBounds) > 0 {
+		bucketExpr = fmt.Sprintf("width_bucket(f.price::bigint, $%d::bigint[])", where.argIdx)
+		args = append(args, req.PriceBounds)
+	} else {
+		bucketExpr = fmt.Sprintf("(f.price - st.min_price)::bigint * $%d / (st.max_price - st.min_price + 1)", where.argIdx)
+		args = append(args, buckets)
+	}
+
+	query := `
+        WITH filtered AS (
+            SELECT l.price, l.category_id, l.status
+            FROM listings l
+            WHERE ` + strings.Join(where.conditions, " AND ") + `
+        ),
+        stats AS (
+            SELECT COUNT(*) AS total, COALESCE(MIN(price), 0) AS min_price, COALESCE(MAX(price), 0) AS max_price
+            FROM filtered
+        )
+        SELECT
+            st.total, st.min_price, st.max_price,
+            (
+                SELECT COALESCE(json_agg(json_build_object('bucket', h.bucket, 'count', h.count) ORDER BY h.bucket), '[]')
+                FROM (SELECT ` + bucketExpr + ` AS bucket, COUNT(*) AS count FROM filtered f GROUP BY 1) h
+            ),
+            (
+                SELECT COALESCE(json_agg(json_build_object('category_id', c.category_id, 'name', cat.name, 'count', c.count) ORDER BY c.count DESC), '[]')
+                FROM (SELECT category_id, COUNT(*) AS count FROM filtered GROUP BY category_id) c
+                LEFT JOIN categories cat ON cat.id = c.category_id
+            ),
+            (
+                SELECT COALESCE(json_agg(json_build_object('status', sc.status, 'count', sc.count) ORDER BY sc.count DESC), '[]')
+                FROM (SELECT status, COUNT(*) AS count FROM filtered GROUP BY status) sc
+            )
+        FROM stats st
+    `
+
+	var facets listingpb.ListingFacets
+	var histogram []histogramRow
+	var categories []categoryRow
+	var statuses []statusRow
+
+	err = s.sql.QueryRow(ctx, query, args...).Scan(
+		&facets.Total,
+		&facets.MinPrice,
+		&facets.MaxPrice,
+		&histogram,
+		&categories,
+		&statuses,
+	)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to aggregate listings: %v", err)
+	}
+
+	counts := make(map[int64]int64, len(histogram))
+	for _, h := range histogram {
+		counts[h.Bucket] = h.Count
+	}
+	if len(req.PriceBounds) > 0 {
+		facets.PriceHistogram = boundsHistogram(req.PriceBounds, counts)
+	} else if facets.Total > 0 {
+		facets.PriceHistogram = uniformHistogram(facets.MinPrice, facets.MaxPrice, buckets, counts)
+	}
+
+	for _, c := range categories {
+		facet := &listingpb.CategoryFacet{Count: c.Count}
+		if c.CategoryID != nil {
+			facet.CategoryId = *c.CategoryID
+		}
+		if c.Name != nil {
+			facet.Name = *c.Name
+		}
+		facets.Categories = append(facets.Categories, facet)
+	}
+
+	for _, st := range statuses {
+		facets.Statuses = append(facets.Statuses, &listingpb.StatusFacet{Status: st.Status, Count: st.Count})
+	}
+
+	return &facets, nil
+}
+
+// validateBuckets проверяет границы интервалов или их количество
+func validateBuckets(bounds []int64, buckets int64) error {
+	if len(bounds) == 0 {
+		if buckets < 1 || buckets > maxFacetBuckets {
+			return status.Errorf(codes.InvalidArgument, "price_buckets must be between 1 and %d", maxFacetBuckets)
+		}
+		return nil
+	}
+
+	if len(bounds) >= maxFacetBuckets {
+		return status.Errorf(codes.InvalidArgument, "too many price bounds, maximum is %d", maxFacetBuckets-1)
+	}
+	for i, bound := range bounds {
+		if bound < 0 {
+			return status.Error(codes.InvalidArgument, "price bounds must not be negative")
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return status.Error(codes.InvalidArgument, "price bounds must be strictly ascending")
+		}
+	}
+	return nil
+}
+
+// boundsHistogram строит гистограмму по заданным границам: интервал i - это [bounds[i-1], bounds[i]),
+// первый интервал начинается с нуля, последний не ограничен сверху
+func boundsHistogram(bounds []int64, counts map[int64]int64) []*listingpb.PriceBucket {
+	histogram := make([]*listingpb.PriceBucket, 0, len(bounds)+1)
+	for i := 0; i <= len(bounds); i++ {
+		bucket := &listingpb.PriceBucket{Count: counts[int64(i)]}
+		if i > 0 {
+			bucket.From = bounds[i-1]
+		}
+		if i < len(bounds) {
+			bucket.To = &bounds[i]
+		}
+		histogram = append(histogram, bucket)
+	}
+	return histogram
+}
+
+// uniformHistogram строит гистограмму из buckets интервалов равной ширины между минимальной и максимальной ценой.
+// Интервал i содержит цены p, для которых (p - minPrice) * buckets / (maxPrice - minPrice + 1) = i;
+// интервалы нулевой ширины, возможные при узком диапазоне цен, пропускаются
+func uniformHistogram(minPrice, maxPrice, buckets int64, counts map[int64]int64) []*listingpb.PriceBucket {
+	width := maxPrice - minPrice + 1
+	edge := func(i int64) int64 {
+		return minPrice + (i*width+buckets-1)/buckets
+	}
+
+	histogram := make([]*listingpb.PriceBucket, 0, buckets)
+	for i := int64(0); i < buckets; i++ {
+		from, to := edge(i), edge(i+1)
+		if from == to {
+			continue
+		}
+		histogram = append(histogram, &listingpb.PriceBucket{
+			From:  from,
+			To:    &to,
+			Count: counts[i],
+		})
+	}
+	return histogram
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"listingService/listingpb"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// feedTokenLength - длина токена фида в байтах
+const feedTokenLength = 32
+
+// GetFeedToken возвращает токен фида пользователя, создавая его при первом обращении.
+// При regenerate старый токен заменяется, и ранее выданные ссылки на фиды перестают работать
+func (s *server) GetFeedToken(ctx context.Context, req *listingpb.FeedTokenRequest) (*listingpb.FeedTokenResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to generate feed token: %v", err)
+	}
+
+	query := `
+        INSERT INTO feed_tokens (user_id, token, created_at) VALUES ($1, $2, $3)
+        ON CONFLICT (user_id) DO NOTHING
+    `
+	if req.Regenerate {
+		query = `
+            INSERT INTO feed_tokens (user_id, token, created_at) VALUES ($1, $2, $3)
+            ON CONFLICT (user_id) DO UPDATE SET token = EXCLUDED.token, created_at = EXCLUDED.created_at
+        `
+	}
+
+	_, err = s.sql.Exec(ctx, query, userID, token, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save feed token: %v", err)
+	}
+
+	err = s.sql.QueryRow(ctx, `SELECT token FROM feed_tokens WHERE user_id = $1`, userID).Scan(&token)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query feed token: %v", err)
+	}
+
+	return &listingpb.FeedTokenResponse{Token: token}, nil
+}
+
+// StreamFeed построчно отправляет активные объявления продавца для выгрузки в фид,
+// не собирая весь каталог в памяти. Фильтр применяется так же, как в GetAllListings
+func (s *server) StreamFeed(req *listingpb.StreamFeedRequest, stream listingpb.ListingService_StreamFeedServer) error {
+	ctx := stream.Context()
+
+	var token string
+	err := s.sql.QueryRow(ctx, `SELECT token FROM feed_tokens WHERE user_id = $1`, req.UserId).Scan(&token)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Error(codes.PermissionDenied, "invalid feed token")
+		}
+		return status.Errorf(codes.Internal, "failed to query feed token: %v", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(req.Token)) != 1 {
+		return status.Error(codes.PermissionDenied, "invalid feed token")
+	}
+
+	// Фид запрашивает маркетплейс по токену, поэтому фильтры текущего пользователя в нём не действуют
+	filter := &listingpb.GetAllListingsRequest{}
+	if req.Filter != nil {
+		filter = proto.Clone(req.Filter).(*listingpb.GetAllListingsRequest)
+	}
+	filter.UserId = ""
+	filter.OnlyLiked = false
+	filter.Following = false
+	filter.TargetUserId = req.UserId
+	if filter.MaxPrice <= 0 {
+		filter.MaxPrice = 100_000_000
+	}
+
+	where, err := s.listingWhere(ctx, filter)
+	if err != nil {
+		return err
+	}
+	conditions := append(where.conditions, fmt.Sprintf("l.status = $%d", where.argIdx))
+	args := append(where.args, listingActive)
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT
+            l.id, l.title, l.description, l.address, l.price,
+            l.author_id, `+authorNameColumn+` as author_username,
+            l.created_at, l.image_url, l.likes
+        FROM listings l
+        LEFT JOIN users u ON l.author_id = u.id
+        WHERE `+strings.Join(conditions, " AND ")+`
+        ORDER BY l.created_at DESC
+    `, args...)
+	if err != nil {
+		return status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l listingpb.Listing
+		var createdAt time.Time
+		var authorUsername *string
+
+		if err := rows.Scan(
+			&l.Id,
+			&l.Title,
+			&l.Description,
+			&l.Address,
+			&l.Price,
+			&l.AuthorId,
+			&authorUsername,
+			&createdAt,
+			&l.ImageUrl,
+			&l.Likes,
+		); err != nil {
+			return status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+
+		if authorUsername != nil {
+			l.AuthorLogin = *authorUsername
+		}
+		l.CreatedAt = timestamppb.New(createdAt)
+
+		if err := stream.Send(&l); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return nil
+}
+
+// newToken генерирует случайный токен для ссылок на фиды и подборки
+func newToken() (string, error) {
+	buf := make([]byte, feedTokenLength)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
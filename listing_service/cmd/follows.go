@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"listingService/listingpb"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// followStats считает подписчиков и подписки пользователя sellerID. Для анонимного followerID
+// признак is_following всегда ложный
+func followStats(ctx context.Context, q querier, followerID, sellerID string) (*listingpb.FollowStats, error) {
+	stats := &listingpb.FollowStats{UserId: sellerID}
+	err := q.QueryRow(ctx, `
+        SELECT
+            (SELECT COUNT(*) FROM follows WHERE seller_id = $1),
+            (SELECT COUNT(*) FROM follows WHERE follower_id = $1),
+            EXISTS (SELECT 1 FROM follows WHERE follower_id = $2 AND seller_id = $1)
+    `, sellerID, followerID).Scan(&stats.FollowersCount, &stats.FollowingCount, &stats.IsFollowing)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count followers: %v", err)
+	}
+	return stats, nil
+}
+
+// parseFollowRequest проверяет идентификаторы подписчика и продавца
+func parseFollowRequest(req *listingpb.FollowRequest) error {
+	if _, err := uuid.Parse(req.SellerId); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid seller_id: %v", err)
+	}
+	if _, err := uuid.Parse(req.FollowerId); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid follower_id: %v", err)
+	}
+	return nil
+}
+
+// FollowUser подписывает пользователя на продавца, повторная подписка ничего не меняет
+func (s *server) FollowUser(ctx context.Context, req *listingpb.FollowRequest) (*listingpb.FollowStats, error) {
+	if err := parseFollowRequest(req); err != nil {
+		return nil, err
+	}
+	if req.FollowerId == req.SellerId {
+		return nil, status.Error(codes.InvalidArgument, "you cannot follow yourself")
+	}
+
+	tag, err := s.sql.Exec(ctx, `
+        INSERT INTO follows (follower_id, seller_id, created_at)
+        SELECT $1, id, $3 FROM users WHERE id = $2
+        ON CONFLICT DO NOTHING
+    `, req.FollowerId, req.SellerId, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to follow user: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		err := s.sql.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, req.SellerId).Scan(&exists)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to query user: %v", err)
+		}
+		if !exists {
+			return nil, status.Error(codes.NotFound, "user not found")
+		}
+	}
+	return followStats(ctx, s.sql, req.FollowerId, req.SellerId)
+}
+
+// UnfollowUser отменяет подписку на продавца
+func (s *server) UnfollowUser(ctx context.Context, req *listingpb.FollowRequest) (*listingpb.FollowStats, error) {
+	if err := parseFollowRequest(req); err != nil {
+		return nil, err
+	}
+
+	_, err := s.sql.Exec(ctx, `
+        DELETE FROM follows WHERE follower_id = $1 AND seller_id = $2
+    `, req.FollowerId, req.SellerId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to unfollow user: %v", err)
+	}
+	return followStats(ctx, s.sql, req.FollowerId, req.SellerId)
+}
+
+// GetFollowStats возвращает количество подписчиков и подписок пользователя
+func (s *server) GetFollowStats(ctx context.Context, req *listingpb.FollowRequest) (*listingpb.FollowStats, error) {
+	if err := parseFollowRequest(req); err != nil {
+		return nil, err
+	}
+
+	var exists bool
+	err := s.sql.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, req.SellerId).Scan(&exists)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query user: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return followStats(ctx, s.sql, req.FollowerId, req.SellerId)
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"listingService/listingpb"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Действия в истории объявления
+const (
+	historyCreated       = "created"
+	historyEdited        = "edited"
+	historyDeleted       = "deleted"
+	historyRestored      = "restored"
+	historyBid           = "bid"            // Ставка изменила цену аукционного объявления
+	historyOfferAccepted = "offer_accepted" // Продавец принял предложение цены, объявление зарезервировано
+	historyAuctionClosed = "auction_closed"
+
+	historyReservationExpired = "reservation_expired" // Покупатель не оформил заказ по принятому предложению
+)
+
+// listingState поля объявления, изменения которых сохраняются в истории. Запись о создании
+// изменений не содержит: исходное состояние восстанавливается по полям before следующих записей
+type listingState map[string]any
+
+// fieldChange значение поля до и после изменения
+type fieldChange struct {
+	Before any
+	After  any
+}
+
+// loadListingState читает текущее состояние объявления, в транзакции - с её изменениями
+func loadListingState(ctx context.Context, q querier, listingID string) (listingState, error) {
+	var title, description, address, imageURL, listingStatus string
+	var price int64
+	var categoryID *int64
+	var deletedAt *time.Time
+	err := q.QueryRow(ctx, `
+        SELECT title, description, address, price, image_url, category_id, status, deleted_at
+        FROM listings WHERE id = $1
+    `, listingID).Scan(&title, &description, &address, &price, &imageURL, &categoryID, &listingStatus, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `
+        SELECT name, value_string, value_int, value_bool FROM listing_attributes WHERE listing_id = $1
+    `, listingID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	attrs := make(map[string]any)
+	for rows.Next() {
+		var name string
+		var valueString *string
+		var valueInt *int64
+		var valueBool *bool
+		if err := rows.Scan(&name, &valueString, &valueInt, &valueBool); err != nil {
+			return nil, err
+		}
+		switch {
+		case valueString != nil:
+			attrs[name] = *valueString
+		case valueInt != nil:
+			attrs[name] = *valueInt
+		case valueBool != nil:
+			attrs[name] = *valueBool
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return listingState{
+		"title":       title,
+		"description": description,
+		"address":     address,
+		"price":       price,
+		"image_url":   imageURL,
+		"category_id": categoryID,
+		"status":      listingStatus,
+		"deleted":     deletedAt != nil,
+		"attributes":  attrs,
+	}, nil
+}
+
+// diffStates возвращает поля, значения которых различаются. Значения сравниваются в JSON виде,
+// так же, как они хранятся в истории
+func diffStates(before, after listingState) (map[string]fieldChange, error) {
+	changes := make(map[string]fieldChange)
+	for field, value := range after {
+		a, err := json.Marshal(before[field])
+		if err != nil {
+			return nil, err
+		}
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(a, b) {
+			changes[field] = fieldChange{Before: before[field], After: value}
+		}
+	}
+	return changes, nil
+}
+
+// insertHistory добавляет запись в историю объявления функцией add_listing_history. Пустой actorID
+// означает изменение, сделанное самим сервисом, например закрытие аукциона
+func insertHistory(ctx context.Context, tx pgx.Tx, listingID, actorID, action string, changes map[string]fieldChange, at time.Time) error {
+	before := make(map[string]any, len(changes))
+	after := make(map[string]any, len(changes))
+	for field, change := range changes {
+		before[field] = change.Before
+		after[field] = change.After
+	}
+	beforeData, err := json.Marshal(before)
+	if err != nil {
+		return err
+	}
+	afterData, err := json.Marshal(after)
+	if err != nil {
+		return err
+	}
+
+	var actor *string
+	if actorID != "" && actorID != uuid.Nil.String() {
+		actor = &actorID
+	}
+	_, err = tx.Exec(ctx, `SELECT add_listing_history($1, $2, $3, $4, $5, $6)`,
+		listingID, actor, action, beforeData, afterData, at)
+	return err
+}
+
+// withHistory выполняет изменение объявления в транзакции и сохраняет в истории поля, которые
+// оно поменяло. Изменение без различий в истории не отражается
+func withHistory(ctx context.Context, tx pgx.Tx, listingID, actorID, action string, mutate func() error) error {
+	before, err := loadListingState(ctx, tx, listingID)
+	if err != nil {
+		return err
+	}
+	if err := mutate(); err != nil {
+		return err
+	}
+
+	after, err := loadListingState(ctx, tx, listingID)
+	if err != nil {
+		return err
+	}
+	changes, err := diffStates(before, after)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return insertHistory(ctx, tx, listingID, actorID, action, changes, time.Now())
+}
+
+// GetListingHistory возвращает историю изменений объявления, начиная с новых.
+// Историю видят автор объявления, в том числе удалённого в корзину, и модераторы
+func (s *server) GetListingHistory(ctx context.Context, req *listingpb.GetListingHistoryRequest) (*listingpb.GetListingHistoryResponse, error) {
+	if _, err := uuid.Parse(req.ListingId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid listing_id: %v", err)
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	var authorID string
+	err := s.sql.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1`, req.ListingId).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "listing not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+	if authorID != req.UserId {
+		if err := s.requireModerator(ctx, req.UserId); err != nil {
+			return nil, err
+		}
+	}
+
+	var totalItems int
+	err = s.sql.QueryRow(ctx, `SELECT COUNT(*) FROM listing_history WHERE listing_id = $1`, req.ListingId).Scan(&totalItems)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count history: %v", err)
+	}
+
+	totalPages := int64((totalItems + limit - 1) / limit)
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if req.Page > totalPages {
+		req.Page = totalPages
+	}
+	offset := (req.Page - 1) * int64(limit)
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT h.id, h.actor_id, u.username, h.action, h.changes, h.created_at
+        FROM listing_history h
+        LEFT JOIN users u ON u.id = h.actor_id
+        WHERE h.listing_id = $1
+        ORDER BY h.created_at DESC, h.id
+        LIMIT $2 OFFSET $3
+    `, req.ListingId, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetListingHistoryResponse{TotalPages: totalPages, CurrentPage: req.Page}
+	for rows.Next() {
+		entry := &listingpb.HistoryEntry{ListingId: req.ListingId}
+		var actorID, actorLogin *string
+		var changes []byte
+		var createdAt time.Time
+		if err := rows.Scan(&entry.Id, &actorID, &actorLogin, &entry.Action, &changes, &createdAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		if actorID != nil {
+			entry.ActorId = *actorID
+		}
+		if actorLogin != nil {
+			entry.ActorLogin = *actorLogin
+		}
+		entry.CreatedAt = timestamppb.New(createdAt)
+
+		var fields map[string]struct {
+			Before json.RawMessage `json:"before"`
+			After  json.RawMessage `json:"after"`
+		}
+		if err := json.Unmarshal(changes, &fields); err != nil {
+			return nil, status.Errorf(codes.Internal, "invalid history changes: %v", err)
+		}
+		for field, change := range fields {
+			entry.Changes = append(entry.Changes, &listingpb.FieldChange{
+				Field:  field,
+				Before: string(change.Before),
+				After:  string(change.After),
+			})
+		}
+		sort.Slice(entry.Changes, func(i, j int) bool { return entry.Changes[i].Field < entry.Changes[j].Field })
+
+		resp.Entries = append(resp.Entries, entry)
+	}
+	return resp, nil
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Статусы задачи импорта
+const (
+	importPending = "pending"
+	importRunning = "running"
+	importDone    = "done"
+	importFailed  = "failed"
+)
+
+// maxImportErrors ограничивает количество ошибок, возвращаемых в статусе задачи
+const maxImportErrors = 1000
+
+// importErrDuplicate - ошибка строки, отклонённой как дубликат. Ошибки строк хранятся кодами
+// клиентских сообщений API, совпадает с его ClientErrDuplicateListing
+const importErrDuplicate = "duplicate_listing"
+
+// importListing строка импорта с отпечатками для поиска дубликатов
+type importListing struct {
+	*listingpb.ImportRow
+	id         uuid.UUID
+	textHash   uint64
+	duplicates []duplicateMatch
+}
+
+// CreateImportJob создаёт задачу импорта. Строки с изображениями присылаются API после создания
+// задачи через AppendImportRows, здесь передаются только ошибки разбора файла
+func (s *server) CreateImportJob(ctx context.Context, req *listingpb.CreateImportJobRequest) (*listingpb.ImportJob, error) {
+	authorID, err := uuid.Parse(req.AuthorId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid author_id: %v", err)
+	}
+
+	if req.TotalRows < int64(len(req.Errors)) {
+		return nil, status.Error(codes.InvalidArgument, "total_rows is less than the number of passed errors")
+	}
+
+	id := uuid.New()
+	createdAt := time.Now()
+	failed := int64(len(req.Errors))
+
+	_, err = s.sql.Exec(ctx, `
+        INSERT INTO import_jobs (id, author_id, status, total_rows, processed_rows, failed_rows, created_at, updated_at)
+        VALUES ($1, $2, $3, $4, $5, $5, $6, $6)
+    `, id, authorID, importPending, req.TotalRows, failed, createdAt)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create import job: %v", err)
+	}
+
+	if err := s.saveImportErrors(ctx, id, req.Errors); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save import errors: %v", err)
+	}
+
+	return &listingpb.ImportJob{
+		Id:            id.String(),
+		Status:        importPending,
+		TotalRows:     req.TotalRows,
+		ProcessedRows: failed,
+		FailedRows:    failed,
+		Errors:        req.Errors,
+		CreatedAt:     timestamppb.New(createdAt),
+	}, nil
+}
+
+// AppendImportRows вставляет очередную порцию строк задачи импорта пакетами по importBatchSize
+// и обновляет прогресс. С final задача завершается. Возвращает строки, отклонённые сервисом,
+// чтобы API удалило их изображения
+func (s *server) AppendImportRows(ctx context.Context, req *listingpb.AppendImportRowsRequest) (*listingpb.AppendImportRowsResponse, error) {
+	jobID, err := uuid.Parse(req.JobId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job_id: %v", err)
+	}
+	authorID, err := uuid.Parse(req.AuthorId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid author_id: %v", err)
+	}
+
+	var jobAuthor, jobStatus string
+	var totalRows, processedRows int64
+	err = s.sql.QueryRow(ctx, `
+        SELECT author_id, status, total_rows, processed_rows FROM import_jobs WHERE id = $1
+    `, jobID).Scan(&jobAuthor, &jobStatus, &totalRows, &processedRows)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "import job not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query import job: %v", err)
+	}
+
+	if jobAuthor != authorID.String() {
+		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this import job")
+	}
+	if jobStatus != importPending && jobStatus != importRunning {
+		return nil, status.Errorf(codes.FailedPrecondition, "import job is %s", jobStatus)
+	}
+	if processedRows+int64(len(req.Rows)+len(req.Errors)) > totalRows {
+		return nil, status.Error(codes.InvalidArgument, "import job has fewer rows left than passed")
+	}
+
+	if err := s.saveImportErrors(ctx, jobID, req.Errors); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save import errors: %v", err)
+	}
+	if err := s.updateImportProgress(ctx, jobID, len(req.Errors), 0, len(req.Errors), importRunning); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to update import job: %v", err)
+	}
+
+	var rejected []*listingpb.ImportRowError
+	for start := 0; start < len(req.Rows); start += importBatchSize {
+		end := min(start+importBatchSize, len(req.Rows))
+		batch := req.Rows[start:end]
+
+		imported, rowErrors := s.insertImportBatch(ctx, authorID, batch)
+		rejected = append(rejected, rowErrors...)
+
+		if err := s.saveImportErrors(ctx, jobID, rowErrors); err != nil {
+			log.Printf("import job %s: failed to save errors: %v", jobID, err)
+		}
+		if err := s.updateImportProgress(ctx, jobID, len(batch), imported, len(rowErrors), importRunning); err != nil {
+			log.Printf("import job %s: failed to update progress: %v", jobID, err)
+		}
+	}
+
+	if req.Final {
+		_, err := s.sql.Exec(ctx, `
+            UPDATE import_jobs SET status = $1, finished_at = $2, updated_at = $2 WHERE id = $3
+        `, importDone, time.Now(), jobID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to finish import job: %v", err)
+		}
+	}
+
+	return &listingpb.AppendImportRowsResponse{Errors: rejected}, nil
+}
+
+// GetImportJob возвращает состояние задачи импорта её владельцу
+func (s *server) GetImportJob(ctx context.Context, req *listingpb.GetImportJobRequest) (*listingpb.ImportJob, error) {
+	var job listingpb.ImportJob
+	var authorID string
+	var createdAt time.Time
+	var finishedAt *time.Time
+
+	err := s.sql.QueryRow(ctx, `
+        SELECT id, author_id, status, total_rows, processed_rows, imported_rows, failed_rows, created_at, finished_at
+        FROM import_jobs WHERE id = $1
+    `, req.Id).Scan(
+		&job.Id,
+		&authorID,
+		&job.Status,
+		&job.TotalRows,
+		&job.ProcessedRows,
+		&job.ImportedRows,
+		&job.FailedRows,
+		&createdAt,
+		&finishedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "import job not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query import job: %v", err)
+	}
+
+	if authorID != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this import job")
+	}
+
+	job.CreatedAt = timestamppb.New(createdAt)
+	if finishedAt != nil {
+		job.FinishedAt = timestamppb.New(*finishedAt)
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT row_number, message FROM import_job_errors
+        WHERE job_id = $1 ORDER BY row_number LIMIT $2
+    `, req.Id, maxImportErrors)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query import errors: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rowErr listingpb.ImportRowError
+		if err := rows.Scan(&rowErr.Row, &rowErr.Message); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		job.Errors = append(job.Errors, &rowErr)
+	}
+
+	return &job, nil
+}
+
+// updateImportProgress добавляет обработанные строки к прогрессу задачи и продлевает её срок жизни
+func (s *server) updateImportProgress(ctx context.Context, id uuid.UUID, processed, imported, failed int, jobStatus string) error {
+	_, err := s.sql.Exec(ctx, `
+        UPDATE import_jobs
+        SET status = $1, processed_rows = processed_rows + $2, imported_rows = imported_rows + $3,
+            failed_rows = failed_rows + $4, updated_at = $5
+        WHERE id = $6
+    `, jobStatus, processed, imported, failed, time.Now(), id)
+	return err
+}
+
+// insertImportBatch проверяет строки пакета на дубликаты так же, как AddListing, и вставляет
+// оставшиеся одной транзакцией. Если пакет не прошёл целиком, строки вставляются по одной,
+// чтобы определить ошибочные
+func (s *server) insertImportBatch(ctx context.Context, authorID uuid.UUID, rows []*listingpb.ImportRow) (int, []*listingpb.ImportRowError) {
+	listings, rowErrors := s.checkImportDuplicates(ctx, authorID, rows)
+	if len(listings) == 0 {
+		return 0, rowErrors
+	}
+
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		batch := &pgx.Batch{}
+		for _, l := range listings {
+			batch.Queue(insertImportRowQuery, importRowArgs(authorID, l)...)
+			for _, m := range l.duplicates {
+				batch.Queue(insertDuplicateQuery, l.id, m.listingID, m.sameAuthor, m.imageDistance, m.textDistance, time.Now())
+			}
+		}
+		return tx.SendBatch(ctx, batch).Close()
+	})
+	if err == nil {
+		return len(listings), rowErrors
+	}
+
+	imported := 0
+	failed := make(map[string]bool)
+	for _, l := range listings {
+		err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+			if _, err := tx.Exec(ctx, insertImportRowQuery, importRowArgs(authorID, l)...); err != nil {
+				return err
+			}
+			// Совпадения со строками пакета, которые не вставились, сохранить нельзя
+			var duplicates []duplicateMatch
+			for _, m := range l.duplicates {
+				if !failed[m.listingID] {
+					duplicates = append(duplicates, m)
+				}
+			}
+			return saveDuplicates(ctx, tx, l.id.String(), duplicates)
+		})
+		if err != nil {
+			failed[l.id.String()] = true
+			rowErrors = append(rowErrors, &listingpb.ImportRowError{
+				Row:     l.Row,
+				Message: err.Error(),
+			})
+			continue
+		}
+		imported++
+	}
+	return imported, rowErrors
+}
+
+// checkImportDuplicates ищет для строк пакета похожие активные объявления и похожие строки этого же
+// импорта. В режиме duplicateReject такие строки не вставляются и возвращаются как ошибки
+func (s *server) checkImportDuplicates(ctx context.Context, authorID uuid.UUID, rows []*listingpb.ImportRow) ([]*importListing, []*listingpb.ImportRowError) {
+	var listings []*importListing
+	var rowErrors []*listingpb.ImportRowError
+	for _, row := range rows {
+		l := &importListing{
+			ImportRow: row,
+			id:        uuid.New(),
+			textHash:  textFingerprint(row.Title, row.Description),
+		}
+
+		duplicates, err := s.findDuplicates(ctx, authorID.String(), row.ImageHash, l.textHash)
+		if err != nil {
+			rowErrors = append(rowErrors, &listingpb.ImportRowError{Row: row.Row, Message: err.Error()})
+			continue
+		}
+		// Предыдущие строки пакета ещё не в базе, с ними отпечатки сравниваются здесь
+		for _, prev := range listings {
+			if len(duplicates) >= maxDuplicateMatches {
+				break
+			}
+			if m, ok := matchFingerprints(prev.id.String(), row.ImageHash, l.textHash, prev.ImageHash, prev.textHash); ok {
+				duplicates = append(duplicates, m)
+			}
+		}
+
+		if len(duplicates) > 0 && duplicateMode == duplicateReject {
+			rowErrors = append(rowErrors, &listingpb.ImportRowError{Row: row.Row, Message: importErrDuplicate})
+			continue
+		}
+		l.duplicates = duplicates
+		listings = append(listings, l)
+	}
+	return listings, rowErrors
+}
+
+// insertImportRowQuery вставляет объявление вместе с записью о создании в его истории
+const insertImportRowQuery = `
+    WITH created AS (
+        INSERT INTO listings (id, title, description, address, price, author_id, created_at, image_url, image_hash, text_hash)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+        RETURNING id, author_id, created_at
+    )
+    SELECT add_listing_history(id, author_id, $11::text, NULL, NULL, created_at) FROM created
+`
+
+func importRowArgs(authorID uuid.UUID, l *importListing) []interface{} {
+	return []interface{}{
+		l.id,
+		l.Title,
+		l.Description,
+		l.Address,
+		l.Price,
+		authorID,
+		time.Now(),
+		l.ImageUrl,
+		nullImageHash(l.ImageHash),
+		int64(l.textHash),
+		historyCreated,
+	}
+}
+
+// saveImportErrors сохраняет ошибки строк импорта
+func (s *server) saveImportErrors(ctx context.Context, jobID uuid.UUID, rowErrors []*listingpb.ImportRowError) error {
+	if len(rowErrors) == 0 {
+		return nil
+	}
+
+	batch := &pgx.Batch{}
+	for _, rowErr := range rowErrors {
+		batch.Queue(`
+            INSERT INTO import_job_errors (job_id, row_number, message) VALUES ($1, $2, $3)
+        `, jobID, rowErr.Row, rowErr.Message)
+	}
+	return s.sql.SendBatch(ctx, batch).Close()
+}
+
+// failStaleImportJobs периодически помечает как проваленные задачи, в которые API дольше importTimeout
+// не присылало строк: обработка на его стороне прервалась, и оставшиеся строки потеряны
+func (s *server) failStaleImportJobs(ctx context.Context) {
+	ticker := time.NewTicker(importTimeout)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			tag, err := s.sql.Exec(ctx, `
+                UPDATE import_jobs SET status = $1, finished_at = $2, updated_at = $2
+                WHERE status IN ($3, $4) AND updated_at <= $5
+            `, importFailed, now, importPending, importRunning, now.Add(-importTimeout))
+			if err != nil {
+				log.Printf("failed to mark stale import jobs: %v", err)
+				continue
+			}
+			if tag.RowsAffected() > 0 {
+				log.Printf("failed %d stale import jobs", tag.RowsAffected())
+			}
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -25,11 +26,70 @@ import (
 
 type server struct {
 	listingpb.UnimplementedListingServiceServer
-	sql *pgx.Conn
+	sql *pgxpool.Pool
 }
 
 var limit int
 
+// maxAuthorFilter - наибольшее число авторов в фильтре выдачи
+const maxAuthorFilter = 50
+
+// maxPageSize - наибольший размер страницы, который можно запросить для выгрузок
+const maxPageSize = 1000
+
+// authorNameColumn - имя автора объявления: отображаемое имя из профиля, а если оно не задано - логин.
+// Выражение рассчитано на таблицу пользователей, присоединённую под псевдонимом u
+const authorNameColumn = `COALESCE(NULLIF(u.display_name, ''), u.username)`
+
+var (
+	trashRetention time.Duration // сколько удалённое объявление хранится в корзине
+	purgeInterval  time.Duration // как часто запускается очистка корзины
+)
+
+var (
+	importBatchSize int           // количество строк импорта, вставляемых одной транзакцией
+	importTimeout   time.Duration // через сколько задача импорта без новых строк считается прерванной
+)
+
+var facetBuckets int // количество интервалов гистограммы цен по умолчанию
+
+var (
+	similarityInterval time.Duration // как часто пересчитывается сходство объявлений
+	similarityTop      int           // сколько похожих объявлений хранится для каждого объявления
+)
+
+var (
+	trendHalfLife time.Duration // период полураспада веса лайков и просмотров в трендовой сортировке
+	viewWindow    time.Duration // в течение какого времени повторные просмотры пользователя не учитываются
+)
+
+var (
+	duplicateMode          string // что делать с найденными дубликатами: flag или reject
+	duplicateImageDistance int    // максимальное расстояние Хэмминга между хэшами похожих изображений
+	duplicateTextDistance  int    // максимальное расстояние Хэмминга между отпечатками похожих текстов
+)
+
+var (
+	promotionSlots          []int         // позиции рекламных мест на странице выдачи
+	promotionExpireInterval time.Duration // как часто закончившиеся продвижения переводятся в expired
+)
+
+var offerTTL time.Duration // срок действия предложения цены, если покупатель не указал свой
+
+var offerReservation time.Duration // сколько принятое предложение держит объявление в резерве, пока покупатель не оформит заказ
+
+var (
+	auctionExtension     time.Duration // на сколько продлевается аукцион ставкой, сделанной перед его окончанием
+	auctionCloseInterval time.Duration // как часто закрываются закончившиеся аукционы
+)
+
+var (
+	suggestTimeout     time.Duration // бюджет времени на подсказки поиска, не успевшие источники пропускаются
+	suggestLimit       int           // сколько подсказок возвращается по умолчанию
+	suggestMinSearches int           // сколько раз запрос должны искать, чтобы он стал подсказкой
+	suggestMinUsers    int           // сколько разных пользователей должны его искать
+)
+
 func init() {
 	err := godotenv.Load()
 	if err != nil {
@@ -41,6 +101,144 @@ func init() {
 	if err != nil {
 		log.Fatalf("invalid LISTING_LIMIT: %v", err)
 	}
+
+	retentionHours, err := envInt("LISTING_TRASH_RETENTION", 720)
+	if err != nil || retentionHours <= 0 {
+		log.Fatalf("invalid LISTING_TRASH_RETENTION: %v", err)
+	}
+	trashRetention = time.Duration(retentionHours) * time.Hour
+
+	purgeMinutes, err := envInt("LISTING_PURGE_INTERVAL", 60)
+	if err != nil || purgeMinutes <= 0 {
+		log.Fatalf("invalid LISTING_PURGE_INTERVAL: %v", err)
+	}
+	purgeInterval = time.Duration(purgeMinutes) * time.Minute
+
+	importBatchSize, err = envInt("LISTING_IMPORT_BATCH", 100)
+	if err != nil || importBatchSize <= 0 {
+		log.Fatalf("invalid LISTING_IMPORT_BATCH: %v", err)
+	}
+
+	importTimeoutMinutes, err := envInt("LISTING_IMPORT_TIMEOUT", 30)
+	if err != nil || importTimeoutMinutes <= 0 {
+		log.Fatalf("invalid LISTING_IMPORT_TIMEOUT: %v", err)
+	}
+	importTimeout = time.Duration(importTimeoutMinutes) * time.Minute
+
+	facetBuckets, err = envInt("LISTING_FACET_BUCKETS", 10)
+	if err != nil || facetBuckets <= 0 || facetBuckets > maxFacetBuckets {
+		log.Fatalf("invalid LISTING_FACET_BUCKETS: %v", err)
+	}
+
+	similarityMinutes, err := envInt("LISTING_SIMILARITY_INTERVAL", 60)
+	if err != nil || similarityMinutes <= 0 {
+		log.Fatalf("invalid LISTING_SIMILARITY_INTERVAL: %v", err)
+	}
+	similarityInterval = time.Duration(similarityMinutes) * time.Minute
+
+	similarityTop, err = envInt("LISTING_SIMILARITY_TOP", 20)
+	if err != nil || similarityTop <= 0 {
+		log.Fatalf("invalid LISTING_SIMILARITY_TOP: %v", err)
+	}
+
+	halfLifeHours, err := envInt("LISTING_TREND_HALF_LIFE", 24)
+	if err != nil || halfLifeHours <= 0 {
+		log.Fatalf("invalid LISTING_TREND_HALF_LIFE: %v", err)
+	}
+	trendHalfLife = time.Duration(halfLifeHours) * time.Hour
+
+	viewWindowHours, err := envInt("LISTING_VIEW_WINDOW", 24)
+	if err != nil || viewWindowHours <= 0 {
+		log.Fatalf("invalid LISTING_VIEW_WINDOW: %v", err)
+	}
+	viewWindow = time.Duration(viewWindowHours) * time.Hour
+
+	slots := os.Getenv("LISTING_PROMOTION_SLOTS")
+	if slots == "" {
+		slots = "0,5"
+	}
+	promotionSlots, err = parsePromotionSlots(slots)
+	if err != nil {
+		log.Fatalf("invalid LISTING_PROMOTION_SLOTS: %v", err)
+	}
+
+	expireMinutes, err := envInt("LISTING_PROMOTION_INTERVAL", 5)
+	if err != nil || expireMinutes <= 0 {
+		log.Fatalf("invalid LISTING_PROMOTION_INTERVAL: %v", err)
+	}
+	promotionExpireInterval = time.Duration(expireMinutes) * time.Minute
+
+	duplicateMode = os.Getenv("LISTING_DUPLICATE_MODE")
+	if duplicateMode == "" {
+		duplicateMode = duplicateFlag
+	}
+	if duplicateMode != duplicateFlag && duplicateMode != duplicateReject {
+		log.Fatalf("invalid LISTING_DUPLICATE_MODE: %q", duplicateMode)
+	}
+
+	duplicateImageDistance, err = envInt("LISTING_DUPLICATE_IMAGE_DISTANCE", 6)
+	if err != nil || duplicateImageDistance < 0 || duplicateImageDistance > 64 {
+		log.Fatalf("invalid LISTING_DUPLICATE_IMAGE_DISTANCE: %v", err)
+	}
+
+	duplicateTextDistance, err = envInt("LISTING_DUPLICATE_TEXT_DISTANCE", 3)
+	if err != nil || duplicateTextDistance < 0 || duplicateTextDistance > 64 {
+		log.Fatalf("invalid LISTING_DUPLICATE_TEXT_DISTANCE: %v", err)
+	}
+
+	offerTTLHours, err := envInt("LISTING_OFFER_TTL", 48)
+	if err != nil || offerTTLHours <= 0 {
+		log.Fatalf("invalid LISTING_OFFER_TTL: %v", err)
+	}
+	offerTTL = time.Duration(offerTTLHours) * time.Hour
+
+	reservationHours, err := envInt("LISTING_OFFER_RESERVATION", 24)
+	if err != nil || reservationHours <= 0 {
+		log.Fatalf("invalid LISTING_OFFER_RESERVATION: %v", err)
+	}
+	offerReservation = time.Duration(reservationHours) * time.Hour
+
+	extensionMinutes, err := envInt("LISTING_AUCTION_EXTENSION", 5)
+	if err != nil || extensionMinutes < 0 {
+		log.Fatalf("invalid LISTING_AUCTION_EXTENSION: %v", err)
+	}
+	auctionExtension = time.Duration(extensionMinutes) * time.Minute
+
+	closeMinutes, err := envInt("LISTING_AUCTION_INTERVAL", 1)
+	if err != nil || closeMinutes <= 0 {
+		log.Fatalf("invalid LISTING_AUCTION_INTERVAL: %v", err)
+	}
+	auctionCloseInterval = time.Duration(closeMinutes) * time.Minute
+
+	suggestMillis, err := envInt("LISTING_SUGGEST_TIMEOUT", 50)
+	if err != nil || suggestMillis <= 0 {
+		log.Fatalf("invalid LISTING_SUGGEST_TIMEOUT: %v", err)
+	}
+	suggestTimeout = time.Duration(suggestMillis) * time.Millisecond
+
+	suggestLimit, err = envInt("LISTING_SUGGEST_LIMIT", 10)
+	if err != nil || suggestLimit <= 0 || suggestLimit > maxSuggestions {
+		log.Fatalf("invalid LISTING_SUGGEST_LIMIT: %v", err)
+	}
+
+	suggestMinSearches, err = envInt("LISTING_SUGGEST_MIN_SEARCHES", 5)
+	if err != nil || suggestMinSearches <= 0 {
+		log.Fatalf("invalid LISTING_SUGGEST_MIN_SEARCHES: %v", err)
+	}
+
+	suggestMinUsers, err = envInt("LISTING_SUGGEST_MIN_USERS", 3)
+	if err != nil || suggestMinUsers <= 0 {
+		log.Fatalf("invalid LISTING_SUGGEST_MIN_USERS: %v", err)
+	}
+}
+
+// envInt читает целочисленную переменную окружения, подставляя значение по умолчанию, если она не задана
+func envInt(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
 }
 
 const (
@@ -49,12 +247,50 @@ const (
 
 var acl = map[string][]string{
 	// ListingService methods
-	"/listingpb.ListingService/GetAllListings": {listing},
-	"/listingpb.ListingService/AddListing":     {listing},
-	"/listingpb.ListingService/EditListing":    {listing},
-	"/listingpb.ListingService/DeleteListing":  {listing},
-	"/listingpb.ListingService/AddLike":        {listing},
-	"/listingpb.ListingService/RemoveLike":     {listing},
+	"/listingpb.ListingService/GetAllListings":       {listing},
+	"/listingpb.ListingService/GetListing":           {listing},
+	"/listingpb.ListingService/AddListing":           {listing},
+	"/listingpb.ListingService/EditListing":          {listing},
+	"/listingpb.ListingService/DeleteListing":        {listing},
+	"/listingpb.ListingService/AddLike":              {listing},
+	"/listingpb.ListingService/RemoveLike":           {listing},
+	"/listingpb.ListingService/GetTrash":             {listing},
+	"/listingpb.ListingService/RestoreListing":       {listing},
+	"/listingpb.ListingService/CreateImportJob":      {listing},
+	"/listingpb.ListingService/AppendImportRows":     {listing},
+	"/listingpb.ListingService/GetImportJob":         {listing},
+	"/listingpb.ListingService/GetFeedToken":         {listing},
+	"/listingpb.ListingService/StreamFeed":           {listing},
+	"/listingpb.ListingService/GetCategories":        {listing},
+	"/listingpb.ListingService/GetListingFacets":     {listing},
+	"/listingpb.ListingService/GetSimilarListings":   {listing},
+	"/listingpb.ListingService/GetRecommendations":   {listing},
+	"/listingpb.ListingService/RecordView":           {listing},
+	"/listingpb.ListingService/CreatePromotion":      {listing},
+	"/listingpb.ListingService/GetPromotions":        {listing},
+	"/listingpb.ListingService/GetDuplicates":        {listing},
+	"/listingpb.ListingService/MakeOffer":            {listing},
+	"/listingpb.ListingService/RespondOffer":         {listing},
+	"/listingpb.ListingService/GetOffers":            {listing},
+	"/listingpb.ListingService/PlaceBid":             {listing},
+	"/listingpb.ListingService/GetAuction":           {listing},
+	"/listingpb.ListingService/CreateCollection":     {listing},
+	"/listingpb.ListingService/RenameCollection":     {listing},
+	"/listingpb.ListingService/DeleteCollection":     {listing},
+	"/listingpb.ListingService/GetCollections":       {listing},
+	"/listingpb.ListingService/AddToCollection":      {listing},
+	"/listingpb.ListingService/RemoveFromCollection": {listing},
+	"/listingpb.ListingService/ShareCollection":      {listing},
+	"/listingpb.ListingService/GetSharedCollection":  {listing},
+	"/listingpb.ListingService/FollowUser":           {listing},
+	"/listingpb.ListingService/UnfollowUser":         {listing},
+	"/listingpb.ListingService/GetFollowStats":       {listing},
+	"/listingpb.ListingService/AskQuestion":          {listing},
+	"/listingpb.ListingService/AnswerQuestion":       {listing},
+	"/listingpb.ListingService/GetQuestions":         {listing},
+	"/listingpb.ListingService/HideQuestion":         {listing},
+	"/listingpb.ListingService/SuggestListings":      {listing},
+	"/listingpb.ListingService/GetListingHistory":    {listing},
 }
 
 // UnaryInterceptor — перехватчик запросов
@@ -64,32 +300,55 @@ func UnaryInterceptor(
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
+	if err := authorize(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamInterceptor — перехватчик потоковых запросов
+func StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if err := authorize(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+
+	return handler(srv, ss)
+}
+
+// authorize проверяет токен сервиса и права на вызов метода по acl-таблице
+func authorize(ctx context.Context, fullMethod string) error {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+		return status.Error(codes.Unauthenticated, "missing metadata")
 	}
 
 	authHeader := md.Get("authorization")
 	if len(authHeader) == 0 {
-		return nil, status.Error(codes.Unauthenticated, "missing token")
+		return status.Error(codes.Unauthenticated, "missing token")
 	}
 
 	token := strings.TrimPrefix(authHeader[0], "Bearer ")
 	role, err := getRoleByToken(token)
 	if err != nil {
-		return nil, status.Error(codes.PermissionDenied, "invalid token")
+		return status.Error(codes.PermissionDenied, "invalid token")
 	}
 
-	allowedRoles, ok := acl[info.FullMethod]
+	allowedRoles, ok := acl[fullMethod]
 	if !ok {
-		return nil, status.Error(codes.PermissionDenied, "method not allowed")
+		return status.Error(codes.PermissionDenied, "method not allowed")
 	}
 
 	if !contains(allowedRoles, role) {
-		return nil, status.Error(codes.PermissionDenied, "access denied")
+		return status.Error(codes.PermissionDenied, "access denied")
 	}
 
-	return handler(ctx, req)
+	return nil
 }
 
 func getRoleByToken(token string) (string, error) {
@@ -110,26 +369,17 @@ func contains(list []string, target string) bool {
 	return false
 }
 
-func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListingsRequest) (*listingpb.GetAllListingsResponse, error) {
-	if req.Page < 1 {
-		req.Page = 1
-	}
-
-	// Нормализация параметров сортировки
-	sortField := "created_at"
-	sortOrder := "DESC"
-
-	switch req.SortField {
-	case "price":
-		sortField = "price"
-	case "created_at":
-		sortField = "created_at"
-	}
-
-	if strings.ToUpper(req.SortOrder) == "ASC" {
-		sortOrder = "ASC"
-	}
+// listingWhere условия выборки объявлений, общие для GetAllListings и GetListingFacets
+type listingWhere struct {
+	conditions []string
+	args       []interface{}
+	argIdx     int             // номер следующего параметра запроса
+	likedMap   map[string]bool // избранное пользователя, если передан UserId
+	empty      bool            // включён фильтр по избранному, а избранных нет
+}
 
+// listingWhere строит условия выборки объявлений по фильтру запроса
+func (s *server) listingWhere(ctx context.Context, req *listingpb.GetAllListingsRequest) (*listingWhere, error) {
 	// Получаем лайкнутые ID, если передан UserId
 	var likedMap map[string]bool
 	var likedListingIDs []uuid.UUID
@@ -152,26 +402,12 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 		}
 	}
 
-	// Если OnlyLiked включен, но список пуст — сразу возвращаем пусто
+	// Если OnlyLiked включен, но список пуст — выборка заведомо пуста
 	if req.OnlyLiked && len(likedListingIDs) == 0 {
-		return &listingpb.GetAllListingsResponse{
-			Listings:    []*listingpb.Listing{},
-			TotalPages:  0,
-			CurrentPage: 0,
-		}, nil
+		return &listingWhere{likedMap: likedMap, empty: true}, nil
 	}
 
-	// Базовый SQL-запрос
-	baseQuery := `
-        SELECT 
-            l.id, l.title, l.description, l.address, l.price, 
-            l.author_id, u.username as author_username,
-            l.created_at, l.image_url, l.likes
-        FROM listings l
-        LEFT JOIN users u ON l.author_id = u.id
-    `
-
-	var conditions []string
+	conditions := []string{"l.deleted_at IS NULL"}
 	var args []interface{}
 	argIdx := 1
 
@@ -186,6 +422,23 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 		argIdx++
 	}
 
+	// Фильтр по подборке избранного
+	if req.CollectionId != "" {
+		if err := s.canViewCollection(ctx, req.CollectionId, req.UserId, req.ShareToken); err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, fmt.Sprintf("l.id IN (SELECT listing_id FROM collection_listings WHERE collection_id = $%d)", argIdx))
+		args = append(args, req.CollectionId)
+		argIdx++
+	}
+
+	// Лента подписок: объявления продавцов, на которых подписан пользователь
+	if req.Following {
+		conditions = append(conditions, fmt.Sprintf("l.author_id IN (SELECT seller_id FROM follows WHERE follower_id = $%d)", argIdx))
+		args = append(args, req.UserId)
+		argIdx++
+	}
+
 	// Фильтр по автору
 	if req.TargetUserId != "" && req.TargetUserId != uuid.Nil.String() {
 		conditions = append(conditions, fmt.Sprintf("l.author_id = $%d", argIdx))
@@ -193,6 +446,50 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 		argIdx++
 	}
 
+	// Фильтр по нескольким авторам
+	if len(req.AuthorIds) > 0 {
+		if len(req.AuthorIds) > maxAuthorFilter {
+			return nil, status.Errorf(codes.InvalidArgument, "too many author_ids, at most %d allowed", maxAuthorFilter)
+		}
+		for _, id := range req.AuthorIds {
+			if _, err := uuid.Parse(id); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid author_ids: %v", err)
+			}
+		}
+		conditions = append(conditions, fmt.Sprintf("l.author_id = ANY($%d::uuid[])", argIdx))
+		args = append(args, req.AuthorIds)
+		argIdx++
+	}
+
+	// Период публикации: created_after включительно, created_before - нет
+	if req.CreatedAfter != nil {
+		conditions = append(conditions, fmt.Sprintf("l.created_at >= $%d", argIdx))
+		args = append(args, req.CreatedAfter.AsTime().Local())
+		argIdx++
+	}
+	if req.CreatedBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("l.created_at < $%d", argIdx))
+		args = append(args, req.CreatedBefore.AsTime().Local())
+		argIdx++
+	}
+
+	if req.MinLikes > 0 {
+		conditions = append(conditions, fmt.Sprintf("l.likes >= $%d", argIdx))
+		args = append(args, req.MinLikes)
+		argIdx++
+	}
+
+	if req.HasImage {
+		conditions = append(conditions, "l.image_url <> ''")
+	}
+
+	// Поиск по названию, индекс listings_title_trgm_idx ускоряет поиск подстроки
+	if query := normalizeQuery(req.Query); query != "" {
+		conditions = append(conditions, fmt.Sprintf("lower(l.title) LIKE $%d", argIdx))
+		args = append(args, "%"+escapeLike(query)+"%")
+		argIdx++
+	}
+
 	conditions = append(conditions, fmt.Sprintf("l.price >= $%d", argIdx))
 	args = append(args, req.MinPrice)
 	argIdx++
@@ -200,36 +497,122 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 	args = append(args, req.MaxPrice)
 	argIdx++
 
-	// Подсчёт общего количества записей
-	countQuery := baseQuery
-	if len(conditions) > 0 {
-		countQuery += " WHERE " + strings.Join(conditions, " AND ")
+	// Фильтр по категории и её атрибутам
+	if req.CategoryId != 0 {
+		conditions = append(conditions, fmt.Sprintf("l.category_id = $%d", argIdx))
+		args = append(args, req.CategoryId)
+		argIdx++
+	}
+
+	attrConditions, attrArgs, argIdx, err := attributeConditions(ctx, s.sql, req.CategoryId, req.AttributeFilters, argIdx)
+	if err != nil {
+		return nil, err
+	}
+	conditions = append(conditions, attrConditions...)
+	args = append(args, attrArgs...)
+
+	return &listingWhere{
+		conditions: conditions,
+		args:       args,
+		argIdx:     argIdx,
+		likedMap:   likedMap,
+	}, nil
+}
+
+func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListingsRequest) (*listingpb.GetAllListingsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	// Нормализация параметров сортировки
+	sortField := "created_at"
+	sortOrder := "DESC"
+
+	switch req.SortField {
+	case "price":
+		sortField = "price"
+	case "created_at":
+		sortField = "created_at"
+	case "likes":
+		sortField = "likes"
 	}
+
+	if strings.ToUpper(req.SortOrder) == "ASC" {
+		sortOrder = "ASC"
+	}
+
+	orderBy := sortField + " " + sortOrder
+	if sortField == "likes" {
+		// У многих объявлений лайков поровну, без второго ключа страницы перемешивались бы
+		orderBy += ", created_at DESC"
+	}
+	if req.Following {
+		// Лента подписок всегда идёт по дате публикации
+		orderBy = "created_at " + sortOrder
+	} else if req.SortField == "trending" {
+		// Трендовые объявления всегда идут по убыванию счёта, объявления без лайков и просмотров — в конце
+		orderBy = "trend_score DESC NULLS LAST, created_at DESC"
+	}
+
+	where, err := s.listingWhere(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Если OnlyLiked включен, но список пуст — сразу возвращаем пусто
+	if where.empty {
+		return &listingpb.GetAllListingsResponse{
+			Listings:    []*listingpb.Listing{},
+			TotalPages:  0,
+			CurrentPage: 0,
+		}, nil
+	}
+
+	// Базовый SQL-запрос
+	baseQuery := `
+        SELECT 
+            l.id, l.title, l.description, l.address, l.price, 
+            l.author_id, ` + authorNameColumn + ` as author_username,
+            l.created_at, l.image_url, l.likes, l.category_id, l.status
+        FROM listings l
+        LEFT JOIN users u ON l.author_id = u.id
+    `
+	conditions, args, argIdx, likedMap := where.conditions, where.args, where.argIdx, where.likedMap
+
+	// Подсчёт общего количества записей
+	countQuery := baseQuery + " WHERE " + strings.Join(conditions, " AND ")
 	countQuery = "SELECT COUNT(*) FROM (" + countQuery + ") AS filtered_listings"
 
 	var totalItems int
-	err := s.sql.QueryRow(ctx, countQuery, args...).Scan(&totalItems)
+	err = s.sql.QueryRow(ctx, countQuery, args...).Scan(&totalItems)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to count listings: %v", err)
 	}
 
-	// Пагинация
-	totalPages := int64((totalItems + limit - 1) / limit)
+	// Запросы, по которым что-то нашлось, попадают в подсказки поиска. Выгрузки и
+	// перелистывание страниц популярность запроса не увеличивают
+	if req.Query != "" && req.Page == 1 && req.PageSize == 0 && totalItems > 0 {
+		s.logSearchQuery(ctx, req.Query, req.UserId)
+	}
+
+	// Пагинация, выгрузки (карта сайта, RSS) запрашивают свой размер страницы
+	pageSize := limit
+	if req.PageSize > 0 {
+		pageSize = int(min(req.PageSize, maxPageSize))
+	}
+	totalPages := int64((totalItems + pageSize - 1) / pageSize)
 	if totalPages == 0 {
 		totalPages = 1
 	}
 	if req.Page > totalPages {
 		req.Page = totalPages
 	}
-	offset := (req.Page - 1) * int64(limit)
+	offset := (req.Page - 1) * int64(pageSize)
 
 	// Финальный запрос
-	query := baseQuery
-	if len(conditions) > 0 {
-		query += " WHERE " + strings.Join(conditions, " AND ")
-	}
-	query += fmt.Sprintf(" ORDER BY %s %s LIMIT $%d OFFSET $%d", sortField, sortOrder, argIdx, argIdx+1)
-	args = append(args, limit, offset)
+	query := baseQuery + " WHERE " + strings.Join(conditions, " AND ")
+	query += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", orderBy, argIdx, argIdx+1)
+	args = append(args, pageSize, offset)
 
 	rows, err := s.sql.Query(ctx, query, args...)
 	if err != nil {
@@ -243,6 +626,7 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 		var l listingpb.Listing
 		var createdAt time.Time
 		var authorUsername *string
+		var categoryID *int64
 
 		if err := rows.Scan(
 			&l.Id,
@@ -255,6 +639,8 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 			&createdAt,
 			&l.ImageUrl,
 			&l.Likes,
+			&categoryID,
+			&l.Status,
 		); err != nil {
 			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
 		}
@@ -263,6 +649,10 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 			l.AuthorLogin = *authorUsername
 		}
 
+		if categoryID != nil {
+			l.CategoryId = *categoryID
+		}
+
 		l.CreatedAt = timestamppb.New(createdAt)
 		l.IsYours = (req.UserId != "" && l.AuthorId == req.UserId)
 
@@ -272,6 +662,20 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 
 		listings = append(listings, &l)
 	}
+	rows.Close()
+
+	// Продвигаемые объявления встраиваются только в общую выдачу, не в избранное, не в объявления продавца
+	// и не в выгрузки со своим размером страницы
+	if !req.OnlyLiked && req.PageSize == 0 && (req.TargetUserId == "" || req.TargetUserId == uuid.Nil.String()) {
+		listings, err = s.injectPromotions(ctx, req, where, listings)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.loadAttributes(ctx, listings); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load attributes: %v", err)
+	}
 
 	return &listingpb.GetAllListingsResponse{
 		Listings:    listings,
@@ -280,23 +684,115 @@ func (s *server) GetAllListings(ctx context.Context, req *listingpb.GetAllListin
 	}, nil
 }
 
+// GetListing возвращает одно неудалённое объявление
+func (s *server) GetListing(ctx context.Context, req *listingpb.GetListingRequest) (*listingpb.Listing, error) {
+	if _, err := uuid.Parse(req.ListingId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid listing_id: %v", err)
+	}
+	// Для анонимного пользователя признак лайка всегда ложный
+	userID := req.UserId
+	if userID == "" {
+		userID = uuid.Nil.String()
+	}
+
+	var l listingpb.Listing
+	var createdAt time.Time
+	var authorUsername *string
+	var categoryID *int64
+	err := s.sql.QueryRow(ctx, `
+        SELECT
+            l.id, l.title, l.description, l.address, l.price,
+            l.author_id, `+authorNameColumn+`, l.created_at, l.image_url, l.likes, l.category_id, l.status,
+            EXISTS (SELECT 1 FROM users WHERE id = $2 AND l.id = ANY(liked_listings))
+        FROM listings l
+        LEFT JOIN users u ON l.author_id = u.id
+        WHERE l.id = $1 AND l.deleted_at IS NULL
+    `, req.ListingId, userID).Scan(
+		&l.Id, &l.Title, &l.Description, &l.Address, &l.Price,
+		&l.AuthorId, &authorUsername, &createdAt, &l.ImageUrl, &l.Likes, &categoryID, &l.Status,
+		&l.IsLiked,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "listing not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+
+	if authorUsername != nil {
+		l.AuthorLogin = *authorUsername
+	}
+	if categoryID != nil {
+		l.CategoryId = *categoryID
+	}
+	l.CreatedAt = timestamppb.New(createdAt)
+	l.IsYours = req.UserId != "" && l.AuthorId == req.UserId
+
+	if err := s.loadAttributes(ctx, []*listingpb.Listing{&l}); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load attributes: %v", err)
+	}
+	return &l, nil
+}
+
 func (s *server) AddListing(ctx context.Context, req *listingpb.AddListingRequest) (*listingpb.AddListingResponse, error) {
 	id := uuid.New()
 	createdAt := time.Now()
 
-	_, err := s.sql.Exec(ctx, `
-        INSERT INTO listings (id, title, description, address, price, author_id, created_at, image_url)
-        VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-    `,
-		id,
-		req.Title,
-		req.Description,
-		req.Address,
-		req.Price,
-		req.AuthorId,
-		createdAt,
-		req.ImageUrl,
-	)
+	attrs, err := validateAttributes(ctx, s.sql, req.CategoryId, req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	var startPrice int64
+	var endsAt time.Time
+	if req.Auction != nil {
+		if startPrice, endsAt, err = validateAuction(req.Auction, req.Price, createdAt); err != nil {
+			return nil, err
+		}
+	}
+
+	textHash := textFingerprint(req.Title, req.Description)
+	duplicates, err := s.findDuplicates(ctx, req.AuthorId, req.ImageHash, textHash)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to check duplicates: %v", err)
+	}
+	if len(duplicates) > 0 && duplicateMode == duplicateReject {
+		return nil, status.Errorf(codes.AlreadyExists, "listing duplicates listing %s", duplicates[0].listingID)
+	}
+
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `
+            INSERT INTO listings (id, title, description, address, price, author_id, created_at, image_url, category_id, image_hash, text_hash)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+        `,
+			id,
+			req.Title,
+			req.Description,
+			req.Address,
+			req.Price,
+			req.AuthorId,
+			createdAt,
+			req.ImageUrl,
+			nullCategory(req.CategoryId),
+			nullImageHash(req.ImageHash),
+			int64(textHash),
+		)
+		if err != nil {
+			return err
+		}
+		if err := saveAttributes(ctx, tx, id.String(), attrs); err != nil {
+			return err
+		}
+		if req.Auction != nil {
+			if err := insertAuction(ctx, tx, id.String(), startPrice, req.Auction.MinIncrement, endsAt); err != nil {
+				return err
+			}
+		}
+		if err := saveDuplicates(ctx, tx, id.String(), duplicates); err != nil {
+			return err
+		}
+		return insertHistory(ctx, tx, id.String(), req.AuthorId, historyCreated, nil, createdAt)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -308,7 +804,7 @@ func (s *server) AddListing(ctx context.Context, req *listingpb.AddListingReques
 
 func (s *server) EditListing(ctx context.Context, req *listingpb.EditListingRequest) (*listingpb.Empty, error) {
 	var authorID string
-	err := s.sql.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1`, req.Id).Scan(&authorID)
+	err := s.sql.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL`, req.Id).Scan(&authorID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "listing not found")
@@ -320,27 +816,47 @@ func (s *server) EditListing(ctx context.Context, req *listingpb.EditListingRequ
 		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this listing")
 	}
 
-	_, err = s.sql.Exec(ctx, `
-        UPDATE listings
-        SET title = $1, description = $2, address = $3, price = $4, image_url = $5
-        WHERE id = $6
-    `,
-		req.Title,
-		req.Description,
-		req.Address,
-		req.Price,
-		req.ImageUrl,
-		req.Id,
-	)
+	attrs, err := validateAttributes(ctx, s.sql, req.CategoryId, req.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Цену аукционного объявления определяют ставки, поэтому при редактировании она не меняется
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		return withHistory(ctx, tx, req.Id, req.UserId, historyEdited, func() error {
+			_, err := tx.Exec(ctx, `
+            UPDATE listings
+            SET title = $1, description = $2, address = $3,
+                price = CASE WHEN EXISTS (SELECT 1 FROM auctions WHERE listing_id = $9) THEN price ELSE $4 END, image_url = $5, category_id = $6,
+                image_hash = $7, text_hash = $8
+            WHERE id = $9
+        `,
+				req.Title,
+				req.Description,
+				req.Address,
+				req.Price,
+				req.ImageUrl,
+				nullCategory(req.CategoryId),
+				nullImageHash(req.ImageHash),
+				int64(textFingerprint(req.Title, req.Description)),
+				req.Id,
+			)
+			if err != nil {
+				return err
+			}
+			return saveAttributes(ctx, tx, req.Id, attrs)
+		})
+	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update listing: %v", err)
 	}
 	return &listingpb.Empty{}, nil
 }
 
+// DeleteListing переносит объявление в корзину, откуда его можно восстановить в течение trashRetention
 func (s *server) DeleteListing(ctx context.Context, req *listingpb.DeleteListingRequest) (*listingpb.Empty, error) {
 	var authorID string
-	err := s.sql.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1`, req.Id).Scan(&authorID)
+	err := s.sql.QueryRow(ctx, `SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL`, req.Id).Scan(&authorID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, status.Error(codes.NotFound, "listing not found")
@@ -352,41 +868,93 @@ func (s *server) DeleteListing(ctx context.Context, req *listingpb.DeleteListing
 		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this listing")
 	}
 
-	_, err = s.sql.Exec(ctx, `DELETE FROM listings WHERE id = $1`, req.Id)
+	// Повторное удаление не должно сдвигать deleted_at и продлевать срок хранения в корзине
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		return withHistory(ctx, tx, req.Id, req.UserId, historyDeleted, func() error {
+			tag, err := tx.Exec(ctx, `
+                UPDATE listings SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL
+            `, time.Now(), req.Id)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 0 {
+				return status.Error(codes.NotFound, "listing not found")
+			}
+			return nil
+		})
+	})
 	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete listing: %v", err)
 	}
 	return &listingpb.Empty{}, nil
 }
 
+// AddLike добавляет объявление в избранное пользователя. Повторный лайк ничего не меняет,
+// а вес в трендовом счёте пользователь добавляет объявлению только первым лайком
 func (s *server) AddLike(ctx context.Context, req *listingpb.AddLikeRequest) (*listingpb.Empty, error) {
-	_, err := s.sql.Exec(ctx, `
-        UPDATE listings SET likes = likes + 1 WHERE id = $1
-    `, req.ListingId)
-	if err != nil {
-		return nil, err
-	}
-	_, err = s.sql.Exec(ctx, `
-        UPDATE users SET liked_listings = array_append(liked_listings, $1) WHERE id = $2
-    `, req.ListingId, req.UserId)
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+            UPDATE users SET liked_listings = array_append(liked_listings, $1)
+            WHERE id = $2 AND NOT ($1 = ANY(COALESCE(liked_listings, '{}')))
+        `, req.ListingId, req.UserId)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return nil
+		}
+
+		tag, err = tx.Exec(ctx, `
+            UPDATE listings SET likes = likes + 1 WHERE id = $1 AND deleted_at IS NULL
+        `, req.ListingId)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return status.Error(codes.NotFound, "listing not found")
+		}
+
+		tag, err = tx.Exec(ctx, `
+            INSERT INTO trend_likes (listing_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+        `, req.ListingId, req.UserId)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            UPDATE listings SET trend_score = `+trendUpdate(2)+` WHERE id = $1
+        `, req.ListingId, trendIncrement(likeTrendWeight, time.Now()))
+		return err
+	})
 	if err != nil {
-		return nil, err
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "failed to add like: %v", err)
 	}
 	return &listingpb.Empty{}, nil
 }
 
+// RemoveLike убирает объявление из избранного пользователя, счётчик лайков уменьшается,
+// только если лайк был
 func (s *server) RemoveLike(ctx context.Context, req *listingpb.RemoveLikeRequest) (*listingpb.Empty, error) {
-	_, err := s.sql.Exec(ctx, `
-        UPDATE listings SET likes = likes - 1 WHERE id = $1 AND likes > 0
-    `, req.ListingId)
-	if err != nil {
-		return nil, err
-	}
-	_, err = s.sql.Exec(ctx, `
-        UPDATE users SET liked_listings = array_remove(liked_listings, $1) WHERE id = $2
-    `, req.ListingId, req.UserId)
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+            UPDATE users SET liked_listings = array_remove(liked_listings, $1)
+            WHERE id = $2 AND $1 = ANY(liked_listings)
+        `, req.ListingId, req.UserId)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            UPDATE listings SET likes = likes - 1 WHERE id = $1 AND likes > 0
+        `, req.ListingId)
+		return err
+	})
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.Internal, "failed to remove like: %v", err)
 	}
 	return &listingpb.Empty{}, nil
 }
@@ -404,24 +972,28 @@ func main() {
 		dbUser, dbPass, dbHost, dbPort, dbName)
 
 	ctx := context.Background()
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgxpool.New(ctx, connString)
 	if err != nil {
 		log.Fatalf("unable to connect to database: %v\n", err)
 	}
+	defer conn.Close()
 
-	defer func() {
-		err := conn.Close(ctx)
-		if err != nil {
-			log.Fatalf("failed to close connection: %v\n", err)
-		}
-	}()
-
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(UnaryInterceptor))
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryInterceptor),
+		grpc.StreamInterceptor(StreamInterceptor),
+	)
 	server := &server{
 		sql: conn,
 	}
 	listingpb.RegisterListingServiceServer(grpcServer, server)
 
+	go server.purgeTrash(ctx)
+	go server.refreshSimilarities(ctx)
+	go server.expirePromotions(ctx)
+	go server.expireOffers(ctx)
+	go server.closeAuctions(ctx)
+	go server.failStaleImportJobs(ctx)
+
 	reflection.Register(grpcServer)
 
 	listener, err := net.Listen("tcp", ":"+serverPort)
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// roleModerator роль пользователя, которому доступны инструменты модерации
+const roleModerator = "moderator"
+
+// isModerator сообщает, является ли пользователь модератором
+func (s *server) isModerator(ctx context.Context, userID string) (bool, error) {
+	var role string
+	err := s.sql.QueryRow(ctx, `SELECT role FROM users WHERE id = $1`, userID).Scan(&role)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return false, status.Errorf(codes.Internal, "failed to query user role: %v", err)
+	}
+	return role == roleModerator, nil
+}
+
+// requireModerator проверяет, что пользователь является модератором
+func (s *server) requireModerator(ctx context.Context, userID string) error {
+	moderator, err := s.isModerator(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !moderator {
+		return status.Error(codes.PermissionDenied, "moderator role required")
+	}
+	return nil
+}
@@ -0,0 +1,451 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"log"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Статусы объявлений
+const (
+	listingActive   = "active"
+	listingReserved = "reserved"
+)
+
+// Статусы предложений цены
+const (
+	offerPending   = "pending"
+	offerAccepted  = "accepted"
+	offerRejected  = "rejected"
+	offerCountered = "countered"
+	offerExpired   = "expired"
+)
+
+// Действия получателя предложения
+const (
+	offerActionAccept  = "accept"
+	offerActionReject  = "reject"
+	offerActionCounter = "counter"
+)
+
+// offerTransitions - допустимые переходы статусов предложения, остальные статусы конечные.
+// Принятое предложение истекает, если покупатель не оформил по нему заказ за offerReservation
+var offerTransitions = map[string][]string{
+	offerPending:  {offerAccepted, offerRejected, offerCountered, offerExpired},
+	offerAccepted: {offerExpired},
+}
+
+// offerActionStatus - статус, в который переводит предложение действие получателя
+var offerActionStatus = map[string]string{
+	offerActionAccept:  offerAccepted,
+	offerActionReject:  offerRejected,
+	offerActionCounter: offerCountered,
+}
+
+// offerExpireInterval - как часто просроченные предложения переводятся в expired
+const offerExpireInterval = 5 * time.Minute
+
+// maxOfferAmount совпадает с ограничением цены объявления
+const maxOfferAmount = 100_000_000
+
+const offerColumns = `id, listing_id, buyer_id, seller_id, parent_id, from_seller, amount, message, status, expires_at, created_at,
+    responded_at, reserved_until`
+
+// canTransition проверяет, что предложение можно перевести из статуса from в статус to
+func canTransition(from, to string) bool {
+	return slices.Contains(offerTransitions[from], to)
+}
+
+// offerExpiry проверяет срок действия нового предложения, по умолчанию он равен offerTTL
+func offerExpiry(now time.Time, expiresAt *timestamppb.Timestamp) (time.Time, error) {
+	if expiresAt == nil {
+		return now.Add(offerTTL), nil
+	}
+	t := expiresAt.AsTime()
+	if !t.After(now) {
+		return t, status.Error(codes.InvalidArgument, "expires_at must be in the future")
+	}
+	return t, nil
+}
+
+func validateOfferAmount(amount int64) error {
+	if amount <= 0 || amount > maxOfferAmount {
+		return status.Errorf(codes.InvalidArgument, "amount must be between 1 and %d", maxOfferAmount)
+	}
+	return nil
+}
+
+func scanOffer(row pgx.Row) (*listingpb.Offer, error) {
+	var o listingpb.Offer
+	var parentID, message *string
+	var expiresAt, createdAt time.Time
+	var respondedAt, reservedUntil *time.Time
+	err := row.Scan(&o.Id, &o.ListingId, &o.BuyerId, &o.SellerId, &parentID, &o.FromSeller,
+		&o.Amount, &message, &o.Status, &expiresAt, &createdAt, &respondedAt, &reservedUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID != nil {
+		o.ParentId = *parentID
+	}
+	if message != nil {
+		o.Message = *message
+	}
+	o.ExpiresAt = timestamppb.New(expiresAt)
+	o.CreatedAt = timestamppb.New(createdAt)
+	if respondedAt != nil {
+		o.RespondedAt = timestamppb.New(*respondedAt)
+	}
+	if reservedUntil != nil {
+		o.ReservedUntil = timestamppb.New(*reservedUntil)
+	}
+	return &o, nil
+}
+
+func insertOffer(ctx context.Context, tx pgx.Tx, o *listingpb.Offer, now, expiresAt time.Time) error {
+	var parentID *string
+	if o.ParentId != "" {
+		parentID = &o.ParentId
+	}
+	_, err := tx.Exec(ctx, `
+        INSERT INTO offers (id, listing_id, buyer_id, seller_id, parent_id, from_seller, amount, message, status, expires_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+    `, o.Id, o.ListingId, o.BuyerId, o.SellerId, parentID, o.FromSeller, o.Amount, o.Message, o.Status, expiresAt, now)
+	return err
+}
+
+// MakeOffer создаёт предложение цены покупателя по объявлению
+func (s *server) MakeOffer(ctx context.Context, req *listingpb.MakeOfferRequest) (*listingpb.Offer, error) {
+	if err := validateOfferAmount(req.Amount); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt, err := offerExpiry(now, req.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	offer := &listingpb.Offer{
+		Id:        uuid.New().String(),
+		ListingId: req.ListingId,
+		BuyerId:   req.UserId,
+		Amount:    req.Amount,
+		Message:   req.Message,
+		Status:    offerPending,
+		ExpiresAt: timestamppb.New(expiresAt),
+		CreatedAt: timestamppb.New(now),
+	}
+
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		var listingStatus string
+		err := tx.QueryRow(ctx, `
+            SELECT author_id, status FROM listings WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+        `, req.ListingId).Scan(&offer.SellerId, &listingStatus)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return status.Error(codes.NotFound, "listing not found")
+			}
+			return status.Errorf(codes.Internal, "failed to query listing: %v", err)
+		}
+		if offer.SellerId == req.UserId {
+			return status.Error(codes.PermissionDenied, "you cannot make an offer on your own listing")
+		}
+		if listingStatus != listingActive {
+			return status.Errorf(codes.FailedPrecondition, "listing is %s", listingStatus)
+		}
+		auction, err := isAuction(ctx, tx, req.ListingId)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query auction: %v", err)
+		}
+		if auction {
+			return status.Error(codes.FailedPrecondition, "listing is sold at auction, place a bid instead")
+		}
+
+		var negotiating bool
+		err = tx.QueryRow(ctx, `
+            SELECT EXISTS (
+                SELECT 1 FROM offers
+                WHERE listing_id = $1 AND buyer_id = $2 AND status = $3 AND expires_at > $4
+            )
+        `, req.ListingId, req.UserId, offerPending, now).Scan(&negotiating)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query offers: %v", err)
+		}
+		if negotiating {
+			return status.Error(codes.FailedPrecondition, "you already have a pending offer on this listing")
+		}
+
+		if err := insertOffer(ctx, tx, offer, now, expiresAt); err != nil {
+			return status.Errorf(codes.Internal, "failed to create offer: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return offer, nil
+}
+
+// RespondOffer принимает, отклоняет предложение или делает встречное.
+// Отвечать может только получатель: продавец на предложение покупателя и покупатель на встречное предложение продавца.
+// Принятое предложение резервирует объявление на offerReservation, остальные ожидающие предложения по нему отклоняются
+func (s *server) RespondOffer(ctx context.Context, req *listingpb.RespondOfferRequest) (*listingpb.Offer, error) {
+	next, ok := offerActionStatus[req.Action]
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown action %q", req.Action)
+	}
+
+	now := time.Now()
+	var counterExpiry time.Time
+	if req.Action == offerActionCounter {
+		if err := validateOfferAmount(req.Amount); err != nil {
+			return nil, err
+		}
+		var err error
+		if counterExpiry, err = offerExpiry(now, req.ExpiresAt); err != nil {
+			return nil, err
+		}
+	}
+
+	var result *listingpb.Offer
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		// Объявление блокируется раньше предложения, чтобы параллельные ответы по нему выполнялись по очереди
+		var listingStatus string
+		err := tx.QueryRow(ctx, `
+            SELECT l.status FROM listings l
+            JOIN offers o ON o.listing_id = l.id
+            WHERE o.id = $1 AND l.deleted_at IS NULL
+            FOR UPDATE OF l
+        `, req.OfferId).Scan(&listingStatus)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return status.Error(codes.NotFound, "offer not found")
+			}
+			return status.Errorf(codes.Internal, "failed to query listing: %v", err)
+		}
+
+		offer, err := scanOffer(tx.QueryRow(ctx, `SELECT `+offerColumns+` FROM offers WHERE id = $1 FOR UPDATE`, req.OfferId))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query offer: %v", err)
+		}
+
+		recipient := offer.SellerId
+		if offer.FromSeller {
+			recipient = offer.BuyerId
+		}
+		if req.UserId != recipient {
+			if req.UserId != offer.SellerId && req.UserId != offer.BuyerId {
+				return status.Error(codes.NotFound, "offer not found")
+			}
+			return status.Error(codes.PermissionDenied, "only the recipient can respond to the offer")
+		}
+
+		if offer.Status == offerPending && !offer.ExpiresAt.AsTime().After(now) {
+			offer.Status = offerExpired
+		}
+		if !canTransition(offer.Status, next) {
+			return status.Errorf(codes.FailedPrecondition, "offer is %s", offer.Status)
+		}
+		if next == offerAccepted && listingStatus != listingActive {
+			return status.Errorf(codes.FailedPrecondition, "listing is %s", listingStatus)
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE offers SET status = $1, responded_at = $2 WHERE id = $3`, next, now, offer.Id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update offer: %v", err)
+		}
+		offer.Status = next
+		offer.RespondedAt = timestamppb.New(now)
+		result = offer
+
+		switch next {
+		case offerAccepted:
+			reservedUntil := now.Add(offerReservation)
+			_, err = tx.Exec(ctx, `UPDATE offers SET reserved_until = $1 WHERE id = $2`, reservedUntil, offer.Id)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to update offer: %v", err)
+			}
+			offer.ReservedUntil = timestamppb.New(reservedUntil)
+
+			err = withHistory(ctx, tx, offer.ListingId, req.UserId, historyOfferAccepted, func() error {
+				_, err := tx.Exec(ctx, `UPDATE listings SET status = $1 WHERE id = $2`, listingReserved, offer.ListingId)
+				return err
+			})
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to reserve listing: %v", err)
+			}
+			_, err = tx.Exec(ctx, `
+                UPDATE offers SET status = $1, responded_at = $2
+                WHERE listing_id = $3 AND status = $4
+            `, offerRejected, now, offer.ListingId, offerPending)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to reject other offers: %v", err)
+			}
+		case offerCountered:
+			result = &listingpb.Offer{
+				Id:         uuid.New().String(),
+				ListingId:  offer.ListingId,
+				BuyerId:    offer.BuyerId,
+				SellerId:   offer.SellerId,
+				ParentId:   offer.Id,
+				FromSeller: !offer.FromSeller,
+				Amount:     req.Amount,
+				Message:    req.Message,
+				Status:     offerPending,
+				ExpiresAt:  timestamppb.New(counterExpiry),
+				CreatedAt:  timestamppb.New(now),
+			}
+			if err := insertOffer(ctx, tx, result, now, counterExpiry); err != nil {
+				return status.Errorf(codes.Internal, "failed to create counter offer: %v", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetOffers возвращает предложения, в которых пользователь покупатель или продавец, начиная с последних
+func (s *server) GetOffers(ctx context.Context, req *listingpb.GetOffersRequest) (*listingpb.GetOffersResponse, error) {
+	var listingID *string
+	if req.ListingId != "" {
+		listingID = &req.ListingId
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT `+offerColumns+` FROM offers
+        WHERE (buyer_id = $1 OR seller_id = $1) AND ($2::uuid IS NULL OR listing_id = $2)
+        ORDER BY created_at DESC
+    `, req.UserId, listingID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetOffersResponse{}
+	for rows.Next() {
+		offer, err := scanOffer(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.Offers = append(resp.Offers, offer)
+	}
+	return resp, nil
+}
+
+// expireOffers периодически переводит просроченные ожидающие предложения в статус expired
+// и снимает резерв с объявлений по принятым предложениям, по которым не оформили заказ
+func (s *server) expireOffers(ctx context.Context) {
+	ticker := time.NewTicker(offerExpireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tag, err := s.sql.Exec(ctx, `
+                UPDATE offers SET status = $1 WHERE status = $2 AND expires_at <= $3
+            `, offerExpired, offerPending, time.Now())
+			if err != nil {
+				log.Printf("failed to expire offers: %v", err)
+				continue
+			}
+			if tag.RowsAffected() > 0 {
+				log.Printf("expired %d offers", tag.RowsAffected())
+			}
+
+			released, err := s.releaseReservations(ctx, time.Now())
+			if err != nil {
+				log.Printf("failed to release reservations: %v", err)
+			}
+			if released > 0 {
+				log.Printf("released %d reserved listings", released)
+			}
+		}
+	}
+}
+
+// releaseReservations переводит в expired принятые предложения, по которым покупатель не оформил заказ
+// до reserved_until, и возвращает их объявления в продажу. Возвращает количество освобождённых объявлений
+func (s *server) releaseReservations(ctx context.Context, now time.Time) (int, error) {
+	rows, err := s.sql.Query(ctx, `
+        SELECT o.id FROM offers o
+        WHERE o.status = $1 AND o.reserved_until <= $2
+            AND NOT EXISTS (SELECT 1 FROM orders WHERE offer_id = o.id)
+    `, offerAccepted, now)
+	if err != nil {
+		return 0, err
+	}
+	offerIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, offerID := range offerIDs {
+		var ok bool
+		err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+			var err error
+			ok, err = releaseReservation(ctx, tx, offerID, now)
+			return err
+		})
+		if err != nil {
+			return released, err
+		}
+		if ok {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// releaseReservation снимает резерв по одному предложению. Объявление блокируется раньше предложения,
+// как в RespondOffer и при оформлении заказа, поэтому заказ, оформленный параллельно, будет виден
+// в проверке и резерв не снимется
+func releaseReservation(ctx context.Context, tx pgx.Tx, offerID string, now time.Time) (bool, error) {
+	var listingID, listingStatus string
+	err := tx.QueryRow(ctx, `
+        SELECT l.id, l.status FROM listings l
+        JOIN offers o ON o.listing_id = l.id
+        WHERE o.id = $1
+        FOR UPDATE OF l
+    `, offerID).Scan(&listingID, &listingStatus)
+	if err != nil {
+		return false, err
+	}
+
+	var lapsed bool
+	err = tx.QueryRow(ctx, `
+        SELECT status = $2 AND reserved_until <= $3 AND NOT EXISTS (SELECT 1 FROM orders WHERE offer_id = $1)
+        FROM offers WHERE id = $1 FOR UPDATE
+    `, offerID, offerAccepted, now).Scan(&lapsed)
+	if err != nil || !lapsed {
+		return false, err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE offers SET status = $1 WHERE id = $2`, offerExpired, offerID); err != nil {
+		return false, err
+	}
+	if listingStatus != listingReserved {
+		return false, nil
+	}
+	err = withHistory(ctx, tx, listingID, "", historyReservationExpired, func() error {
+		_, err := tx.Exec(ctx, `UPDATE listings SET status = $1 WHERE id = $2`, listingActive, listingID)
+		return err
+	})
+	return err == nil, err
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"listingService/listingpb"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Типы продвижения: premium занимает рекламные места раньше top
+const (
+	promotionTop     = "top"
+	promotionPremium = "premium"
+)
+
+// Статусы продвижения
+const (
+	promotionActive  = "active"
+	promotionExpired = "expired"
+)
+
+// maxPromotionCandidates ограничивает количество продвигаемых объявлений, среди которых выбираются показы страницы
+const maxPromotionCandidates = 100
+
+// parsePromotionSlots разбирает позиции рекламных мест на странице, например "0,5"
+func parsePromotionSlots(value string) ([]int, error) {
+	var slots []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		slot, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		if slot < 0 {
+			return nil, fmt.Errorf("negative slot %d", slot)
+		}
+		slots = append(slots, slot)
+	}
+	slices.Sort(slots)
+	return slices.Compact(slots), nil
+}
+
+// CreatePromotion создаёт продвижение объявления его автором
+func (s *server) CreatePromotion(ctx context.Context, req *listingpb.CreatePromotionRequest) (*listingpb.Promotion, error) {
+	if req.Type != promotionTop && req.Type != promotionPremium {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown promotion type %q", req.Type)
+	}
+
+	now := time.Now()
+	startsAt := now
+	if req.StartsAt != nil {
+		startsAt = req.StartsAt.AsTime()
+	}
+	if req.EndsAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "ends_at is required")
+	}
+	endsAt := req.EndsAt.AsTime()
+	if !endsAt.After(startsAt) || !endsAt.After(now) {
+		return nil, status.Error(codes.InvalidArgument, "ends_at must be after starts_at and in the future")
+	}
+
+	var authorID string
+	err := s.sql.QueryRow(ctx, `
+        SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL
+    `, req.ListingId).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "listing not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+	if authorID != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this listing")
+	}
+
+	promotion := &listingpb.Promotion{
+		Id:        uuid.New().String(),
+		ListingId: req.ListingId,
+		Type:      req.Type,
+		Status:    promotionActive,
+		StartsAt:  timestamppb.New(startsAt),
+		EndsAt:    timestamppb.New(endsAt),
+		CreatedAt: timestamppb.New(now),
+	}
+
+	_, err = s.sql.Exec(ctx, `
+        INSERT INTO promotions (id, listing_id, type, status, starts_at, ends_at, created_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7)
+    `, promotion.Id, req.ListingId, req.Type, promotionActive, startsAt, endsAt, now)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create promotion: %v", err)
+	}
+
+	return promotion, nil
+}
+
+// GetPromotions возвращает продвижения объявлений пользователя, начиная с последних
+func (s *server) GetPromotions(ctx context.Context, req *listingpb.GetPromotionsRequest) (*listingpb.GetPromotionsResponse, error) {
+	rows, err := s.sql.Query(ctx, `
+        SELECT p.id, p.listing_id, p.type, p.status, p.starts_at, p.ends_at, p.created_at
+        FROM promotions p
+        JOIN listings l ON l.id = p.listing_id
+        WHERE l.author_id = $1 AND l.deleted_at IS NULL
+        ORDER BY p.created_at DESC
+    `, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetPromotionsResponse{}
+	for rows.Next() {
+		var p listingpb.Promotion
+		var startsAt, endsAt, createdAt time.Time
+		if err := rows.Scan(&p.Id, &p.ListingId, &p.Type, &p.Status, &startsAt, &endsAt, &createdAt); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		p.StartsAt = timestamppb.New(startsAt)
+		p.EndsAt = timestamppb.New(endsAt)
+		p.CreatedAt = timestamppb.New(createdAt)
+		resp.Promotions = append(resp.Promotions, &p)
+	}
+
+	return resp, nil
+}
+
+// injectPromotions встраивает продвигаемые объявления, подходящие под фильтр, в рекламные места страницы.
+// Показы чередуются между страницами, каждое объявление показывается на странице не больше одного раза:
+// если оно попало в обычную выдачу, оттуда оно убирается
+func (s *server) injectPromotions(ctx context.Context, req *listingpb.GetAllListingsRequest, where *listingWhere, listings []*listingpb.Listing) ([]*listingpb.Listing, error) {
+	if len(promotionSlots) == 0 {
+		return listings, nil
+	}
+
+	now := time.Now()
+	args := append([]interface{}{}, where.args...)
+	args = append(args, now, now.Truncate(time.Hour).Format(time.RFC3339), maxPromotionCandidates)
+
+	rows, err := s.sql.Query(ctx, listingSelect+fmt.Sprintf(`
+        JOIN (
+            SELECT listing_id, bool_or(type = '%s') AS premium
+            FROM promotions
+            WHERE status = '%s' AND starts_at <= $%[3]d AND ends_at > $%[3]d
+            GROUP BY listing_id
+        ) p ON p.listing_id = l.id
+        WHERE %[4]s
+        ORDER BY p.premium DESC, md5(l.id::text || $%[5]d)
+        LIMIT $%[6]d
+    `, promotionPremium, promotionActive, where.argIdx, strings.Join(where.conditions, " AND "), where.argIdx+1, where.argIdx+2), args...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query promotions: %v", err)
+	}
+
+	candidates, err := scanListings(rows, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return listings, nil
+	}
+
+	count := min(len(promotionSlots), len(candidates))
+	start := int((req.Page-1)*int64(len(promotionSlots))) % len(candidates)
+
+	promoted := make(map[string]bool, count)
+	picked := make([]*listingpb.Listing, 0, count)
+	for i := 0; i < count; i++ {
+		l := candidates[(start+i)%len(candidates)]
+		l.Promoted = true
+		l.IsLiked = where.likedMap[l.Id]
+		promoted[l.Id] = true
+		picked = append(picked, l)
+	}
+
+	result := make([]*listingpb.Listing, 0, len(listings)+count)
+	for _, l := range listings {
+		if !promoted[l.Id] {
+			result = append(result, l)
+		}
+	}
+
+	for i, l := range picked {
+		pos := min(promotionSlots[i], len(result))
+		result = slices.Insert(result, pos, l)
+	}
+	return result, nil
+}
+
+// expirePromotions периодически переводит закончившиеся продвижения в статус expired
+func (s *server) expirePromotions(ctx context.Context) {
+	ticker := time.NewTicker(promotionExpireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tag, err := s.sql.Exec(ctx, `
+                UPDATE promotions SET status = $1 WHERE status = $2 AND ends_at <= $3
+            `, promotionExpired, promotionActive, time.Now())
+			if err != nil {
+				log.Printf("failed to expire promotions: %v", err)
+				continue
+			}
+			if tag.RowsAffected() > 0 {
+				log.Printf("expired %d promotions", tag.RowsAffected())
+			}
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxQuestionText - максимальная длина вопроса и ответа в символах
+const maxQuestionText = 1000
+
+const questionColumns = `q.id, q.listing_id, q.asker_id, u.username, q.text, q.answer, q.answered_at,
+    q.hidden_at IS NOT NULL, q.created_at`
+
+func scanQuestion(row pgx.Row) (*listingpb.Question, error) {
+	var q listingpb.Question
+	var askerLogin, answer *string
+	var answeredAt *time.Time
+	var createdAt time.Time
+	err := row.Scan(&q.Id, &q.ListingId, &q.AskerId, &askerLogin, &q.Text, &answer, &answeredAt, &q.Hidden, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+	if askerLogin != nil {
+		q.AskerLogin = *askerLogin
+	}
+	if answer != nil {
+		q.Answer = *answer
+	}
+	if answeredAt != nil {
+		q.AnsweredAt = timestamppb.New(*answeredAt)
+	}
+	q.CreatedAt = timestamppb.New(createdAt)
+	return &q, nil
+}
+
+// questionText проверяет и нормализует текст вопроса или ответа
+func questionText(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxQuestionText {
+		return "", status.Errorf(codes.InvalidArgument, "text must be between 1 and %d characters", maxQuestionText)
+	}
+	return text, nil
+}
+
+// getQuestion возвращает вопрос вместе с автором объявления
+func getQuestion(ctx context.Context, q querier, questionID string) (*listingpb.Question, string, error) {
+	var authorID string
+	err := q.QueryRow(ctx, `
+        SELECT l.author_id FROM listing_questions q
+        JOIN listings l ON l.id = q.listing_id
+        WHERE q.id = $1 AND l.deleted_at IS NULL
+    `, questionID).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", status.Error(codes.NotFound, "question not found")
+		}
+		return nil, "", status.Errorf(codes.Internal, "failed to query question: %v", err)
+	}
+
+	question, err := scanQuestion(q.QueryRow(ctx, `
+        SELECT `+questionColumns+`
+        FROM listing_questions q
+        LEFT JOIN users u ON u.id = q.asker_id
+        WHERE q.id = $1
+    `, questionID))
+	if err != nil {
+		return nil, "", status.Errorf(codes.Internal, "failed to query question: %v", err)
+	}
+	return question, authorID, nil
+}
+
+// listingAuthor возвращает автора объявления, которое не удалено
+func listingAuthor(ctx context.Context, q querier, listingID string) (string, error) {
+	var authorID string
+	err := q.QueryRow(ctx, `
+        SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL
+    `, listingID).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", status.Error(codes.NotFound, "listing not found")
+		}
+		return "", status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+	return authorID, nil
+}
+
+// AskQuestion задаёт публичный вопрос продавцу. Вопрос виден всем после ответа
+func (s *server) AskQuestion(ctx context.Context, req *listingpb.AskQuestionRequest) (*listingpb.Question, error) {
+	if _, err := uuid.Parse(req.ListingId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid listing_id: %v", err)
+	}
+	text, err := questionText(req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	authorID, err := listingAuthor(ctx, s.sql, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+	if authorID == req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "you cannot ask questions on your own listing")
+	}
+
+	id := uuid.New().String()
+	_, err = s.sql.Exec(ctx, `
+        INSERT INTO listing_questions (id, listing_id, asker_id, text, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `, id, req.ListingId, req.UserId, text, time.Now())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create question: %v", err)
+	}
+
+	question, _, err := getQuestion(ctx, s.sql, id)
+	return question, err
+}
+
+// AnswerQuestion отвечает на вопрос, повторный ответ заменяет прежний. Отвечать может только автор объявления
+func (s *server) AnswerQuestion(ctx context.Context, req *listingpb.AnswerQuestionRequest) (*listingpb.Question, error) {
+	if _, err := uuid.Parse(req.QuestionId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid question_id: %v", err)
+	}
+	text, err := questionText(req.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	question, authorID, err := getQuestion(ctx, s.sql, req.QuestionId)
+	if err != nil {
+		return nil, err
+	}
+	// Скрытый модератором вопрос автору объявления не виден
+	if question.Hidden {
+		return nil, status.Error(codes.NotFound, "question not found")
+	}
+	if authorID != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "only the listing author can answer questions")
+	}
+
+	_, err = s.sql.Exec(ctx, `
+        UPDATE listing_questions SET answer = $1, answered_at = $2 WHERE id = $3
+    `, text, time.Now(), req.QuestionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to answer question: %v", err)
+	}
+
+	question, _, err = getQuestion(ctx, s.sql, req.QuestionId)
+	return question, err
+}
+
+// GetQuestions возвращает вопросы по объявлению, начиная с новых. Всем видны вопросы с ответом,
+// автору объявления - ещё и вопросы без ответа, спросившему - его собственные,
+// модератору - все, включая скрытые
+func (s *server) GetQuestions(ctx context.Context, req *listingpb.GetQuestionsRequest) (*listingpb.GetQuestionsResponse, error) {
+	if _, err := uuid.Parse(req.ListingId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid listing_id: %v", err)
+	}
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	authorID, err := listingAuthor(ctx, s.sql, req.ListingId)
+	if err != nil {
+		return nil, err
+	}
+	moderator, err := s.isModerator(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+
+	const visible = `q.listing_id = $1 AND ($2 OR (q.hidden_at IS NULL AND
+        (q.answered_at IS NOT NULL OR $3 OR q.asker_id = $4)))`
+	args := []interface{}{req.ListingId, moderator, authorID == req.UserId, req.UserId}
+
+	var totalItems int
+	err = s.sql.QueryRow(ctx, `SELECT COUNT(*) FROM listing_questions q WHERE `+visible, args...).Scan(&totalItems)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count questions: %v", err)
+	}
+
+	totalPages := int64((totalItems + limit - 1) / limit)
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if req.Page > totalPages {
+		req.Page = totalPages
+	}
+	offset := (req.Page - 1) * int64(limit)
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT `+questionColumns+`
+        FROM listing_questions q
+        LEFT JOIN users u ON u.id = q.asker_id
+        WHERE `+visible+`
+        ORDER BY q.created_at DESC
+        LIMIT $5 OFFSET $6
+    `, append(args, limit, offset)...)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &listingpb.GetQuestionsResponse{TotalPages: totalPages, CurrentPage: req.Page}
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.Questions = append(resp.Questions, question)
+	}
+	return resp, nil
+}
+
+// HideQuestion скрывает вопрос или возвращает его в выдачу, доступно только модератору
+func (s *server) HideQuestion(ctx context.Context, req *listingpb.HideQuestionRequest) (*listingpb.Question, error) {
+	if _, err := uuid.Parse(req.QuestionId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid question_id: %v", err)
+	}
+	if err := s.requireModerator(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	var hiddenAt *time.Time
+	var hiddenBy *string
+	if req.Hidden {
+		now := time.Now()
+		hiddenAt, hiddenBy = &now, &req.UserId
+	}
+
+	tag, err := s.sql.Exec(ctx, `
+        UPDATE listing_questions SET hidden_at = $1, hidden_by = $2 WHERE id = $3
+    `, hiddenAt, hiddenBy, req.QuestionId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hide question: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil, status.Error(codes.NotFound, "question not found")
+	}
+
+	question, _, err := getQuestion(ctx, s.sql, req.QuestionId)
+	return question, err
+}
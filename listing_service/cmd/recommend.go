@@ -0,0 +1,405 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"log"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Веса составляющих сходства объявлений
+const (
+	likeWeight  = 0.7 // совместные лайки
+	titleWeight = 0.3 // сходство заголовков
+)
+
+const (
+	maxRecommendations = 50   // максимальное количество объявлений в ответе
+	maxUserLikes       = 200  // сколько последних лайков пользователя учитывается при подсчёте совместных лайков
+	maxTokenListings   = 1000 // слова, встречающиеся в большем количестве заголовков, не учитываются
+	minTokenLength     = 3    // более короткие слова не учитываются
+)
+
+// pair пара объявлений, a < b
+type pair struct {
+	a, b int
+}
+
+// GetSimilarListings возвращает объявления, похожие на данное, по заранее рассчитанной таблице сходства
+func (s *server) GetSimilarListings(ctx context.Context, req *listingpb.GetSimilarListingsRequest) (*listingpb.GetAllListingsResponse, error) {
+	if _, err := uuid.Parse(req.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+
+	var exists bool
+	err := s.sql.QueryRow(ctx, `
+        SELECT EXISTS (SELECT 1 FROM listings WHERE id = $1 AND deleted_at IS NULL)
+    `, req.Id).Scan(&exists)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+	if !exists {
+		return nil, status.Error(codes.NotFound, "listing not found")
+	}
+
+	rows, err := s.sql.Query(ctx, listingSelect+`
+        JOIN listing_similarities s ON s.similar_id = l.id
+        WHERE s.listing_id = $1 AND l.deleted_at IS NULL AND l.status = $3
+        ORDER BY s.score DESC
+        LIMIT $2
+    `, req.Id, recommendationLimit(req.Limit), listingActive)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	return s.recommendationResponse(ctx, rows, req.UserId)
+}
+
+// GetRecommendations возвращает ленту «для вас»: объявления, похожие на понравившиеся пользователю.
+// Пока у пользователя нет лайков, лента состоит из популярных объявлений
+func (s *server) GetRecommendations(ctx context.Context, req *listingpb.GetRecommendationsRequest) (*listingpb.GetAllListingsResponse, error) {
+	userID, err := uuid.Parse(req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+
+	var liked []uuid.UUID
+	err = s.sql.QueryRow(ctx, `SELECT liked_listings FROM users WHERE id = $1`, userID).Scan(&liked)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Errorf(codes.Internal, "failed to query liked listings: %v", err)
+	}
+
+	var rows pgx.Rows
+	if len(liked) == 0 {
+		rows, err = s.sql.Query(ctx, listingSelect+`
+            WHERE l.deleted_at IS NULL AND l.status = $3 AND l.author_id <> $1
+            ORDER BY l.likes DESC, l.created_at DESC
+            LIMIT $2
+        `, userID, recommendationLimit(req.Limit), listingActive)
+	} else {
+		rows, err = s.sql.Query(ctx, listingSelect+`
+            JOIN (
+                SELECT similar_id, SUM(score) AS score
+                FROM listing_similarities
+                WHERE listing_id = ANY($1) AND NOT (similar_id = ANY($1))
+                GROUP BY similar_id
+            ) s ON s.similar_id = l.id
+            WHERE l.deleted_at IS NULL AND l.status = $4 AND l.author_id <> $2
+            ORDER BY s.score DESC
+            LIMIT $3
+        `, liked, userID, recommendationLimit(req.Limit), listingActive)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+
+	return s.recommendationResponse(ctx, rows, req.UserId)
+}
+
+// listingSelect выбирает поля объявления в порядке, ожидаемом scanListings
+const listingSelect = `
+    SELECT
+        l.id, l.title, l.description, l.address, l.price,
+        l.author_id, ` + authorNameColumn + ` as author_username,
+        l.created_at, l.image_url, l.likes, l.category_id, l.status
+    FROM listings l
+    LEFT JOIN users u ON l.author_id = u.id
+`
+
+// recommendationLimit ограничивает размер ответа рекомендаций
+func recommendationLimit(requested int64) int64 {
+	if requested <= 0 || requested > maxRecommendations {
+		return int64(limit)
+	}
+	return requested
+}
+
+// recommendationResponse собирает объявления из результата listingSelect
+// и отмечает лайкнутые и собственные объявления пользователя
+func (s *server) recommendationResponse(ctx context.Context, rows pgx.Rows, userID string) (*listingpb.GetAllListingsResponse, error) {
+	listings, err := scanListings(rows, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(userID); err == nil {
+		var liked []uuid.UUID
+		err := s.sql.QueryRow(ctx, `SELECT liked_listings FROM users WHERE id = $1`, userID).Scan(&liked)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Errorf(codes.Internal, "failed to query liked listings: %v", err)
+		}
+
+		likedMap := make(map[string]bool, len(liked))
+		for _, id := range liked {
+			likedMap[id.String()] = true
+		}
+		for _, l := range listings {
+			l.IsLiked = likedMap[l.Id]
+		}
+	}
+
+	if err := s.loadAttributes(ctx, listings); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to load attributes: %v", err)
+	}
+
+	return &listingpb.GetAllListingsResponse{
+		Listings:    listings,
+		TotalPages:  1,
+		CurrentPage: 1,
+	}, nil
+}
+
+// scanListings читает объявления из результата listingSelect и закрывает rows
+func scanListings(rows pgx.Rows, userID string) ([]*listingpb.Listing, error) {
+	defer rows.Close()
+
+	listings := []*listingpb.Listing{}
+	for rows.Next() {
+		var l listingpb.Listing
+		var createdAt time.Time
+		var authorUsername *string
+		var categoryID *int64
+
+		if err := rows.Scan(
+			&l.Id,
+			&l.Title,
+			&l.Description,
+			&l.Address,
+			&l.Price,
+			&l.AuthorId,
+			&authorUsername,
+			&createdAt,
+			&l.ImageUrl,
+			&l.Likes,
+			&categoryID,
+			&l.Status,
+		); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+
+		if authorUsername != nil {
+			l.AuthorLogin = *authorUsername
+		}
+		if categoryID != nil {
+			l.CategoryId = *categoryID
+		}
+		l.CreatedAt = timestamppb.New(createdAt)
+		l.IsYours = userID != "" && l.AuthorId == userID
+
+		listings = append(listings, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	return listings, nil
+}
+
+// refreshSimilarities периодически пересчитывает таблицу сходства объявлений
+func (s *server) refreshSimilarities(ctx context.Context) {
+	ticker := time.NewTicker(similarityInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.computeSimilarities(ctx); err != nil {
+			log.Printf("failed to refresh listing similarities: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// computeSimilarities считает сходство каждой пары объявлений как взвешенную сумму
+// косинусной меры по совместным лайкам и коэффициента Жаккара по словам заголовков,
+// и сохраняет для каждого объявления similarityTop самых похожих
+func (s *server) computeSimilarities(ctx context.Context) error {
+	started := time.Now()
+
+	rows, err := s.sql.Query(ctx, `SELECT id, title FROM listings WHERE deleted_at IS NULL`)
+	if err != nil {
+		return err
+	}
+
+	var ids []uuid.UUID
+	var titles [][]string
+	index := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var id uuid.UUID
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			rows.Close()
+			return err
+		}
+		index[id] = len(ids)
+		ids = append(ids, id)
+		titles = append(titles, titleTokens(title))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	likeCounts, cooccurrence, err := s.likeCooccurrence(ctx, index)
+	if err != nil {
+		return err
+	}
+
+	scores := make(map[pair]float64)
+	for p, common := range cooccurrence {
+		scores[p] += likeWeight * float64(common) / math.Sqrt(float64(likeCounts[p.a]*likeCounts[p.b]))
+	}
+	for p, jaccard := range titleSimilarity(titles) {
+		scores[p] += titleWeight * jaccard
+	}
+
+	neighbours := make([][]similarItem, len(ids))
+	for p, score := range scores {
+		neighbours[p.a] = append(neighbours[p.a], similarItem{index: p.b, score: score})
+		neighbours[p.b] = append(neighbours[p.b], similarItem{index: p.a, score: score})
+	}
+
+	var copyRows [][]interface{}
+	for i, items := range neighbours {
+		sort.Slice(items, func(x, y int) bool { return items[x].score > items[y].score })
+		if len(items) > similarityTop {
+			items = items[:similarityTop]
+		}
+		for _, item := range items {
+			copyRows = append(copyRows, []interface{}{ids[i], ids[item.index], item.score})
+		}
+	}
+
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM listing_similarities`); err != nil {
+			return err
+		}
+		_, err := tx.CopyFrom(ctx,
+			pgx.Identifier{"listing_similarities"},
+			[]string{"listing_id", "similar_id", "score"},
+			pgx.CopyFromRows(copyRows),
+		)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("listing similarities refreshed: %d listings, %d pairs in %s", len(ids), len(copyRows), time.Since(started))
+	return nil
+}
+
+// similarItem похожее объявление с его оценкой сходства
+type similarItem struct {
+	index int
+	score float64
+}
+
+// likeCooccurrence считает количество лайков каждого объявления и количество пользователей,
+// лайкнувших оба объявления пары. В количество лайков входят все пользователи,
+// иначе косинусная мера завышается для объявлений с одиночными лайками
+func (s *server) likeCooccurrence(ctx context.Context, index map[uuid.UUID]int) (map[int]int, map[pair]int, error) {
+	rows, err := s.sql.Query(ctx, `SELECT liked_listings FROM users WHERE cardinality(liked_listings) > 0`)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	likeCounts := make(map[int]int)
+	cooccurrence := make(map[pair]int)
+	for rows.Next() {
+		var liked []uuid.UUID
+		if err := rows.Scan(&liked); err != nil {
+			return nil, nil, err
+		}
+		if len(liked) > maxUserLikes {
+			liked = liked[len(liked)-maxUserLikes:]
+		}
+
+		seen := make(map[int]bool, len(liked))
+		items := make([]int, 0, len(liked))
+		for _, id := range liked {
+			i, ok := index[id]
+			if !ok || seen[i] {
+				continue
+			}
+			seen[i] = true
+			items = append(items, i)
+			likeCounts[i]++
+		}
+
+		for x := 0; x < len(items); x++ {
+			for y := x + 1; y < len(items); y++ {
+				cooccurrence[newPair(items[x], items[y])]++
+			}
+		}
+	}
+	return likeCounts, cooccurrence, rows.Err()
+}
+
+// titleSimilarity считает коэффициент Жаккара по словам заголовков для пар с общими словами
+func titleSimilarity(titles [][]string) map[pair]float64 {
+	postings := make(map[string][]int)
+	for i, tokens := range titles {
+		for _, token := range tokens {
+			postings[token] = append(postings[token], i)
+		}
+	}
+
+	common := make(map[pair]int)
+	for _, items := range postings {
+		if len(items) > maxTokenListings {
+			continue
+		}
+		for x := 0; x < len(items); x++ {
+			for y := x + 1; y < len(items); y++ {
+				common[newPair(items[x], items[y])]++
+			}
+		}
+	}
+
+	similarity := make(map[pair]float64, len(common))
+	for p, n := range common {
+		union := len(titles[p.a]) + len(titles[p.b]) - n
+		similarity[p] = float64(n) / float64(union)
+	}
+	return similarity
+}
+
+// titleTokens разбивает заголовок на уникальные слова в нижнем регистре
+func titleTokens(title string) []string {
+	words := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	seen := make(map[string]bool, len(words))
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if len([]rune(word)) < minTokenLength || seen[word] {
+			continue
+		}
+		seen[word] = true
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+func newPair(a, b int) pair {
+	if a > b {
+		a, b = b, a
+	}
+	return pair{a: a, b: b}
+}
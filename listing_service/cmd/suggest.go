@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"listingService/listingpb"
+	"log"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Источники подсказок поиска
+const (
+	suggestionQuery   = "query"
+	suggestionListing = "listing"
+)
+
+const (
+	maxSuggestions   = 20  // наибольшее число подсказок в ответе
+	maxSearchQuery   = 100 // запросы длиннее обрезаются до этого числа символов
+	minSuggestPrefix = 2   // по одной букве подсказки бесполезны и дороги
+)
+
+// Вес одного поиска в популярности запроса, затухает так же, как трендовый счёт объявлений
+const searchTrendWeight = 1.0
+
+// normalizeQuery приводит поисковый запрос к виду, в котором он хранится в журнале и сравнивается с названиями
+func normalizeQuery(query string) string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), " "))
+	if utf8.RuneCountInString(query) > maxSearchQuery {
+		query = strings.TrimSpace(string([]rune(query)[:maxSearchQuery]))
+	}
+	return query
+}
+
+// escapeLike экранирует спецсимволы шаблона LIKE
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// logSearchQuery учитывает поиск в журнале запросов вместе с числом разных искавших пользователей.
+// Ошибка журнала не должна ломать сам поиск, поэтому она только пишется в лог
+func (s *server) logSearchQuery(ctx context.Context, query, userID string) {
+	query = normalizeQuery(query)
+	if utf8.RuneCountInString(query) < minSuggestPrefix {
+		return
+	}
+
+	now := time.Now()
+	increment := trendIncrement(searchTrendWeight, now)
+
+	tag, err := s.sql.Exec(ctx, `
+        UPDATE search_queries
+        SET trend_score = `+trendUpdate(2)+`, searches = searches + 1, last_searched_at = $3
+        WHERE query = $1
+    `, query, increment, now)
+	if err == nil && tag.RowsAffected() == 0 {
+		_, err = s.sql.Exec(ctx, `
+            INSERT INTO search_queries (query, trend_score, last_searched_at)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (query) DO NOTHING
+        `, query, increment, now)
+	}
+	if err == nil && userID != "" && userID != uuid.Nil.String() {
+		tag, err = s.sql.Exec(ctx, `
+            INSERT INTO search_query_users (query, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING
+        `, query, userID)
+		if err == nil && tag.RowsAffected() > 0 {
+			_, err = s.sql.Exec(ctx, `UPDATE search_queries SET users = users + 1 WHERE query = $1`, query)
+		}
+	}
+	if err != nil {
+		log.Printf("failed to log search query: %v", err)
+	}
+}
+
+// suggestQueries возвращает популярные прошлые запросы, начинающиеся с префикса. Запрос становится
+// подсказкой, только когда его искали не меньше suggestMinSearches раз и не меньше suggestMinUsers
+// разных пользователей: запрос одного человека может содержать его личные данные
+func (s *server) suggestQueries(ctx context.Context, prefix string, n int) ([]*listingpb.Suggestion, error) {
+	rows, err := s.sql.Query(ctx, `
+        SELECT query FROM search_queries
+        WHERE query LIKE $1 AND searches >= $3 AND users >= $4
+        ORDER BY trend_score DESC
+        LIMIT $2
+    `, escapeLike(prefix)+"%", n, suggestMinSearches, suggestMinUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []*listingpb.Suggestion
+	for rows.Next() {
+		var query string
+		if err := rows.Scan(&query); err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, &listingpb.Suggestion{Text: query, Kind: suggestionQuery})
+	}
+	return suggestions, rows.Err()
+}
+
+// suggestTitles возвращает названия активных объявлений, содержащие префикс. Сначала идут названия,
+// которые с него начинаются, затем более похожие по триграммам и более популярные
+func (s *server) suggestTitles(ctx context.Context, prefix string, n int) ([]*listingpb.Suggestion, error) {
+	pattern := escapeLike(prefix)
+	contains := "%" + pattern + "%"
+	// Триграммный индекс работает с трёх символов, более короткий префикс ищется только в начале названия
+	if utf8.RuneCountInString(prefix) < 3 {
+		contains = pattern + "%"
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT id, title FROM listings
+        WHERE deleted_at IS NULL AND status = $5 AND lower(title) LIKE $1
+        ORDER BY lower(title) LIKE $2 DESC, similarity(lower(title), $3) DESC,
+            trend_score DESC NULLS LAST
+        LIMIT $4
+    `, contains, pattern+"%", prefix, n, listingActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var suggestions []*listingpb.Suggestion
+	for rows.Next() {
+		var sg listingpb.Suggestion
+		if err := rows.Scan(&sg.ListingId, &sg.Text); err != nil {
+			return nil, err
+		}
+		sg.Kind = suggestionListing
+		suggestions = append(suggestions, &sg)
+	}
+	return suggestions, rows.Err()
+}
+
+// SuggestListings подсказывает поисковые запросы по началу ввода: популярные прошлые запросы
+// и названия объявлений. Оба источника опрашиваются параллельно в пределах suggestTimeout,
+// не успевший источник пропускается, чтобы подсказки не задерживали ввод
+func (s *server) SuggestListings(ctx context.Context, req *listingpb.SuggestRequest) (*listingpb.SuggestResponse, error) {
+	n := suggestLimit
+	if req.Limit > 0 {
+		n = int(min(req.Limit, maxSuggestions))
+	}
+
+	prefix := normalizeQuery(req.Prefix)
+	if utf8.RuneCountInString(prefix) < minSuggestPrefix {
+		return &listingpb.SuggestResponse{Suggestions: []*listingpb.Suggestion{}}, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, suggestTimeout)
+	defer cancel()
+
+	var (
+		wg                  sync.WaitGroup
+		queries, titles     []*listingpb.Suggestion
+		queryErr, titlesErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		queries, queryErr = s.suggestQueries(ctx, prefix, n)
+	}()
+	go func() {
+		defer wg.Done()
+		titles, titlesErr = s.suggestTitles(ctx, prefix, n)
+	}()
+	wg.Wait()
+
+	if queryErr != nil && titlesErr != nil && ctx.Err() == nil {
+		return nil, status.Errorf(codes.Internal, "failed to query suggestions: %v", queryErr)
+	}
+	if queryErr != nil {
+		log.Printf("query suggestions skipped: %v", queryErr)
+		queries = nil
+	}
+	if titlesErr != nil {
+		log.Printf("title suggestions skipped: %v", titlesErr)
+		titles = nil
+	}
+
+	return &listingpb.SuggestResponse{Suggestions: mergeSuggestions(queries, titles, n)}, nil
+}
+
+// mergeSuggestions отдаёт половину мест популярным запросам, остальное - названиям объявлений.
+// Если одного источника не хватает, его места занимает другой. Повторяющиеся тексты пропускаются
+func mergeSuggestions(queries, titles []*listingpb.Suggestion, n int) []*listingpb.Suggestion {
+	result := make([]*listingpb.Suggestion, 0, n)
+	seen := make(map[string]bool)
+	add := func(list []*listingpb.Suggestion, quota int) []*listingpb.Suggestion {
+		for len(list) > 0 && len(result) < quota {
+			sg := list[0]
+			list = list[1:]
+			if key := strings.ToLower(sg.Text); !seen[key] {
+				seen[key] = true
+				result = append(result, sg)
+			}
+		}
+		return list
+	}
+
+	queries = add(queries, (n+1)/2)
+	add(titles, n)
+	add(queries, n)
+	return result
+}
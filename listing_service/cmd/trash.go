@@ -0,0 +1,156 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"listingService/listingpb"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GetTrash возвращает удалённые объявления пользователя, которые ещё можно восстановить
+func (s *server) GetTrash(ctx context.Context, req *listingpb.GetTrashRequest) (*listingpb.GetAllListingsResponse, error) {
+	if req.Page < 1 {
+		req.Page = 1
+	}
+
+	deletedAfter := time.Now().Add(-trashRetention)
+
+	var totalItems int
+	err := s.sql.QueryRow(ctx, `
+        SELECT COUNT(*) FROM listings
+        WHERE author_id = $1 AND deleted_at IS NOT NULL AND deleted_at > $2
+    `, req.UserId, deletedAfter).Scan(&totalItems)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to count deleted listings: %v", err)
+	}
+
+	totalPages := int64((totalItems + limit - 1) / limit)
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	if req.Page > totalPages {
+		req.Page = totalPages
+	}
+	offset := (req.Page - 1) * int64(limit)
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT
+            l.id, l.title, l.description, l.address, l.price,
+            l.author_id, `+authorNameColumn+` as author_username,
+            l.created_at, l.image_url, l.likes, l.deleted_at
+        FROM listings l
+        LEFT JOIN users u ON l.author_id = u.id
+        WHERE l.author_id = $1 AND l.deleted_at IS NOT NULL AND l.deleted_at > $2
+        ORDER BY l.deleted_at DESC
+        LIMIT $3 OFFSET $4
+    `, req.UserId, deletedAfter, limit, offset)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	var listings []*listingpb.Listing
+	for rows.Next() {
+		var l listingpb.Listing
+		var createdAt, deletedAt time.Time
+		var authorUsername *string
+
+		if err := rows.Scan(
+			&l.Id,
+			&l.Title,
+			&l.Description,
+			&l.Address,
+			&l.Price,
+			&l.AuthorId,
+			&authorUsername,
+			&createdAt,
+			&l.ImageUrl,
+			&l.Likes,
+			&deletedAt,
+		); err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+
+		if authorUsername != nil {
+			l.AuthorLogin = *authorUsername
+		}
+
+		l.CreatedAt = timestamppb.New(createdAt)
+		l.DeletedAt = timestamppb.New(deletedAt)
+		l.IsYours = true
+
+		listings = append(listings, &l)
+	}
+
+	return &listingpb.GetAllListingsResponse{
+		Listings:    listings,
+		TotalPages:  totalPages,
+		CurrentPage: req.Page,
+	}, nil
+}
+
+// RestoreListing возвращает объявление из корзины, если срок хранения ещё не истёк
+func (s *server) RestoreListing(ctx context.Context, req *listingpb.RestoreListingRequest) (*listingpb.Empty, error) {
+	var authorID string
+	var deletedAt *time.Time
+	err := s.sql.QueryRow(ctx, `SELECT author_id, deleted_at FROM listings WHERE id = $1`, req.Id).Scan(&authorID, &deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "listing not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+
+	if authorID != req.UserId {
+		return nil, status.Error(codes.PermissionDenied, "you are not the owner of this listing")
+	}
+
+	if deletedAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "listing is not deleted")
+	}
+
+	if time.Since(*deletedAt) > trashRetention {
+		return nil, status.Error(codes.FailedPrecondition, "restore window expired")
+	}
+
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		return withHistory(ctx, tx, req.Id, req.UserId, historyRestored, func() error {
+			_, err := tx.Exec(ctx, `UPDATE listings SET deleted_at = NULL WHERE id = $1`, req.Id)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to restore listing: %v", err)
+	}
+	return &listingpb.Empty{}, nil
+}
+
+// purgeTrash периодически окончательно удаляет объявления, пролежавшие в корзине дольше trashRetention
+func (s *server) purgeTrash(ctx context.Context) {
+	ticker := time.NewTicker(purgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tag, err := s.sql.Exec(ctx, `
+                DELETE FROM listings WHERE deleted_at IS NOT NULL AND deleted_at <= $1
+            `, time.Now().Add(-trashRetention))
+			if err != nil {
+				log.Printf("failed to purge deleted listings: %v", err)
+				continue
+			}
+			if tag.RowsAffected() > 0 {
+				log.Printf("purged %d deleted listings", tag.RowsAffected())
+			}
+		}
+	}
+}
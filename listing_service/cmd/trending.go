@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"listingService/listingpb"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Веса событий в трендовом счёте
+const (
+	likeTrendWeight = 1.0
+	viewTrendWeight = 0.2
+)
+
+// trendEpoch точка отсчёта трендового счёта
+var trendEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Трендовый счёт объявления - сумма весов событий, каждый из которых уменьшается вдвое за trendHalfLife.
+// Чтобы не пересчитывать счёт всех объявлений со временем, веса хранятся приведёнными к моменту
+// события: событие в момент t весит w * 2^((t - trendEpoch) / trendHalfLife). Порядок объявлений
+// по такой сумме совпадает с порядком по затухающему счёту в любой момент времени.
+// Чтобы сумма не переполнялась, в trend_score хранится её двоичный логарифм.
+
+// trendIncrement возвращает логарифм приведённого веса события
+func trendIncrement(weight float64, at time.Time) float64 {
+	return at.Sub(trendEpoch).Seconds()/trendHalfLife.Seconds() + math.Log2(weight)
+}
+
+// trendUpdate возвращает SQL выражение нового trend_score после прибавления события
+// с логарифмом веса из параметра запроса номер param: log2(2^a + 2^b) = max(a, b) + log2(1 + 2^-|a - b|)
+func trendUpdate(param int) string {
+	return fmt.Sprintf(`CASE WHEN trend_score IS NULL THEN $%[1]d::double precision
+        ELSE GREATEST(trend_score, $%[1]d::double precision)
+            + ln(1 + power(2, -abs(trend_score - $%[1]d::double precision))) / ln(2) END`, param)
+}
+
+// RecordView учитывает просмотр объявления в трендовом счёте. Учитываются только просмотры
+// вошедших пользователей, кроме автора, и не чаще раза в viewWindow от одного пользователя
+func (s *server) RecordView(ctx context.Context, req *listingpb.RecordViewRequest) (*listingpb.Empty, error) {
+	if _, err := uuid.Parse(req.ListingId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid listing_id: %v", err)
+	}
+
+	var authorID string
+	err := s.sql.QueryRow(ctx, `
+        SELECT author_id FROM listings WHERE id = $1 AND deleted_at IS NULL
+    `, req.ListingId).Scan(&authorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "listing not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query listing: %v", err)
+	}
+
+	if req.UserId == "" || req.UserId == uuid.Nil.String() || req.UserId == authorID {
+		return &listingpb.Empty{}, nil
+	}
+
+	now := time.Now()
+	err = pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO listing_views (listing_id, user_id, viewed_at) VALUES ($1, $2, $3)
+            ON CONFLICT (listing_id, user_id) DO UPDATE SET viewed_at = EXCLUDED.viewed_at
+            WHERE listing_views.viewed_at <= $4
+        `, req.ListingId, req.UserId, now, now.Add(-viewWindow))
+		if err != nil || tag.RowsAffected() == 0 {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+            UPDATE listings SET trend_score = `+trendUpdate(2)+` WHERE id = $1
+        `, req.ListingId, trendIncrement(viewTrendWeight, now))
+		return err
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to record view: %v", err)
+	}
+
+	return &listingpb.Empty{}, nil
+}
@@ -72,6 +72,11 @@ type Listing struct {
 	IsLiked       bool                   `protobuf:"varint,10,opt,name=is_liked,json=isLiked,proto3" json:"is_liked,omitempty"`
 	IsYours       bool                   `protobuf:"varint,11,opt,name=is_yours,json=isYours,proto3" json:"is_yours,omitempty"`
 	AuthorLogin   string                 `protobuf:"bytes,12,opt,name=author_login,json=authorLogin,proto3" json:"author_login,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,14,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,15,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Status        string                 `protobuf:"bytes,16,opt,name=status,proto3" json:"status,omitempty"`
+	Promoted      bool                   `protobuf:"varint,17,opt,name=promoted,proto3" json:"promoted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -190,18 +195,65 @@ func (x *Listing) GetAuthorLogin() string {
 	return ""
 }
 
+func (x *Listing) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *Listing) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *Listing) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *Listing) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Listing) GetPromoted() bool {
+	if x != nil {
+		return x.Promoted
+	}
+	return false
+}
+
 type GetAllListingsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
-	SortField     string                 `protobuf:"bytes,3,opt,name=sort_field,json=sortField,proto3" json:"sort_field,omitempty"`
-	SortOrder     string                 `protobuf:"bytes,4,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
-	OnlyLiked     bool                   `protobuf:"varint,5,opt,name=only_liked,json=onlyLiked,proto3" json:"only_liked,omitempty"`
-	Page          int64                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
-	MinPrice      int64                  `protobuf:"varint,7,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
-	MaxPrice      int64                  `protobuf:"varint,8,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TargetUserId     string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	SortField        string                 `protobuf:"bytes,3,opt,name=sort_field,json=sortField,proto3" json:"sort_field,omitempty"`
+	SortOrder        string                 `protobuf:"bytes,4,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	OnlyLiked        bool                   `protobuf:"varint,5,opt,name=only_liked,json=onlyLiked,proto3" json:"only_liked,omitempty"`
+	Page             int64                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	MinPrice         int64                  `protobuf:"varint,7,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice         int64                  `protobuf:"varint,8,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	CategoryId       int64                  `protobuf:"varint,9,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	AttributeFilters []*AttributeFilter     `protobuf:"bytes,10,rep,name=attribute_filters,json=attributeFilters,proto3" json:"attribute_filters,omitempty"`
+	CollectionId     string                 `protobuf:"bytes,11,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	ShareToken       string                 `protobuf:"bytes,12,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	Following        bool                   `protobuf:"varint,13,opt,name=following,proto3" json:"following,omitempty"`
+	PageSize         int64                  `protobuf:"varint,14,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Query            string                 `protobuf:"bytes,15,opt,name=query,proto3" json:"query,omitempty"`
+	CreatedAfter     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore    *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	AuthorIds        []string               `protobuf:"bytes,18,rep,name=author_ids,json=authorIds,proto3" json:"author_ids,omitempty"`
+	MinLikes         int64                  `protobuf:"varint,19,opt,name=min_likes,json=minLikes,proto3" json:"min_likes,omitempty"`
+	HasImage         bool                   `protobuf:"varint,20,opt,name=has_image,json=hasImage,proto3" json:"has_image,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *GetAllListingsRequest) Reset() {
@@ -290,6 +342,210 @@ func (x *GetAllListingsRequest) GetMaxPrice() int64 {
 	return 0
 }
 
+func (x *GetAllListingsRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *GetAllListingsRequest) GetAttributeFilters() []*AttributeFilter {
+	if x != nil {
+		return x.AttributeFilters
+	}
+	return nil
+}
+
+func (x *GetAllListingsRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *GetAllListingsRequest) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *GetAllListingsRequest) GetFollowing() bool {
+	if x != nil {
+		return x.Following
+	}
+	return false
+}
+
+func (x *GetAllListingsRequest) GetPageSize() int64 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetAllListingsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *GetAllListingsRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *GetAllListingsRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *GetAllListingsRequest) GetAuthorIds() []string {
+	if x != nil {
+		return x.AuthorIds
+	}
+	return nil
+}
+
+func (x *GetAllListingsRequest) GetMinLikes() int64 {
+	if x != nil {
+		return x.MinLikes
+	}
+	return 0
+}
+
+func (x *GetAllListingsRequest) GetHasImage() bool {
+	if x != nil {
+		return x.HasImage
+	}
+	return false
+}
+
+type GetListingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListingRequest) Reset() {
+	*x = GetListingRequest{}
+	mi := &file_listing_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListingRequest) ProtoMessage() {}
+
+func (x *GetListingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListingRequest.ProtoReflect.Descriptor instead.
+func (*GetListingRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetListingRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *GetListingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AttributeFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         *string                `protobuf:"bytes,2,opt,name=value,proto3,oneof" json:"value,omitempty"`
+	Min           *int64                 `protobuf:"varint,3,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *int64                 `protobuf:"varint,4,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeFilter) Reset() {
+	*x = AttributeFilter{}
+	mi := &file_listing_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeFilter) ProtoMessage() {}
+
+func (x *AttributeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeFilter.ProtoReflect.Descriptor instead.
+func (*AttributeFilter) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AttributeFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+func (x *AttributeFilter) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *AttributeFilter) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
 type GetAllListingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Listings      []*Listing             `protobuf:"bytes,1,rep,name=listings,proto3" json:"listings,omitempty"`
@@ -301,7 +557,7 @@ type GetAllListingsResponse struct {
 
 func (x *GetAllListingsResponse) Reset() {
 	*x = GetAllListingsResponse{}
-	mi := &file_listing_proto_msgTypes[3]
+	mi := &file_listing_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -313,7 +569,7 @@ func (x *GetAllListingsResponse) String() string {
 func (*GetAllListingsResponse) ProtoMessage() {}
 
 func (x *GetAllListingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[3]
+	mi := &file_listing_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -326,7 +582,7 @@ func (x *GetAllListingsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllListingsResponse.ProtoReflect.Descriptor instead.
 func (*GetAllListingsResponse) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{3}
+	return file_listing_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetAllListingsResponse) GetListings() []*Listing {
@@ -358,13 +614,17 @@ type AddListingRequest struct {
 	Price         int64                  `protobuf:"varint,4,opt,name=price,proto3" json:"price,omitempty"`
 	AuthorId      string                 `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
 	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,7,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,8,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ImageHash     uint64                 `protobuf:"varint,9,opt,name=image_hash,json=imageHash,proto3" json:"image_hash,omitempty"`
+	Auction       *AuctionSettings       `protobuf:"bytes,10,opt,name=auction,proto3" json:"auction,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddListingRequest) Reset() {
 	*x = AddListingRequest{}
-	mi := &file_listing_proto_msgTypes[4]
+	mi := &file_listing_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -376,7 +636,7 @@ func (x *AddListingRequest) String() string {
 func (*AddListingRequest) ProtoMessage() {}
 
 func (x *AddListingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[4]
+	mi := &file_listing_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -389,7 +649,7 @@ func (x *AddListingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddListingRequest.ProtoReflect.Descriptor instead.
 func (*AddListingRequest) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{4}
+	return file_listing_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *AddListingRequest) GetTitle() string {
@@ -434,6 +694,34 @@ func (x *AddListingRequest) GetImageUrl() string {
 	return ""
 }
 
+func (x *AddListingRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *AddListingRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *AddListingRequest) GetImageHash() uint64 {
+	if x != nil {
+		return x.ImageHash
+	}
+	return 0
+}
+
+func (x *AddListingRequest) GetAuction() *AuctionSettings {
+	if x != nil {
+		return x.Auction
+	}
+	return nil
+}
+
 type AddListingResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -443,7 +731,7 @@ type AddListingResponse struct {
 
 func (x *AddListingResponse) Reset() {
 	*x = AddListingResponse{}
-	mi := &file_listing_proto_msgTypes[5]
+	mi := &file_listing_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -455,7 +743,7 @@ func (x *AddListingResponse) String() string {
 func (*AddListingResponse) ProtoMessage() {}
 
 func (x *AddListingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[5]
+	mi := &file_listing_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -468,7 +756,7 @@ func (x *AddListingResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddListingResponse.ProtoReflect.Descriptor instead.
 func (*AddListingResponse) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{5}
+	return file_listing_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AddListingResponse) GetId() string {
@@ -487,13 +775,16 @@ type EditListingRequest struct {
 	Price         int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
 	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
 	UserId        string                 `protobuf:"bytes,7,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CategoryId    int64                  `protobuf:"varint,8,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,9,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	ImageHash     uint64                 `protobuf:"varint,10,opt,name=image_hash,json=imageHash,proto3" json:"image_hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *EditListingRequest) Reset() {
 	*x = EditListingRequest{}
-	mi := &file_listing_proto_msgTypes[6]
+	mi := &file_listing_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -505,7 +796,7 @@ func (x *EditListingRequest) String() string {
 func (*EditListingRequest) ProtoMessage() {}
 
 func (x *EditListingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[6]
+	mi := &file_listing_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -518,7 +809,7 @@ func (x *EditListingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use EditListingRequest.ProtoReflect.Descriptor instead.
 func (*EditListingRequest) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{6}
+	return file_listing_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *EditListingRequest) GetId() string {
@@ -570,6 +861,27 @@ func (x *EditListingRequest) GetUserId() string {
 	return ""
 }
 
+func (x *EditListingRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *EditListingRequest) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *EditListingRequest) GetImageHash() uint64 {
+	if x != nil {
+		return x.ImageHash
+	}
+	return 0
+}
+
 type DeleteListingRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -580,7 +892,7 @@ type DeleteListingRequest struct {
 
 func (x *DeleteListingRequest) Reset() {
 	*x = DeleteListingRequest{}
-	mi := &file_listing_proto_msgTypes[7]
+	mi := &file_listing_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -592,7 +904,7 @@ func (x *DeleteListingRequest) String() string {
 func (*DeleteListingRequest) ProtoMessage() {}
 
 func (x *DeleteListingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[7]
+	mi := &file_listing_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -605,7 +917,7 @@ func (x *DeleteListingRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteListingRequest.ProtoReflect.Descriptor instead.
 func (*DeleteListingRequest) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{7}
+	return file_listing_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteListingRequest) GetId() string {
@@ -632,7 +944,7 @@ type AddLikeRequest struct {
 
 func (x *AddLikeRequest) Reset() {
 	*x = AddLikeRequest{}
-	mi := &file_listing_proto_msgTypes[8]
+	mi := &file_listing_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -644,7 +956,7 @@ func (x *AddLikeRequest) String() string {
 func (*AddLikeRequest) ProtoMessage() {}
 
 func (x *AddLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[8]
+	mi := &file_listing_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -657,7 +969,7 @@ func (x *AddLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddLikeRequest.ProtoReflect.Descriptor instead.
 func (*AddLikeRequest) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{8}
+	return file_listing_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AddLikeRequest) GetListingId() string {
@@ -684,7 +996,7 @@ type RemoveLikeRequest struct {
 
 func (x *RemoveLikeRequest) Reset() {
 	*x = RemoveLikeRequest{}
-	mi := &file_listing_proto_msgTypes[9]
+	mi := &file_listing_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -696,7 +1008,7 @@ func (x *RemoveLikeRequest) String() string {
 func (*RemoveLikeRequest) ProtoMessage() {}
 
 func (x *RemoveLikeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_listing_proto_msgTypes[9]
+	mi := &file_listing_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -709,7 +1021,7 @@ func (x *RemoveLikeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveLikeRequest.ProtoReflect.Descriptor instead.
 func (*RemoveLikeRequest) Descriptor() ([]byte, []int) {
-	return file_listing_proto_rawDescGZIP(), []int{9}
+	return file_listing_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *RemoveLikeRequest) GetListingId() string {
@@ -726,12 +1038,4037 @@ func (x *RemoveLikeRequest) GetUserId() string {
 	return ""
 }
 
-var File_listing_proto protoreflect.FileDescriptor
+type GetTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_listing_proto_rawDesc = "" +
-	"\n" +
-	"\rlisting.proto\x12\tlistingpb\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
-	"\x05Empty\"\xe5\x02\n" +
+func (x *GetTrashRequest) Reset() {
+	*x = GetTrashRequest{}
+	mi := &file_listing_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrashRequest) ProtoMessage() {}
+
+func (x *GetTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrashRequest.ProtoReflect.Descriptor instead.
+func (*GetTrashRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetTrashRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetTrashRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type RestoreListingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreListingRequest) Reset() {
+	*x = RestoreListingRequest{}
+	mi := &file_listing_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreListingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreListingRequest) ProtoMessage() {}
+
+func (x *RestoreListingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreListingRequest.ProtoReflect.Descriptor instead.
+func (*RestoreListingRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RestoreListingRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestoreListingRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ImportRow struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Address       string                 `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+	Price         int64                  `protobuf:"varint,5,opt,name=price,proto3" json:"price,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,6,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	ImageHash     uint64                 `protobuf:"varint,7,opt,name=image_hash,json=imageHash,proto3" json:"image_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRow) Reset() {
+	*x = ImportRow{}
+	mi := &file_listing_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRow) ProtoMessage() {}
+
+func (x *ImportRow) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRow.ProtoReflect.Descriptor instead.
+func (*ImportRow) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ImportRow) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRow) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *ImportRow) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ImportRow) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ImportRow) GetPrice() int64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ImportRow) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ImportRow) GetImageHash() uint64 {
+	if x != nil {
+		return x.ImageHash
+	}
+	return 0
+}
+
+type ImportRowError struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Row           int64                  `protobuf:"varint,1,opt,name=row,proto3" json:"row,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRowError) Reset() {
+	*x = ImportRowError{}
+	mi := &file_listing_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRowError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRowError) ProtoMessage() {}
+
+func (x *ImportRowError) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRowError.ProtoReflect.Descriptor instead.
+func (*ImportRowError) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ImportRowError) GetRow() int64 {
+	if x != nil {
+		return x.Row
+	}
+	return 0
+}
+
+func (x *ImportRowError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type CreateImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	TotalRows     int64                  `protobuf:"varint,2,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateImportJobRequest) Reset() {
+	*x = CreateImportJobRequest{}
+	mi := &file_listing_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateImportJobRequest) ProtoMessage() {}
+
+func (x *CreateImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateImportJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *CreateImportJobRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateImportJobRequest) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *CreateImportJobRequest) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type AppendImportRowsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Rows          []*ImportRow           `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Final         bool                   `protobuf:"varint,5,opt,name=final,proto3" json:"final,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendImportRowsRequest) Reset() {
+	*x = AppendImportRowsRequest{}
+	mi := &file_listing_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendImportRowsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendImportRowsRequest) ProtoMessage() {}
+
+func (x *AppendImportRowsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendImportRowsRequest.ProtoReflect.Descriptor instead.
+func (*AppendImportRowsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *AppendImportRowsRequest) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *AppendImportRowsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *AppendImportRowsRequest) GetRows() []*ImportRow {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+func (x *AppendImportRowsRequest) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *AppendImportRowsRequest) GetFinal() bool {
+	if x != nil {
+		return x.Final
+	}
+	return false
+}
+
+type AppendImportRowsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Errors        []*ImportRowError      `protobuf:"bytes,1,rep,name=errors,proto3" json:"errors,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppendImportRowsResponse) Reset() {
+	*x = AppendImportRowsResponse{}
+	mi := &file_listing_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppendImportRowsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppendImportRowsResponse) ProtoMessage() {}
+
+func (x *AppendImportRowsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppendImportRowsResponse.ProtoReflect.Descriptor instead.
+func (*AppendImportRowsResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AppendImportRowsResponse) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+type GetImportJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportJobRequest) Reset() {
+	*x = GetImportJobRequest{}
+	mi := &file_listing_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportJobRequest) ProtoMessage() {}
+
+func (x *GetImportJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportJobRequest.ProtoReflect.Descriptor instead.
+func (*GetImportJobRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetImportJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetImportJobRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type ImportJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TotalRows     int64                  `protobuf:"varint,3,opt,name=total_rows,json=totalRows,proto3" json:"total_rows,omitempty"`
+	ProcessedRows int64                  `protobuf:"varint,4,opt,name=processed_rows,json=processedRows,proto3" json:"processed_rows,omitempty"`
+	ImportedRows  int64                  `protobuf:"varint,5,opt,name=imported_rows,json=importedRows,proto3" json:"imported_rows,omitempty"`
+	FailedRows    int64                  `protobuf:"varint,6,opt,name=failed_rows,json=failedRows,proto3" json:"failed_rows,omitempty"`
+	Errors        []*ImportRowError      `protobuf:"bytes,7,rep,name=errors,proto3" json:"errors,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportJob) Reset() {
+	*x = ImportJob{}
+	mi := &file_listing_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportJob) ProtoMessage() {}
+
+func (x *ImportJob) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportJob.ProtoReflect.Descriptor instead.
+func (*ImportJob) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ImportJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportJob) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ImportJob) GetTotalRows() int64 {
+	if x != nil {
+		return x.TotalRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetProcessedRows() int64 {
+	if x != nil {
+		return x.ProcessedRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetImportedRows() int64 {
+	if x != nil {
+		return x.ImportedRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetFailedRows() int64 {
+	if x != nil {
+		return x.FailedRows
+	}
+	return 0
+}
+
+func (x *ImportJob) GetErrors() []*ImportRowError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ImportJob) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ImportJob) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+type FeedTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Regenerate    bool                   `protobuf:"varint,2,opt,name=regenerate,proto3" json:"regenerate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedTokenRequest) Reset() {
+	*x = FeedTokenRequest{}
+	mi := &file_listing_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedTokenRequest) ProtoMessage() {}
+
+func (x *FeedTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedTokenRequest.ProtoReflect.Descriptor instead.
+func (*FeedTokenRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FeedTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FeedTokenRequest) GetRegenerate() bool {
+	if x != nil {
+		return x.Regenerate
+	}
+	return false
+}
+
+type FeedTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeedTokenResponse) Reset() {
+	*x = FeedTokenResponse{}
+	mi := &file_listing_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeedTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeedTokenResponse) ProtoMessage() {}
+
+func (x *FeedTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeedTokenResponse.ProtoReflect.Descriptor instead.
+func (*FeedTokenResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *FeedTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// В фид попадают только активные объявления продавца user_id, подходящие под filter
+type StreamFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	Filter        *GetAllListingsRequest `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamFeedRequest) Reset() {
+	*x = StreamFeedRequest{}
+	mi := &file_listing_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamFeedRequest) ProtoMessage() {}
+
+func (x *StreamFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamFeedRequest.ProtoReflect.Descriptor instead.
+func (*StreamFeedRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *StreamFeedRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *StreamFeedRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *StreamFeedRequest) GetFilter() *GetAllListingsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type AttributeSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	EnumValues    []string               `protobuf:"bytes,3,rep,name=enum_values,json=enumValues,proto3" json:"enum_values,omitempty"`
+	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttributeSchema) Reset() {
+	*x = AttributeSchema{}
+	mi := &file_listing_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttributeSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttributeSchema) ProtoMessage() {}
+
+func (x *AttributeSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttributeSchema.ProtoReflect.Descriptor instead.
+func (*AttributeSchema) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *AttributeSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AttributeSchema) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *AttributeSchema) GetEnumValues() []string {
+	if x != nil {
+		return x.EnumValues
+	}
+	return nil
+}
+
+func (x *AttributeSchema) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type Category struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Attributes    []*AttributeSchema     `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Category) Reset() {
+	*x = Category{}
+	mi := &file_listing_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Category) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Category) ProtoMessage() {}
+
+func (x *Category) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Category.ProtoReflect.Descriptor instead.
+func (*Category) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Category) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Category) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Category) GetAttributes() []*AttributeSchema {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type GetCategoriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*Category            `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCategoriesResponse) Reset() {
+	*x = GetCategoriesResponse{}
+	mi := &file_listing_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCategoriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCategoriesResponse) ProtoMessage() {}
+
+func (x *GetCategoriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCategoriesResponse.ProtoReflect.Descriptor instead.
+func (*GetCategoriesResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetCategoriesResponse) GetCategories() []*Category {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type GetListingFacetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *GetAllListingsRequest `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	PriceBounds   []int64                `protobuf:"varint,2,rep,packed,name=price_bounds,json=priceBounds,proto3" json:"price_bounds,omitempty"`
+	PriceBuckets  int64                  `protobuf:"varint,3,opt,name=price_buckets,json=priceBuckets,proto3" json:"price_buckets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListingFacetsRequest) Reset() {
+	*x = GetListingFacetsRequest{}
+	mi := &file_listing_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListingFacetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListingFacetsRequest) ProtoMessage() {}
+
+func (x *GetListingFacetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListingFacetsRequest.ProtoReflect.Descriptor instead.
+func (*GetListingFacetsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetListingFacetsRequest) GetFilter() *GetAllListingsRequest {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GetListingFacetsRequest) GetPriceBounds() []int64 {
+	if x != nil {
+		return x.PriceBounds
+	}
+	return nil
+}
+
+func (x *GetListingFacetsRequest) GetPriceBuckets() int64 {
+	if x != nil {
+		return x.PriceBuckets
+	}
+	return 0
+}
+
+type PriceBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          int64                  `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            *int64                 `protobuf:"varint,2,opt,name=to,proto3,oneof" json:"to,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceBucket) Reset() {
+	*x = PriceBucket{}
+	mi := &file_listing_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceBucket) ProtoMessage() {}
+
+func (x *PriceBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceBucket.ProtoReflect.Descriptor instead.
+func (*PriceBucket) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PriceBucket) GetFrom() int64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetTo() int64 {
+	if x != nil && x.To != nil {
+		return *x.To
+	}
+	return 0
+}
+
+func (x *PriceBucket) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type CategoryFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CategoryId    int64                  `protobuf:"varint,1,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int64                  `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryFacet) Reset() {
+	*x = CategoryFacet{}
+	mi := &file_listing_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryFacet) ProtoMessage() {}
+
+func (x *CategoryFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryFacet.ProtoReflect.Descriptor instead.
+func (*CategoryFacet) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *CategoryFacet) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *CategoryFacet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CategoryFacet) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type StatusFacet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StatusFacet) Reset() {
+	*x = StatusFacet{}
+	mi := &file_listing_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StatusFacet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusFacet) ProtoMessage() {}
+
+func (x *StatusFacet) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusFacet.ProtoReflect.Descriptor instead.
+func (*StatusFacet) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *StatusFacet) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusFacet) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ListingFacets struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Total          int64                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	MinPrice       int64                  `protobuf:"varint,2,opt,name=min_price,json=minPrice,proto3" json:"min_price,omitempty"`
+	MaxPrice       int64                  `protobuf:"varint,3,opt,name=max_price,json=maxPrice,proto3" json:"max_price,omitempty"`
+	PriceHistogram []*PriceBucket         `protobuf:"bytes,4,rep,name=price_histogram,json=priceHistogram,proto3" json:"price_histogram,omitempty"`
+	Categories     []*CategoryFacet       `protobuf:"bytes,5,rep,name=categories,proto3" json:"categories,omitempty"`
+	Statuses       []*StatusFacet         `protobuf:"bytes,6,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListingFacets) Reset() {
+	*x = ListingFacets{}
+	mi := &file_listing_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListingFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListingFacets) ProtoMessage() {}
+
+func (x *ListingFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListingFacets.ProtoReflect.Descriptor instead.
+func (*ListingFacets) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListingFacets) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListingFacets) GetMinPrice() int64 {
+	if x != nil {
+		return x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListingFacets) GetMaxPrice() int64 {
+	if x != nil {
+		return x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListingFacets) GetPriceHistogram() []*PriceBucket {
+	if x != nil {
+		return x.PriceHistogram
+	}
+	return nil
+}
+
+func (x *ListingFacets) GetCategories() []*CategoryFacet {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ListingFacets) GetStatuses() []*StatusFacet {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type GetSimilarListingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSimilarListingsRequest) Reset() {
+	*x = GetSimilarListingsRequest{}
+	mi := &file_listing_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSimilarListingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSimilarListingsRequest) ProtoMessage() {}
+
+func (x *GetSimilarListingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSimilarListingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSimilarListingsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetSimilarListingsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetSimilarListingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSimilarListingsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetRecommendationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecommendationsRequest) Reset() {
+	*x = GetRecommendationsRequest{}
+	mi := &file_listing_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecommendationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecommendationsRequest) ProtoMessage() {}
+
+func (x *GetRecommendationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecommendationsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecommendationsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetRecommendationsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetRecommendationsRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type RecordViewRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordViewRequest) Reset() {
+	*x = RecordViewRequest{}
+	mi := &file_listing_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordViewRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordViewRequest) ProtoMessage() {}
+
+func (x *RecordViewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordViewRequest.ProtoReflect.Descriptor instead.
+func (*RecordViewRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RecordViewRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *RecordViewRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_listing_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *CreatePromotionRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *CreatePromotionRequest) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *CreatePromotionRequest) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type Promotion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartsAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
+	mi := &file_listing_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Promotion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Promotion) ProtoMessage() {}
+
+func (x *Promotion) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Promotion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Promotion) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Promotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Promotion) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Promotion) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionsRequest) Reset() {
+	*x = GetPromotionsRequest{}
+	mi := &file_listing_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionsRequest) ProtoMessage() {}
+
+func (x *GetPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*GetPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetPromotionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPromotionsResponse) Reset() {
+	*x = GetPromotionsResponse{}
+	mi := &file_listing_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPromotionsResponse) ProtoMessage() {}
+
+func (x *GetPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*GetPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type GetDuplicatesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int64                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDuplicatesRequest) Reset() {
+	*x = GetDuplicatesRequest{}
+	mi := &file_listing_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDuplicatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDuplicatesRequest) ProtoMessage() {}
+
+func (x *GetDuplicatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDuplicatesRequest.ProtoReflect.Descriptor instead.
+func (*GetDuplicatesRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetDuplicatesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetDuplicatesRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type DuplicateMatch struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ListingId           string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	ListingTitle        string                 `protobuf:"bytes,2,opt,name=listing_title,json=listingTitle,proto3" json:"listing_title,omitempty"`
+	ListingAuthorId     string                 `protobuf:"bytes,3,opt,name=listing_author_id,json=listingAuthorId,proto3" json:"listing_author_id,omitempty"`
+	ListingDeleted      bool                   `protobuf:"varint,4,opt,name=listing_deleted,json=listingDeleted,proto3" json:"listing_deleted,omitempty"`
+	DuplicateOfId       string                 `protobuf:"bytes,5,opt,name=duplicate_of_id,json=duplicateOfId,proto3" json:"duplicate_of_id,omitempty"`
+	DuplicateOfTitle    string                 `protobuf:"bytes,6,opt,name=duplicate_of_title,json=duplicateOfTitle,proto3" json:"duplicate_of_title,omitempty"`
+	DuplicateOfAuthorId string                 `protobuf:"bytes,7,opt,name=duplicate_of_author_id,json=duplicateOfAuthorId,proto3" json:"duplicate_of_author_id,omitempty"`
+	DuplicateOfDeleted  bool                   `protobuf:"varint,8,opt,name=duplicate_of_deleted,json=duplicateOfDeleted,proto3" json:"duplicate_of_deleted,omitempty"`
+	SameAuthor          bool                   `protobuf:"varint,9,opt,name=same_author,json=sameAuthor,proto3" json:"same_author,omitempty"`
+	ImageDistance       *int64                 `protobuf:"varint,10,opt,name=image_distance,json=imageDistance,proto3,oneof" json:"image_distance,omitempty"`
+	TextDistance        *int64                 `protobuf:"varint,11,opt,name=text_distance,json=textDistance,proto3,oneof" json:"text_distance,omitempty"`
+	DetectedAt          *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *DuplicateMatch) Reset() {
+	*x = DuplicateMatch{}
+	mi := &file_listing_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateMatch) ProtoMessage() {}
+
+func (x *DuplicateMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateMatch.ProtoReflect.Descriptor instead.
+func (*DuplicateMatch) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DuplicateMatch) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetListingTitle() string {
+	if x != nil {
+		return x.ListingTitle
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetListingAuthorId() string {
+	if x != nil {
+		return x.ListingAuthorId
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetListingDeleted() bool {
+	if x != nil {
+		return x.ListingDeleted
+	}
+	return false
+}
+
+func (x *DuplicateMatch) GetDuplicateOfId() string {
+	if x != nil {
+		return x.DuplicateOfId
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetDuplicateOfTitle() string {
+	if x != nil {
+		return x.DuplicateOfTitle
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetDuplicateOfAuthorId() string {
+	if x != nil {
+		return x.DuplicateOfAuthorId
+	}
+	return ""
+}
+
+func (x *DuplicateMatch) GetDuplicateOfDeleted() bool {
+	if x != nil {
+		return x.DuplicateOfDeleted
+	}
+	return false
+}
+
+func (x *DuplicateMatch) GetSameAuthor() bool {
+	if x != nil {
+		return x.SameAuthor
+	}
+	return false
+}
+
+func (x *DuplicateMatch) GetImageDistance() int64 {
+	if x != nil && x.ImageDistance != nil {
+		return *x.ImageDistance
+	}
+	return 0
+}
+
+func (x *DuplicateMatch) GetTextDistance() int64 {
+	if x != nil && x.TextDistance != nil {
+		return *x.TextDistance
+	}
+	return 0
+}
+
+func (x *DuplicateMatch) GetDetectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return nil
+}
+
+type GetDuplicatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*DuplicateMatch      `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	CurrentPage   int64                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDuplicatesResponse) Reset() {
+	*x = GetDuplicatesResponse{}
+	mi := &file_listing_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDuplicatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDuplicatesResponse) ProtoMessage() {}
+
+func (x *GetDuplicatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDuplicatesResponse.ProtoReflect.Descriptor instead.
+func (*GetDuplicatesResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetDuplicatesResponse) GetMatches() []*DuplicateMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *GetDuplicatesResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *GetDuplicatesResponse) GetCurrentPage() int64 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+type MakeOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeOfferRequest) Reset() {
+	*x = MakeOfferRequest{}
+	mi := &file_listing_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeOfferRequest) ProtoMessage() {}
+
+func (x *MakeOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeOfferRequest.ProtoReflect.Descriptor instead.
+func (*MakeOfferRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *MakeOfferRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *MakeOfferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MakeOfferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MakeOfferRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *MakeOfferRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RespondOfferRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OfferId       string                 `protobuf:"bytes,1,opt,name=offer_id,json=offerId,proto3" json:"offer_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Amount        int64                  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondOfferRequest) Reset() {
+	*x = RespondOfferRequest{}
+	mi := &file_listing_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondOfferRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondOfferRequest) ProtoMessage() {}
+
+func (x *RespondOfferRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondOfferRequest.ProtoReflect.Descriptor instead.
+func (*RespondOfferRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *RespondOfferRequest) GetOfferId() string {
+	if x != nil {
+		return x.OfferId
+	}
+	return ""
+}
+
+func (x *RespondOfferRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RespondOfferRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *RespondOfferRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RespondOfferRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RespondOfferRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type Offer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	BuyerId       string                 `protobuf:"bytes,3,opt,name=buyer_id,json=buyerId,proto3" json:"buyer_id,omitempty"`
+	SellerId      string                 `protobuf:"bytes,4,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	FromSeller    bool                   `protobuf:"varint,6,opt,name=from_seller,json=fromSeller,proto3" json:"from_seller,omitempty"`
+	Amount        int64                  `protobuf:"varint,7,opt,name=amount,proto3" json:"amount,omitempty"`
+	Message       string                 `protobuf:"bytes,8,opt,name=message,proto3" json:"message,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	RespondedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=responded_at,json=respondedAt,proto3" json:"responded_at,omitempty"`
+	ReservedUntil *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=reserved_until,json=reservedUntil,proto3" json:"reserved_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Offer) Reset() {
+	*x = Offer{}
+	mi := &file_listing_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Offer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Offer) ProtoMessage() {}
+
+func (x *Offer) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Offer.ProtoReflect.Descriptor instead.
+func (*Offer) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Offer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Offer) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Offer) GetBuyerId() string {
+	if x != nil {
+		return x.BuyerId
+	}
+	return ""
+}
+
+func (x *Offer) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+func (x *Offer) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Offer) GetFromSeller() bool {
+	if x != nil {
+		return x.FromSeller
+	}
+	return false
+}
+
+func (x *Offer) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Offer) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Offer) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Offer) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *Offer) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Offer) GetRespondedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RespondedAt
+	}
+	return nil
+}
+
+func (x *Offer) GetReservedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedUntil
+	}
+	return nil
+}
+
+type GetOffersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOffersRequest) Reset() {
+	*x = GetOffersRequest{}
+	mi := &file_listing_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOffersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffersRequest) ProtoMessage() {}
+
+func (x *GetOffersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffersRequest.ProtoReflect.Descriptor instead.
+func (*GetOffersRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetOffersRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetOffersRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type GetOffersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offers        []*Offer               `protobuf:"bytes,1,rep,name=offers,proto3" json:"offers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOffersResponse) Reset() {
+	*x = GetOffersResponse{}
+	mi := &file_listing_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOffersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOffersResponse) ProtoMessage() {}
+
+func (x *GetOffersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOffersResponse.ProtoReflect.Descriptor instead.
+func (*GetOffersResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetOffersResponse) GetOffers() []*Offer {
+	if x != nil {
+		return x.Offers
+	}
+	return nil
+}
+
+type AuctionSettings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartPrice    int64                  `protobuf:"varint,1,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	MinIncrement  int64                  `protobuf:"varint,2,opt,name=min_increment,json=minIncrement,proto3" json:"min_increment,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuctionSettings) Reset() {
+	*x = AuctionSettings{}
+	mi := &file_listing_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuctionSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuctionSettings) ProtoMessage() {}
+
+func (x *AuctionSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuctionSettings.ProtoReflect.Descriptor instead.
+func (*AuctionSettings) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *AuctionSettings) GetStartPrice() int64 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *AuctionSettings) GetMinIncrement() int64 {
+	if x != nil {
+		return x.MinIncrement
+	}
+	return 0
+}
+
+func (x *AuctionSettings) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+type Bid struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	BidderId      string                 `protobuf:"bytes,2,opt,name=bidder_id,json=bidderId,proto3" json:"bidder_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Bid) Reset() {
+	*x = Bid{}
+	mi := &file_listing_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Bid) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Bid) ProtoMessage() {}
+
+func (x *Bid) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Bid.ProtoReflect.Descriptor instead.
+func (*Bid) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *Bid) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bid) GetBidderId() string {
+	if x != nil {
+		return x.BidderId
+	}
+	return ""
+}
+
+func (x *Bid) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Bid) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type Auction struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	StartPrice    int64                  `protobuf:"varint,2,opt,name=start_price,json=startPrice,proto3" json:"start_price,omitempty"`
+	MinIncrement  int64                  `protobuf:"varint,3,opt,name=min_increment,json=minIncrement,proto3" json:"min_increment,omitempty"`
+	CurrentPrice  *int64                 `protobuf:"varint,4,opt,name=current_price,json=currentPrice,proto3,oneof" json:"current_price,omitempty"`
+	MinBid        int64                  `protobuf:"varint,5,opt,name=min_bid,json=minBid,proto3" json:"min_bid,omitempty"`
+	LeaderId      string                 `protobuf:"bytes,6,opt,name=leader_id,json=leaderId,proto3" json:"leader_id,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,7,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	BidsCount     int64                  `protobuf:"varint,8,opt,name=bids_count,json=bidsCount,proto3" json:"bids_count,omitempty"`
+	Status        string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
+	EndsAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=closed_at,json=closedAt,proto3" json:"closed_at,omitempty"`
+	Bids          []*Bid                 `protobuf:"bytes,12,rep,name=bids,proto3" json:"bids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Auction) Reset() {
+	*x = Auction{}
+	mi := &file_listing_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Auction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Auction) ProtoMessage() {}
+
+func (x *Auction) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Auction.ProtoReflect.Descriptor instead.
+func (*Auction) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Auction) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Auction) GetStartPrice() int64 {
+	if x != nil {
+		return x.StartPrice
+	}
+	return 0
+}
+
+func (x *Auction) GetMinIncrement() int64 {
+	if x != nil {
+		return x.MinIncrement
+	}
+	return 0
+}
+
+func (x *Auction) GetCurrentPrice() int64 {
+	if x != nil && x.CurrentPrice != nil {
+		return *x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *Auction) GetMinBid() int64 {
+	if x != nil {
+		return x.MinBid
+	}
+	return 0
+}
+
+func (x *Auction) GetLeaderId() string {
+	if x != nil {
+		return x.LeaderId
+	}
+	return ""
+}
+
+func (x *Auction) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *Auction) GetBidsCount() int64 {
+	if x != nil {
+		return x.BidsCount
+	}
+	return 0
+}
+
+func (x *Auction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Auction) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Auction) GetClosedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClosedAt
+	}
+	return nil
+}
+
+func (x *Auction) GetBids() []*Bid {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+type PlaceBidRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceBidRequest) Reset() {
+	*x = PlaceBidRequest{}
+	mi := &file_listing_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceBidRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceBidRequest) ProtoMessage() {}
+
+func (x *PlaceBidRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceBidRequest.ProtoReflect.Descriptor instead.
+func (*PlaceBidRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PlaceBidRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *PlaceBidRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PlaceBidRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type GetAuctionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuctionRequest) Reset() {
+	*x = GetAuctionRequest{}
+	mi := &file_listing_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuctionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuctionRequest) ProtoMessage() {}
+
+func (x *GetAuctionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuctionRequest.ProtoReflect.Descriptor instead.
+func (*GetAuctionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetAuctionRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type Collection struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId          string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name            string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	ShareToken      string                 `protobuf:"bytes,4,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	ListingsCount   int64                  `protobuf:"varint,5,opt,name=listings_count,json=listingsCount,proto3" json:"listings_count,omitempty"`
+	ContainsListing bool                   `protobuf:"varint,6,opt,name=contains_listing,json=containsListing,proto3" json:"contains_listing,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Collection) Reset() {
+	*x = Collection{}
+	mi := &file_listing_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Collection) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Collection) ProtoMessage() {}
+
+func (x *Collection) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Collection.ProtoReflect.Descriptor instead.
+func (*Collection) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *Collection) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Collection) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Collection) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Collection) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+func (x *Collection) GetListingsCount() int64 {
+	if x != nil {
+		return x.ListingsCount
+	}
+	return 0
+}
+
+func (x *Collection) GetContainsListing() bool {
+	if x != nil {
+		return x.ContainsListing
+	}
+	return false
+}
+
+func (x *Collection) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type CollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionRequest) Reset() {
+	*x = CollectionRequest{}
+	mi := &file_listing_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionRequest) ProtoMessage() {}
+
+func (x *CollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionRequest.ProtoReflect.Descriptor instead.
+func (*CollectionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CollectionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetCollectionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionsRequest) Reset() {
+	*x = GetCollectionsRequest{}
+	mi := &file_listing_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionsRequest) ProtoMessage() {}
+
+func (x *GetCollectionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionsRequest.ProtoReflect.Descriptor instead.
+func (*GetCollectionsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetCollectionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetCollectionsRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type GetCollectionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Collections   []*Collection          `protobuf:"bytes,1,rep,name=collections,proto3" json:"collections,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCollectionsResponse) Reset() {
+	*x = GetCollectionsResponse{}
+	mi := &file_listing_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCollectionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCollectionsResponse) ProtoMessage() {}
+
+func (x *GetCollectionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCollectionsResponse.ProtoReflect.Descriptor instead.
+func (*GetCollectionsResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetCollectionsResponse) GetCollections() []*Collection {
+	if x != nil {
+		return x.Collections
+	}
+	return nil
+}
+
+type CollectionItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,3,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CollectionItemRequest) Reset() {
+	*x = CollectionItemRequest{}
+	mi := &file_listing_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CollectionItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CollectionItemRequest) ProtoMessage() {}
+
+func (x *CollectionItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CollectionItemRequest.ProtoReflect.Descriptor instead.
+func (*CollectionItemRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *CollectionItemRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *CollectionItemRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CollectionItemRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type ShareCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CollectionId  string                 `protobuf:"bytes,1,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Shared        bool                   `protobuf:"varint,3,opt,name=shared,proto3" json:"shared,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareCollectionRequest) Reset() {
+	*x = ShareCollectionRequest{}
+	mi := &file_listing_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareCollectionRequest) ProtoMessage() {}
+
+func (x *ShareCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareCollectionRequest.ProtoReflect.Descriptor instead.
+func (*ShareCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ShareCollectionRequest) GetCollectionId() string {
+	if x != nil {
+		return x.CollectionId
+	}
+	return ""
+}
+
+func (x *ShareCollectionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ShareCollectionRequest) GetShared() bool {
+	if x != nil {
+		return x.Shared
+	}
+	return false
+}
+
+type GetSharedCollectionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ShareToken    string                 `protobuf:"bytes,1,opt,name=share_token,json=shareToken,proto3" json:"share_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSharedCollectionRequest) Reset() {
+	*x = GetSharedCollectionRequest{}
+	mi := &file_listing_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSharedCollectionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSharedCollectionRequest) ProtoMessage() {}
+
+func (x *GetSharedCollectionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSharedCollectionRequest.ProtoReflect.Descriptor instead.
+func (*GetSharedCollectionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetSharedCollectionRequest) GetShareToken() string {
+	if x != nil {
+		return x.ShareToken
+	}
+	return ""
+}
+
+type FollowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FollowerId    string                 `protobuf:"bytes,1,opt,name=follower_id,json=followerId,proto3" json:"follower_id,omitempty"`
+	SellerId      string                 `protobuf:"bytes,2,opt,name=seller_id,json=sellerId,proto3" json:"seller_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FollowRequest) Reset() {
+	*x = FollowRequest{}
+	mi := &file_listing_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowRequest) ProtoMessage() {}
+
+func (x *FollowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowRequest.ProtoReflect.Descriptor instead.
+func (*FollowRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *FollowRequest) GetFollowerId() string {
+	if x != nil {
+		return x.FollowerId
+	}
+	return ""
+}
+
+func (x *FollowRequest) GetSellerId() string {
+	if x != nil {
+		return x.SellerId
+	}
+	return ""
+}
+
+type FollowStats struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	FollowersCount int64                  `protobuf:"varint,2,opt,name=followers_count,json=followersCount,proto3" json:"followers_count,omitempty"`
+	FollowingCount int64                  `protobuf:"varint,3,opt,name=following_count,json=followingCount,proto3" json:"following_count,omitempty"`
+	IsFollowing    bool                   `protobuf:"varint,4,opt,name=is_following,json=isFollowing,proto3" json:"is_following,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *FollowStats) Reset() {
+	*x = FollowStats{}
+	mi := &file_listing_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FollowStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FollowStats) ProtoMessage() {}
+
+func (x *FollowStats) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FollowStats.ProtoReflect.Descriptor instead.
+func (*FollowStats) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *FollowStats) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *FollowStats) GetFollowersCount() int64 {
+	if x != nil {
+		return x.FollowersCount
+	}
+	return 0
+}
+
+func (x *FollowStats) GetFollowingCount() int64 {
+	if x != nil {
+		return x.FollowingCount
+	}
+	return 0
+}
+
+func (x *FollowStats) GetIsFollowing() bool {
+	if x != nil {
+		return x.IsFollowing
+	}
+	return false
+}
+
+type Question struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	AskerId       string                 `protobuf:"bytes,3,opt,name=asker_id,json=askerId,proto3" json:"asker_id,omitempty"`
+	AskerLogin    string                 `protobuf:"bytes,4,opt,name=asker_login,json=askerLogin,proto3" json:"asker_login,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	Answer        string                 `protobuf:"bytes,6,opt,name=answer,proto3" json:"answer,omitempty"`
+	AnsweredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=answered_at,json=answeredAt,proto3" json:"answered_at,omitempty"`
+	Hidden        bool                   `protobuf:"varint,8,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Question) Reset() {
+	*x = Question{}
+	mi := &file_listing_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Question) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Question) ProtoMessage() {}
+
+func (x *Question) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Question.ProtoReflect.Descriptor instead.
+func (*Question) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *Question) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Question) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *Question) GetAskerId() string {
+	if x != nil {
+		return x.AskerId
+	}
+	return ""
+}
+
+func (x *Question) GetAskerLogin() string {
+	if x != nil {
+		return x.AskerLogin
+	}
+	return ""
+}
+
+func (x *Question) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Question) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *Question) GetAnsweredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AnsweredAt
+	}
+	return nil
+}
+
+func (x *Question) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+func (x *Question) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AskQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AskQuestionRequest) Reset() {
+	*x = AskQuestionRequest{}
+	mi := &file_listing_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AskQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AskQuestionRequest) ProtoMessage() {}
+
+func (x *AskQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AskQuestionRequest.ProtoReflect.Descriptor instead.
+func (*AskQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *AskQuestionRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *AskQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AskQuestionRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type AnswerQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerQuestionRequest) Reset() {
+	*x = AnswerQuestionRequest{}
+	mi := &file_listing_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerQuestionRequest) ProtoMessage() {}
+
+func (x *AnswerQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerQuestionRequest.ProtoReflect.Descriptor instead.
+func (*AnswerQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AnswerQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *AnswerQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AnswerQuestionRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type GetQuestionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionsRequest) Reset() {
+	*x = GetQuestionsRequest{}
+	mi := &file_listing_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionsRequest) ProtoMessage() {}
+
+func (x *GetQuestionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionsRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionsRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *GetQuestionsRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *GetQuestionsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetQuestionsRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type GetQuestionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questions     []*Question            `protobuf:"bytes,1,rep,name=questions,proto3" json:"questions,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	CurrentPage   int64                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionsResponse) Reset() {
+	*x = GetQuestionsResponse{}
+	mi := &file_listing_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionsResponse) ProtoMessage() {}
+
+func (x *GetQuestionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionsResponse.ProtoReflect.Descriptor instead.
+func (*GetQuestionsResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *GetQuestionsResponse) GetQuestions() []*Question {
+	if x != nil {
+		return x.Questions
+	}
+	return nil
+}
+
+func (x *GetQuestionsResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *GetQuestionsResponse) GetCurrentPage() int64 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+type HideQuestionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionId    string                 `protobuf:"bytes,1,opt,name=question_id,json=questionId,proto3" json:"question_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Hidden        bool                   `protobuf:"varint,3,opt,name=hidden,proto3" json:"hidden,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HideQuestionRequest) Reset() {
+	*x = HideQuestionRequest{}
+	mi := &file_listing_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HideQuestionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HideQuestionRequest) ProtoMessage() {}
+
+func (x *HideQuestionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HideQuestionRequest.ProtoReflect.Descriptor instead.
+func (*HideQuestionRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *HideQuestionRequest) GetQuestionId() string {
+	if x != nil {
+		return x.QuestionId
+	}
+	return ""
+}
+
+func (x *HideQuestionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *HideQuestionRequest) GetHidden() bool {
+	if x != nil {
+		return x.Hidden
+	}
+	return false
+}
+
+type SuggestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Limit         int64                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestRequest) Reset() {
+	*x = SuggestRequest{}
+	mi := &file_listing_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestRequest) ProtoMessage() {}
+
+func (x *SuggestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestRequest.ProtoReflect.Descriptor instead.
+func (*SuggestRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *SuggestRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *SuggestRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Suggestion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	ListingId     string                 `protobuf:"bytes,3,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Suggestion) Reset() {
+	*x = Suggestion{}
+	mi := &file_listing_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Suggestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Suggestion) ProtoMessage() {}
+
+func (x *Suggestion) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Suggestion.ProtoReflect.Descriptor instead.
+func (*Suggestion) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Suggestion) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Suggestion) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Suggestion) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+type SuggestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Suggestions   []*Suggestion          `protobuf:"bytes,1,rep,name=suggestions,proto3" json:"suggestions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SuggestResponse) Reset() {
+	*x = SuggestResponse{}
+	mi := &file_listing_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SuggestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuggestResponse) ProtoMessage() {}
+
+func (x *SuggestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuggestResponse.ProtoReflect.Descriptor instead.
+func (*SuggestResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *SuggestResponse) GetSuggestions() []*Suggestion {
+	if x != nil {
+		return x.Suggestions
+	}
+	return nil
+}
+
+type GetListingHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ListingId     string                 `protobuf:"bytes,1,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int64                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListingHistoryRequest) Reset() {
+	*x = GetListingHistoryRequest{}
+	mi := &file_listing_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListingHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListingHistoryRequest) ProtoMessage() {}
+
+func (x *GetListingHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListingHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetListingHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *GetListingHistoryRequest) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *GetListingHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetListingHistoryRequest) GetPage() int64 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Before        string                 `protobuf:"bytes,2,opt,name=before,proto3" json:"before,omitempty"`
+	After         string                 `protobuf:"bytes,3,opt,name=after,proto3" json:"after,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_listing_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *FieldChange) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+type HistoryEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ListingId     string                 `protobuf:"bytes,2,opt,name=listing_id,json=listingId,proto3" json:"listing_id,omitempty"`
+	ActorId       string                 `protobuf:"bytes,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"`
+	ActorLogin    string                 `protobuf:"bytes,4,opt,name=actor_login,json=actorLogin,proto3" json:"actor_login,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	Changes       []*FieldChange         `protobuf:"bytes,6,rep,name=changes,proto3" json:"changes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HistoryEntry) Reset() {
+	*x = HistoryEntry{}
+	mi := &file_listing_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HistoryEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistoryEntry) ProtoMessage() {}
+
+func (x *HistoryEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistoryEntry.ProtoReflect.Descriptor instead.
+func (*HistoryEntry) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *HistoryEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetListingId() string {
+	if x != nil {
+		return x.ListingId
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetActorId() string {
+	if x != nil {
+		return x.ActorId
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetActorLogin() string {
+	if x != nil {
+		return x.ActorLogin
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *HistoryEntry) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *HistoryEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetListingHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*HistoryEntry        `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	TotalPages    int64                  `protobuf:"varint,2,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	CurrentPage   int64                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListingHistoryResponse) Reset() {
+	*x = GetListingHistoryResponse{}
+	mi := &file_listing_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListingHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListingHistoryResponse) ProtoMessage() {}
+
+func (x *GetListingHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_listing_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListingHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetListingHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_listing_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *GetListingHistoryResponse) GetEntries() []*HistoryEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetListingHistoryResponse) GetTotalPages() int64 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *GetListingHistoryResponse) GetCurrentPage() int64 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+var File_listing_proto protoreflect.FileDescriptor
+
+const file_listing_proto_rawDesc = "" +
+	"\n" +
+	"\rlisting.proto\x12\tlistingpb\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
+	"\x05Empty\"\xf8\x04\n" +
 	"\aListing\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -746,7 +5083,19 @@ const file_listing_proto_rawDesc = "" +
 	"\bis_liked\x18\n" +
 	" \x01(\bR\aisLiked\x12\x19\n" +
 	"\bis_yours\x18\v \x01(\bR\aisYours\x12!\n" +
-	"\fauthor_login\x18\f \x01(\tR\vauthorLogin\"\x81\x02\n" +
+	"\fauthor_login\x18\f \x01(\tR\vauthorLogin\x129\n" +
+	"\n" +
+	"deleted_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1f\n" +
+	"\vcategory_id\x18\x0e \x01(\x03R\n" +
+	"categoryId\x12B\n" +
+	"\n" +
+	"attributes\x18\x0f \x03(\v2\".listingpb.Listing.AttributesEntryR\n" +
+	"attributes\x12\x16\n" +
+	"\x06status\x18\x10 \x01(\tR\x06status\x12\x1a\n" +
+	"\bpromoted\x18\x11 \x01(\bR\bpromoted\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xdf\x05\n" +
 	"\x15GetAllListingsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
 	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\x12\x1d\n" +
@@ -758,21 +5107,61 @@ const file_listing_proto_rawDesc = "" +
 	"only_liked\x18\x05 \x01(\bR\tonlyLiked\x12\x12\n" +
 	"\x04page\x18\x06 \x01(\x03R\x04page\x12\x1b\n" +
 	"\tmin_price\x18\a \x01(\x03R\bminPrice\x12\x1b\n" +
-	"\tmax_price\x18\b \x01(\x03R\bmaxPrice\"\x8c\x01\n" +
+	"\tmax_price\x18\b \x01(\x03R\bmaxPrice\x12\x1f\n" +
+	"\vcategory_id\x18\t \x01(\x03R\n" +
+	"categoryId\x12G\n" +
+	"\x11attribute_filters\x18\n" +
+	" \x03(\v2\x1a.listingpb.AttributeFilterR\x10attributeFilters\x12#\n" +
+	"\rcollection_id\x18\v \x01(\tR\fcollectionId\x12\x1f\n" +
+	"\vshare_token\x18\f \x01(\tR\n" +
+	"shareToken\x12\x1c\n" +
+	"\tfollowing\x18\r \x01(\bR\tfollowing\x12\x1b\n" +
+	"\tpage_size\x18\x0e \x01(\x03R\bpageSize\x12\x14\n" +
+	"\x05query\x18\x0f \x01(\tR\x05query\x12?\n" +
+	"\rcreated_after\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12\x1d\n" +
+	"\n" +
+	"author_ids\x18\x12 \x03(\tR\tauthorIds\x12\x1b\n" +
+	"\tmin_likes\x18\x13 \x01(\x03R\bminLikes\x12\x1b\n" +
+	"\thas_image\x18\x14 \x01(\bR\bhasImage\"K\n" +
+	"\x11GetListingRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x88\x01\n" +
+	"\x0fAttributeFilter\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x19\n" +
+	"\x05value\x18\x02 \x01(\tH\x00R\x05value\x88\x01\x01\x12\x15\n" +
+	"\x03min\x18\x03 \x01(\x03H\x01R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x04 \x01(\x03H\x02R\x03max\x88\x01\x01B\b\n" +
+	"\x06_valueB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\x8c\x01\n" +
 	"\x16GetAllListingsResponse\x12.\n" +
 	"\blistings\x18\x01 \x03(\v2\x12.listingpb.ListingR\blistings\x12\x1f\n" +
 	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
 	"totalPages\x12!\n" +
-	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage\"\xb5\x01\n" +
+	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage\"\xb8\x03\n" +
 	"\x11AddListingRequest\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
 	"\aaddress\x18\x03 \x01(\tR\aaddress\x12\x14\n" +
 	"\x05price\x18\x04 \x01(\x03R\x05price\x12\x1b\n" +
 	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x12\x1b\n" +
-	"\timage_url\x18\x06 \x01(\tR\bimageUrl\"$\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x1f\n" +
+	"\vcategory_id\x18\a \x01(\x03R\n" +
+	"categoryId\x12L\n" +
+	"\n" +
+	"attributes\x18\b \x03(\v2,.listingpb.AddListingRequest.AttributesEntryR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"image_hash\x18\t \x01(\x04R\timageHash\x124\n" +
+	"\aauction\x18\n" +
+	" \x01(\v2\x1a.listingpb.AuctionSettingsR\aauction\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"$\n" +
 	"\x12AddListingResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xc2\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x90\x03\n" +
 	"\x12EditListingRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -780,7 +5169,18 @@ const file_listing_proto_rawDesc = "" +
 	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x14\n" +
 	"\x05price\x18\x05 \x01(\x03R\x05price\x12\x1b\n" +
 	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x17\n" +
-	"\auser_id\x18\a \x01(\tR\x06userId\"?\n" +
+	"\auser_id\x18\a \x01(\tR\x06userId\x12\x1f\n" +
+	"\vcategory_id\x18\b \x01(\x03R\n" +
+	"categoryId\x12M\n" +
+	"\n" +
+	"attributes\x18\t \x03(\v2-.listingpb.EditListingRequest.AttributesEntryR\n" +
+	"attributes\x12\x1d\n" +
+	"\n" +
+	"image_hash\x18\n" +
+	" \x01(\x04R\timageHash\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
 	"\x14DeleteListingRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\"H\n" +
@@ -791,16 +5191,416 @@ const file_listing_proto_rawDesc = "" +
 	"\x11RemoveLikeRequest\x12\x1d\n" +
 	"\n" +
 	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId2\xac\x03\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\">\n" +
+	"\x0fGetTrashRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\"@\n" +
+	"\x15RestoreListingRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xc1\x01\n" +
+	"\tImportRow\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\aaddress\x18\x04 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x03R\x05price\x12\x1b\n" +
+	"\timage_url\x18\x06 \x01(\tR\bimageUrl\x12\x1d\n" +
+	"\n" +
+	"image_hash\x18\a \x01(\x04R\timageHash\"<\n" +
+	"\x0eImportRowError\x12\x10\n" +
+	"\x03row\x18\x01 \x01(\x03R\x03row\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x8d\x01\n" +
+	"\x16CreateImportJobRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x02 \x01(\x03R\ttotalRows\x121\n" +
+	"\x06errors\x18\x04 \x03(\v2\x19.listingpb.ImportRowErrorR\x06errorsJ\x04\b\x03\x10\x04\"\xc0\x01\n" +
+	"\x17AppendImportRowsRequest\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\x12\x1b\n" +
+	"\tauthor_id\x18\x02 \x01(\tR\bauthorId\x12(\n" +
+	"\x04rows\x18\x03 \x03(\v2\x14.listingpb.ImportRowR\x04rows\x121\n" +
+	"\x06errors\x18\x04 \x03(\v2\x19.listingpb.ImportRowErrorR\x06errors\x12\x14\n" +
+	"\x05final\x18\x05 \x01(\bR\x05final\"M\n" +
+	"\x18AppendImportRowsResponse\x121\n" +
+	"\x06errors\x18\x01 \x03(\v2\x19.listingpb.ImportRowErrorR\x06errors\">\n" +
+	"\x13GetImportJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xea\x02\n" +
+	"\tImportJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"total_rows\x18\x03 \x01(\x03R\ttotalRows\x12%\n" +
+	"\x0eprocessed_rows\x18\x04 \x01(\x03R\rprocessedRows\x12#\n" +
+	"\rimported_rows\x18\x05 \x01(\x03R\fimportedRows\x12\x1f\n" +
+	"\vfailed_rows\x18\x06 \x01(\x03R\n" +
+	"failedRows\x121\n" +
+	"\x06errors\x18\a \x03(\v2\x19.listingpb.ImportRowErrorR\x06errors\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12;\n" +
+	"\vfinished_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\"K\n" +
+	"\x10FeedTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1e\n" +
+	"\n" +
+	"regenerate\x18\x02 \x01(\bR\n" +
+	"regenerate\")\n" +
+	"\x11FeedTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x88\x01\n" +
+	"\x11StreamFeedRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x128\n" +
+	"\x06filter\x18\x05 \x01(\v2 .listingpb.GetAllListingsRequestR\x06filterJ\x04\b\x03\x10\x04J\x04\b\x04\x10\x05\"v\n" +
+	"\x0fAttributeSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1f\n" +
+	"\venum_values\x18\x03 \x03(\tR\n" +
+	"enumValues\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\"j\n" +
+	"\bCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12:\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2\x1a.listingpb.AttributeSchemaR\n" +
+	"attributes\"L\n" +
+	"\x15GetCategoriesResponse\x123\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x13.listingpb.CategoryR\n" +
+	"categories\"\x9b\x01\n" +
+	"\x17GetListingFacetsRequest\x128\n" +
+	"\x06filter\x18\x01 \x01(\v2 .listingpb.GetAllListingsRequestR\x06filter\x12!\n" +
+	"\fprice_bounds\x18\x02 \x03(\x03R\vpriceBounds\x12#\n" +
+	"\rprice_buckets\x18\x03 \x01(\x03R\fpriceBuckets\"S\n" +
+	"\vPriceBucket\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\x03R\x04from\x12\x13\n" +
+	"\x02to\x18\x02 \x01(\x03H\x00R\x02to\x88\x01\x01\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05countB\x05\n" +
+	"\x03_to\"Z\n" +
+	"\rCategoryFacet\x12\x1f\n" +
+	"\vcategory_id\x18\x01 \x01(\x03R\n" +
+	"categoryId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\";\n" +
+	"\vStatusFacet\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\x8e\x02\n" +
+	"\rListingFacets\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12\x1b\n" +
+	"\tmin_price\x18\x02 \x01(\x03R\bminPrice\x12\x1b\n" +
+	"\tmax_price\x18\x03 \x01(\x03R\bmaxPrice\x12?\n" +
+	"\x0fprice_histogram\x18\x04 \x03(\v2\x16.listingpb.PriceBucketR\x0epriceHistogram\x128\n" +
+	"\n" +
+	"categories\x18\x05 \x03(\v2\x18.listingpb.CategoryFacetR\n" +
+	"categories\x122\n" +
+	"\bstatuses\x18\x06 \x03(\v2\x16.listingpb.StatusFacetR\bstatuses\"Z\n" +
+	"\x19GetSimilarListingsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x03R\x05limit\"J\n" +
+	"\x19GetRecommendationsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"K\n" +
+	"\x11RecordViewRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xd2\x01\n" +
+	"\x16CreatePromotionRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x127\n" +
+	"\tstarts_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"\x8f\x02\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x127\n" +
+	"\tstarts_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"/\n" +
+	"\x14GetPromotionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"M\n" +
+	"\x15GetPromotionsResponse\x124\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x14.listingpb.PromotionR\n" +
+	"promotions\"C\n" +
+	"\x14GetDuplicatesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x03R\x04page\"\xbf\x04\n" +
+	"\x0eDuplicateMatch\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12#\n" +
+	"\rlisting_title\x18\x02 \x01(\tR\flistingTitle\x12*\n" +
+	"\x11listing_author_id\x18\x03 \x01(\tR\x0flistingAuthorId\x12'\n" +
+	"\x0flisting_deleted\x18\x04 \x01(\bR\x0elistingDeleted\x12&\n" +
+	"\x0fduplicate_of_id\x18\x05 \x01(\tR\rduplicateOfId\x12,\n" +
+	"\x12duplicate_of_title\x18\x06 \x01(\tR\x10duplicateOfTitle\x123\n" +
+	"\x16duplicate_of_author_id\x18\a \x01(\tR\x13duplicateOfAuthorId\x120\n" +
+	"\x14duplicate_of_deleted\x18\b \x01(\bR\x12duplicateOfDeleted\x12\x1f\n" +
+	"\vsame_author\x18\t \x01(\bR\n" +
+	"sameAuthor\x12*\n" +
+	"\x0eimage_distance\x18\n" +
+	" \x01(\x03H\x00R\rimageDistance\x88\x01\x01\x12(\n" +
+	"\rtext_distance\x18\v \x01(\x03H\x01R\ftextDistance\x88\x01\x01\x12;\n" +
+	"\vdetected_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"detectedAtB\x11\n" +
+	"\x0f_image_distanceB\x10\n" +
+	"\x0e_text_distance\"\x90\x01\n" +
+	"\x15GetDuplicatesResponse\x123\n" +
+	"\amatches\x18\x01 \x03(\v2\x19.listingpb.DuplicateMatchR\amatches\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage\"\xb7\x01\n" +
+	"\x10MakeOfferRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xce\x01\n" +
+	"\x13RespondOfferRequest\x12\x19\n" +
+	"\boffer_id\x18\x01 \x01(\tR\aofferId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x03R\x06amount\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"\xee\x03\n" +
+	"\x05Offer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12\x19\n" +
+	"\bbuyer_id\x18\x03 \x01(\tR\abuyerId\x12\x1b\n" +
+	"\tseller_id\x18\x04 \x01(\tR\bsellerId\x12\x1b\n" +
+	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12\x1f\n" +
+	"\vfrom_seller\x18\x06 \x01(\bR\n" +
+	"fromSeller\x12\x16\n" +
+	"\x06amount\x18\a \x01(\x03R\x06amount\x12\x18\n" +
+	"\amessage\x18\b \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x129\n" +
+	"\n" +
+	"expires_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fresponded_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\vrespondedAt\x12A\n" +
+	"\x0ereserved_until\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\rreservedUntil\"J\n" +
+	"\x10GetOffersRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\"=\n" +
+	"\x11GetOffersResponse\x12(\n" +
+	"\x06offers\x18\x01 \x03(\v2\x10.listingpb.OfferR\x06offers\"\x8c\x01\n" +
+	"\x0fAuctionSettings\x12\x1f\n" +
+	"\vstart_price\x18\x01 \x01(\x03R\n" +
+	"startPrice\x12#\n" +
+	"\rmin_increment\x18\x02 \x01(\x03R\fminIncrement\x123\n" +
+	"\aends_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\"\x85\x01\n" +
+	"\x03Bid\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tbidder_id\x18\x02 \x01(\tR\bbidderId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc6\x03\n" +
+	"\aAuction\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x1f\n" +
+	"\vstart_price\x18\x02 \x01(\x03R\n" +
+	"startPrice\x12#\n" +
+	"\rmin_increment\x18\x03 \x01(\x03R\fminIncrement\x12(\n" +
+	"\rcurrent_price\x18\x04 \x01(\x03H\x00R\fcurrentPrice\x88\x01\x01\x12\x17\n" +
+	"\amin_bid\x18\x05 \x01(\x03R\x06minBid\x12\x1b\n" +
+	"\tleader_id\x18\x06 \x01(\tR\bleaderId\x12\x1b\n" +
+	"\twinner_id\x18\a \x01(\tR\bwinnerId\x12\x1d\n" +
+	"\n" +
+	"bids_count\x18\b \x01(\x03R\tbidsCount\x12\x16\n" +
+	"\x06status\x18\t \x01(\tR\x06status\x123\n" +
+	"\aends_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x127\n" +
+	"\tclosed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bclosedAt\x12\"\n" +
+	"\x04bids\x18\f \x03(\v2\x0e.listingpb.BidR\x04bidsB\x10\n" +
+	"\x0e_current_price\"a\n" +
+	"\x0fPlaceBidRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\"2\n" +
+	"\x11GetAuctionRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\"\xf7\x01\n" +
+	"\n" +
+	"Collection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1f\n" +
+	"\vshare_token\x18\x04 \x01(\tR\n" +
+	"shareToken\x12%\n" +
+	"\x0elistings_count\x18\x05 \x01(\x03R\rlistingsCount\x12)\n" +
+	"\x10contains_listing\x18\x06 \x01(\bR\x0fcontainsListing\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"e\n" +
+	"\x11CollectionRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\"O\n" +
+	"\x15GetCollectionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\"Q\n" +
+	"\x16GetCollectionsResponse\x127\n" +
+	"\vcollections\x18\x01 \x03(\v2\x15.listingpb.CollectionR\vcollections\"t\n" +
+	"\x15CollectionItemRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x03 \x01(\tR\tlistingId\"n\n" +
+	"\x16ShareCollectionRequest\x12#\n" +
+	"\rcollection_id\x18\x01 \x01(\tR\fcollectionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06shared\x18\x03 \x01(\bR\x06shared\"=\n" +
+	"\x1aGetSharedCollectionRequest\x12\x1f\n" +
+	"\vshare_token\x18\x01 \x01(\tR\n" +
+	"shareToken\"M\n" +
+	"\rFollowRequest\x12\x1f\n" +
+	"\vfollower_id\x18\x01 \x01(\tR\n" +
+	"followerId\x12\x1b\n" +
+	"\tseller_id\x18\x02 \x01(\tR\bsellerId\"\x9b\x01\n" +
+	"\vFollowStats\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0ffollowers_count\x18\x02 \x01(\x03R\x0efollowersCount\x12'\n" +
+	"\x0ffollowing_count\x18\x03 \x01(\x03R\x0efollowingCount\x12!\n" +
+	"\fis_following\x18\x04 \x01(\bR\visFollowing\"\xb1\x02\n" +
+	"\bQuestion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12\x19\n" +
+	"\basker_id\x18\x03 \x01(\tR\aaskerId\x12\x1f\n" +
+	"\vasker_login\x18\x04 \x01(\tR\n" +
+	"askerLogin\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12\x16\n" +
+	"\x06answer\x18\x06 \x01(\tR\x06answer\x12;\n" +
+	"\vanswered_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"answeredAt\x12\x16\n" +
+	"\x06hidden\x18\b \x01(\bR\x06hidden\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"`\n" +
+	"\x12AskQuestionRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"e\n" +
+	"\x15AnswerQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"a\n" +
+	"\x13GetQuestionsRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\"\x8d\x01\n" +
+	"\x14GetQuestionsResponse\x121\n" +
+	"\tquestions\x18\x01 \x03(\v2\x13.listingpb.QuestionR\tquestions\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage\"g\n" +
+	"\x13HideQuestionRequest\x12\x1f\n" +
+	"\vquestion_id\x18\x01 \x01(\tR\n" +
+	"questionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06hidden\x18\x03 \x01(\bR\x06hidden\">\n" +
+	"\x0eSuggestRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\"S\n" +
+	"\n" +
+	"Suggestion\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x03 \x01(\tR\tlistingId\"J\n" +
+	"\x0fSuggestResponse\x127\n" +
+	"\vsuggestions\x18\x01 \x03(\v2\x15.listingpb.SuggestionR\vsuggestions\"f\n" +
+	"\x18GetListingHistoryRequest\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x01 \x01(\tR\tlistingId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x03R\x04page\"Q\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06before\x18\x02 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x03 \x01(\tR\x05after\"\xfe\x01\n" +
+	"\fHistoryEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"listing_id\x18\x02 \x01(\tR\tlistingId\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\tR\aactorId\x12\x1f\n" +
+	"\vactor_login\x18\x04 \x01(\tR\n" +
+	"actorLogin\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x120\n" +
+	"\achanges\x18\x06 \x03(\v2\x16.listingpb.FieldChangeR\achanges\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x92\x01\n" +
+	"\x19GetListingHistoryResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.listingpb.HistoryEntryR\aentries\x12\x1f\n" +
+	"\vtotal_pages\x18\x02 \x01(\x03R\n" +
+	"totalPages\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x03R\vcurrentPage2\xb6\x19\n" +
 	"\x0eListingService\x12U\n" +
-	"\x0eGetAllListings\x12 .listingpb.GetAllListingsRequest\x1a!.listingpb.GetAllListingsResponse\x12I\n" +
+	"\x0eGetAllListings\x12 .listingpb.GetAllListingsRequest\x1a!.listingpb.GetAllListingsResponse\x12>\n" +
+	"\n" +
+	"GetListing\x12\x1c.listingpb.GetListingRequest\x1a\x12.listingpb.Listing\x12I\n" +
 	"\n" +
 	"AddListing\x12\x1c.listingpb.AddListingRequest\x1a\x1d.listingpb.AddListingResponse\x12>\n" +
 	"\vEditListing\x12\x1d.listingpb.EditListingRequest\x1a\x10.listingpb.Empty\x12B\n" +
 	"\rDeleteListing\x12\x1f.listingpb.DeleteListingRequest\x1a\x10.listingpb.Empty\x126\n" +
 	"\aAddLike\x12\x19.listingpb.AddLikeRequest\x1a\x10.listingpb.Empty\x12<\n" +
 	"\n" +
-	"RemoveLike\x12\x1c.listingpb.RemoveLikeRequest\x1a\x10.listingpb.EmptyB\fZ\n" +
+	"RemoveLike\x12\x1c.listingpb.RemoveLikeRequest\x1a\x10.listingpb.Empty\x12I\n" +
+	"\bGetTrash\x12\x1a.listingpb.GetTrashRequest\x1a!.listingpb.GetAllListingsResponse\x12D\n" +
+	"\x0eRestoreListing\x12 .listingpb.RestoreListingRequest\x1a\x10.listingpb.Empty\x12J\n" +
+	"\x0fCreateImportJob\x12!.listingpb.CreateImportJobRequest\x1a\x14.listingpb.ImportJob\x12[\n" +
+	"\x10AppendImportRows\x12\".listingpb.AppendImportRowsRequest\x1a#.listingpb.AppendImportRowsResponse\x12D\n" +
+	"\fGetImportJob\x12\x1e.listingpb.GetImportJobRequest\x1a\x14.listingpb.ImportJob\x12I\n" +
+	"\fGetFeedToken\x12\x1b.listingpb.FeedTokenRequest\x1a\x1c.listingpb.FeedTokenResponse\x12@\n" +
+	"\n" +
+	"StreamFeed\x12\x1c.listingpb.StreamFeedRequest\x1a\x12.listingpb.Listing0\x01\x12C\n" +
+	"\rGetCategories\x12\x10.listingpb.Empty\x1a .listingpb.GetCategoriesResponse\x12P\n" +
+	"\x10GetListingFacets\x12\".listingpb.GetListingFacetsRequest\x1a\x18.listingpb.ListingFacets\x12]\n" +
+	"\x12GetSimilarListings\x12$.listingpb.GetSimilarListingsRequest\x1a!.listingpb.GetAllListingsResponse\x12]\n" +
+	"\x12GetRecommendations\x12$.listingpb.GetRecommendationsRequest\x1a!.listingpb.GetAllListingsResponse\x12<\n" +
+	"\n" +
+	"RecordView\x12\x1c.listingpb.RecordViewRequest\x1a\x10.listingpb.Empty\x12J\n" +
+	"\x0fCreatePromotion\x12!.listingpb.CreatePromotionRequest\x1a\x14.listingpb.Promotion\x12R\n" +
+	"\rGetPromotions\x12\x1f.listingpb.GetPromotionsRequest\x1a .listingpb.GetPromotionsResponse\x12R\n" +
+	"\rGetDuplicates\x12\x1f.listingpb.GetDuplicatesRequest\x1a .listingpb.GetDuplicatesResponse\x12:\n" +
+	"\tMakeOffer\x12\x1b.listingpb.MakeOfferRequest\x1a\x10.listingpb.Offer\x12@\n" +
+	"\fRespondOffer\x12\x1e.listingpb.RespondOfferRequest\x1a\x10.listingpb.Offer\x12F\n" +
+	"\tGetOffers\x12\x1b.listingpb.GetOffersRequest\x1a\x1c.listingpb.GetOffersResponse\x12:\n" +
+	"\bPlaceBid\x12\x1a.listingpb.PlaceBidRequest\x1a\x12.listingpb.Auction\x12>\n" +
+	"\n" +
+	"GetAuction\x12\x1c.listingpb.GetAuctionRequest\x1a\x12.listingpb.Auction\x12G\n" +
+	"\x10CreateCollection\x12\x1c.listingpb.CollectionRequest\x1a\x15.listingpb.Collection\x12G\n" +
+	"\x10RenameCollection\x12\x1c.listingpb.CollectionRequest\x1a\x15.listingpb.Collection\x12B\n" +
+	"\x10DeleteCollection\x12\x1c.listingpb.CollectionRequest\x1a\x10.listingpb.Empty\x12U\n" +
+	"\x0eGetCollections\x12 .listingpb.GetCollectionsRequest\x1a!.listingpb.GetCollectionsResponse\x12E\n" +
+	"\x0fAddToCollection\x12 .listingpb.CollectionItemRequest\x1a\x10.listingpb.Empty\x12J\n" +
+	"\x14RemoveFromCollection\x12 .listingpb.CollectionItemRequest\x1a\x10.listingpb.Empty\x12K\n" +
+	"\x0fShareCollection\x12!.listingpb.ShareCollectionRequest\x1a\x15.listingpb.Collection\x12S\n" +
+	"\x13GetSharedCollection\x12%.listingpb.GetSharedCollectionRequest\x1a\x15.listingpb.Collection\x12>\n" +
+	"\n" +
+	"FollowUser\x12\x18.listingpb.FollowRequest\x1a\x16.listingpb.FollowStats\x12@\n" +
+	"\fUnfollowUser\x12\x18.listingpb.FollowRequest\x1a\x16.listingpb.FollowStats\x12B\n" +
+	"\x0eGetFollowStats\x12\x18.listingpb.FollowRequest\x1a\x16.listingpb.FollowStats\x12A\n" +
+	"\vAskQuestion\x12\x1d.listingpb.AskQuestionRequest\x1a\x13.listingpb.Question\x12G\n" +
+	"\x0eAnswerQuestion\x12 .listingpb.AnswerQuestionRequest\x1a\x13.listingpb.Question\x12O\n" +
+	"\fGetQuestions\x12\x1e.listingpb.GetQuestionsRequest\x1a\x1f.listingpb.GetQuestionsResponse\x12C\n" +
+	"\fHideQuestion\x12\x1e.listingpb.HideQuestionRequest\x1a\x13.listingpb.Question\x12H\n" +
+	"\x0fSuggestListings\x12\x19.listingpb.SuggestRequest\x1a\x1a.listingpb.SuggestResponse\x12^\n" +
+	"\x11GetListingHistory\x12#.listingpb.GetListingHistoryRequest\x1a$.listingpb.GetListingHistoryResponseB\fZ\n" +
 	"/listingpbb\x06proto3"
 
 var (
@@ -815,40 +5615,234 @@ func file_listing_proto_rawDescGZIP() []byte {
 	return file_listing_proto_rawDescData
 }
 
-var file_listing_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_listing_proto_msgTypes = make([]protoimpl.MessageInfo, 77)
 var file_listing_proto_goTypes = []any{
-	(*Empty)(nil),                  // 0: listingpb.Empty
-	(*Listing)(nil),                // 1: listingpb.Listing
-	(*GetAllListingsRequest)(nil),  // 2: listingpb.GetAllListingsRequest
-	(*GetAllListingsResponse)(nil), // 3: listingpb.GetAllListingsResponse
-	(*AddListingRequest)(nil),      // 4: listingpb.AddListingRequest
-	(*AddListingResponse)(nil),     // 5: listingpb.AddListingResponse
-	(*EditListingRequest)(nil),     // 6: listingpb.EditListingRequest
-	(*DeleteListingRequest)(nil),   // 7: listingpb.DeleteListingRequest
-	(*AddLikeRequest)(nil),         // 8: listingpb.AddLikeRequest
-	(*RemoveLikeRequest)(nil),      // 9: listingpb.RemoveLikeRequest
-	(*timestamppb.Timestamp)(nil),  // 10: google.protobuf.Timestamp
+	(*Empty)(nil),                      // 0: listingpb.Empty
+	(*Listing)(nil),                    // 1: listingpb.Listing
+	(*GetAllListingsRequest)(nil),      // 2: listingpb.GetAllListingsRequest
+	(*GetListingRequest)(nil),          // 3: listingpb.GetListingRequest
+	(*AttributeFilter)(nil),            // 4: listingpb.AttributeFilter
+	(*GetAllListingsResponse)(nil),     // 5: listingpb.GetAllListingsResponse
+	(*AddListingRequest)(nil),          // 6: listingpb.AddListingRequest
+	(*AddListingResponse)(nil),         // 7: listingpb.AddListingResponse
+	(*EditListingRequest)(nil),         // 8: listingpb.EditListingRequest
+	(*DeleteListingRequest)(nil),       // 9: listingpb.DeleteListingRequest
+	(*AddLikeRequest)(nil),             // 10: listingpb.AddLikeRequest
+	(*RemoveLikeRequest)(nil),          // 11: listingpb.RemoveLikeRequest
+	(*GetTrashRequest)(nil),            // 12: listingpb.GetTrashRequest
+	(*RestoreListingRequest)(nil),      // 13: listingpb.RestoreListingRequest
+	(*ImportRow)(nil),                  // 14: listingpb.ImportRow
+	(*ImportRowError)(nil),             // 15: listingpb.ImportRowError
+	(*CreateImportJobRequest)(nil),     // 16: listingpb.CreateImportJobRequest
+	(*AppendImportRowsRequest)(nil),    // 17: listingpb.AppendImportRowsRequest
+	(*AppendImportRowsResponse)(nil),   // 18: listingpb.AppendImportRowsResponse
+	(*GetImportJobRequest)(nil),        // 19: listingpb.GetImportJobRequest
+	(*ImportJob)(nil),                  // 20: listingpb.ImportJob
+	(*FeedTokenRequest)(nil),           // 21: listingpb.FeedTokenRequest
+	(*FeedTokenResponse)(nil),          // 22: listingpb.FeedTokenResponse
+	(*StreamFeedRequest)(nil),          // 23: listingpb.StreamFeedRequest
+	(*AttributeSchema)(nil),            // 24: listingpb.AttributeSchema
+	(*Category)(nil),                   // 25: listingpb.Category
+	(*GetCategoriesResponse)(nil),      // 26: listingpb.GetCategoriesResponse
+	(*GetListingFacetsRequest)(nil),    // 27: listingpb.GetListingFacetsRequest
+	(*PriceBucket)(nil),                // 28: listingpb.PriceBucket
+	(*CategoryFacet)(nil),              // 29: listingpb.CategoryFacet
+	(*StatusFacet)(nil),                // 30: listingpb.StatusFacet
+	(*ListingFacets)(nil),              // 31: listingpb.ListingFacets
+	(*GetSimilarListingsRequest)(nil),  // 32: listingpb.GetSimilarListingsRequest
+	(*GetRecommendationsRequest)(nil),  // 33: listingpb.GetRecommendationsRequest
+	(*RecordViewRequest)(nil),          // 34: listingpb.RecordViewRequest
+	(*CreatePromotionRequest)(nil),     // 35: listingpb.CreatePromotionRequest
+	(*Promotion)(nil),                  // 36: listingpb.Promotion
+	(*GetPromotionsRequest)(nil),       // 37: listingpb.GetPromotionsRequest
+	(*GetPromotionsResponse)(nil),      // 38: listingpb.GetPromotionsResponse
+	(*GetDuplicatesRequest)(nil),       // 39: listingpb.GetDuplicatesRequest
+	(*DuplicateMatch)(nil),             // 40: listingpb.DuplicateMatch
+	(*GetDuplicatesResponse)(nil),      // 41: listingpb.GetDuplicatesResponse
+	(*MakeOfferRequest)(nil),           // 42: listingpb.MakeOfferRequest
+	(*RespondOfferRequest)(nil),        // 43: listingpb.RespondOfferRequest
+	(*Offer)(nil),                      // 44: listingpb.Offer
+	(*GetOffersRequest)(nil),           // 45: listingpb.GetOffersRequest
+	(*GetOffersResponse)(nil),          // 46: listingpb.GetOffersResponse
+	(*AuctionSettings)(nil),            // 47: listingpb.AuctionSettings
+	(*Bid)(nil),                        // 48: listingpb.Bid
+	(*Auction)(nil),                    // 49: listingpb.Auction
+	(*PlaceBidRequest)(nil),            // 50: listingpb.PlaceBidRequest
+	(*GetAuctionRequest)(nil),          // 51: listingpb.GetAuctionRequest
+	(*Collection)(nil),                 // 52: listingpb.Collection
+	(*CollectionRequest)(nil),          // 53: listingpb.CollectionRequest
+	(*GetCollectionsRequest)(nil),      // 54: listingpb.GetCollectionsRequest
+	(*GetCollectionsResponse)(nil),     // 55: listingpb.GetCollectionsResponse
+	(*CollectionItemRequest)(nil),      // 56: listingpb.CollectionItemRequest
+	(*ShareCollectionRequest)(nil),     // 57: listingpb.ShareCollectionRequest
+	(*GetSharedCollectionRequest)(nil), // 58: listingpb.GetSharedCollectionRequest
+	(*FollowRequest)(nil),              // 59: listingpb.FollowRequest
+	(*FollowStats)(nil),                // 60: listingpb.FollowStats
+	(*Question)(nil),                   // 61: listingpb.Question
+	(*AskQuestionRequest)(nil),         // 62: listingpb.AskQuestionRequest
+	(*AnswerQuestionRequest)(nil),      // 63: listingpb.AnswerQuestionRequest
+	(*GetQuestionsRequest)(nil),        // 64: listingpb.GetQuestionsRequest
+	(*GetQuestionsResponse)(nil),       // 65: listingpb.GetQuestionsResponse
+	(*HideQuestionRequest)(nil),        // 66: listingpb.HideQuestionRequest
+	(*SuggestRequest)(nil),             // 67: listingpb.SuggestRequest
+	(*Suggestion)(nil),                 // 68: listingpb.Suggestion
+	(*SuggestResponse)(nil),            // 69: listingpb.SuggestResponse
+	(*GetListingHistoryRequest)(nil),   // 70: listingpb.GetListingHistoryRequest
+	(*FieldChange)(nil),                // 71: listingpb.FieldChange
+	(*HistoryEntry)(nil),               // 72: listingpb.HistoryEntry
+	(*GetListingHistoryResponse)(nil),  // 73: listingpb.GetListingHistoryResponse
+	nil,                                // 74: listingpb.Listing.AttributesEntry
+	nil,                                // 75: listingpb.AddListingRequest.AttributesEntry
+	nil,                                // 76: listingpb.EditListingRequest.AttributesEntry
+	(*timestamppb.Timestamp)(nil),      // 77: google.protobuf.Timestamp
 }
 var file_listing_proto_depIdxs = []int32{
-	10, // 0: listingpb.Listing.created_at:type_name -> google.protobuf.Timestamp
-	1,  // 1: listingpb.GetAllListingsResponse.listings:type_name -> listingpb.Listing
-	2,  // 2: listingpb.ListingService.GetAllListings:input_type -> listingpb.GetAllListingsRequest
-	4,  // 3: listingpb.ListingService.AddListing:input_type -> listingpb.AddListingRequest
-	6,  // 4: listingpb.ListingService.EditListing:input_type -> listingpb.EditListingRequest
-	7,  // 5: listingpb.ListingService.DeleteListing:input_type -> listingpb.DeleteListingRequest
-	8,  // 6: listingpb.ListingService.AddLike:input_type -> listingpb.AddLikeRequest
-	9,  // 7: listingpb.ListingService.RemoveLike:input_type -> listingpb.RemoveLikeRequest
-	3,  // 8: listingpb.ListingService.GetAllListings:output_type -> listingpb.GetAllListingsResponse
-	5,  // 9: listingpb.ListingService.AddListing:output_type -> listingpb.AddListingResponse
-	0,  // 10: listingpb.ListingService.EditListing:output_type -> listingpb.Empty
-	0,  // 11: listingpb.ListingService.DeleteListing:output_type -> listingpb.Empty
-	0,  // 12: listingpb.ListingService.AddLike:output_type -> listingpb.Empty
-	0,  // 13: listingpb.ListingService.RemoveLike:output_type -> listingpb.Empty
-	8,  // [8:14] is the sub-list for method output_type
-	2,  // [2:8] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	77, // 0: listingpb.Listing.created_at:type_name -> google.protobuf.Timestamp
+	77, // 1: listingpb.Listing.deleted_at:type_name -> google.protobuf.Timestamp
+	74, // 2: listingpb.Listing.attributes:type_name -> listingpb.Listing.AttributesEntry
+	4,  // 3: listingpb.GetAllListingsRequest.attribute_filters:type_name -> listingpb.AttributeFilter
+	77, // 4: listingpb.GetAllListingsRequest.created_after:type_name -> google.protobuf.Timestamp
+	77, // 5: listingpb.GetAllListingsRequest.created_before:type_name -> google.protobuf.Timestamp
+	1,  // 6: listingpb.GetAllListingsResponse.listings:type_name -> listingpb.Listing
+	75, // 7: listingpb.AddListingRequest.attributes:type_name -> listingpb.AddListingRequest.AttributesEntry
+	47, // 8: listingpb.AddListingRequest.auction:type_name -> listingpb.AuctionSettings
+	76, // 9: listingpb.EditListingRequest.attributes:type_name -> listingpb.EditListingRequest.AttributesEntry
+	15, // 10: listingpb.CreateImportJobRequest.errors:type_name -> listingpb.ImportRowError
+	14, // 11: listingpb.AppendImportRowsRequest.rows:type_name -> listingpb.ImportRow
+	15, // 12: listingpb.AppendImportRowsRequest.errors:type_name -> listingpb.ImportRowError
+	15, // 13: listingpb.AppendImportRowsResponse.errors:type_name -> listingpb.ImportRowError
+	15, // 14: listingpb.ImportJob.errors:type_name -> listingpb.ImportRowError
+	77, // 15: listingpb.ImportJob.created_at:type_name -> google.protobuf.Timestamp
+	77, // 16: listingpb.ImportJob.finished_at:type_name -> google.protobuf.Timestamp
+	2,  // 17: listingpb.StreamFeedRequest.filter:type_name -> listingpb.GetAllListingsRequest
+	24, // 18: listingpb.Category.attributes:type_name -> listingpb.AttributeSchema
+	25, // 19: listingpb.GetCategoriesResponse.categories:type_name -> listingpb.Category
+	2,  // 20: listingpb.GetListingFacetsRequest.filter:type_name -> listingpb.GetAllListingsRequest
+	28, // 21: listingpb.ListingFacets.price_histogram:type_name -> listingpb.PriceBucket
+	29, // 22: listingpb.ListingFacets.categories:type_name -> listingpb.CategoryFacet
+	30, // 23: listingpb.ListingFacets.statuses:type_name -> listingpb.StatusFacet
+	77, // 24: listingpb.CreatePromotionRequest.starts_at:type_name -> google.protobuf.Timestamp
+	77, // 25: listingpb.CreatePromotionRequest.ends_at:type_name -> google.protobuf.Timestamp
+	77, // 26: listingpb.Promotion.starts_at:type_name -> google.protobuf.Timestamp
+	77, // 27: listingpb.Promotion.ends_at:type_name -> google.protobuf.Timestamp
+	77, // 28: listingpb.Promotion.created_at:type_name -> google.protobuf.Timestamp
+	36, // 29: listingpb.GetPromotionsResponse.promotions:type_name -> listingpb.Promotion
+	77, // 30: listingpb.DuplicateMatch.detected_at:type_name -> google.protobuf.Timestamp
+	40, // 31: listingpb.GetDuplicatesResponse.matches:type_name -> listingpb.DuplicateMatch
+	77, // 32: listingpb.MakeOfferRequest.expires_at:type_name -> google.protobuf.Timestamp
+	77, // 33: listingpb.RespondOfferRequest.expires_at:type_name -> google.protobuf.Timestamp
+	77, // 34: listingpb.Offer.expires_at:type_name -> google.protobuf.Timestamp
+	77, // 35: listingpb.Offer.created_at:type_name -> google.protobuf.Timestamp
+	77, // 36: listingpb.Offer.responded_at:type_name -> google.protobuf.Timestamp
+	77, // 37: listingpb.Offer.reserved_until:type_name -> google.protobuf.Timestamp
+	44, // 38: listingpb.GetOffersResponse.offers:type_name -> listingpb.Offer
+	77, // 39: listingpb.AuctionSettings.ends_at:type_name -> google.protobuf.Timestamp
+	77, // 40: listingpb.Bid.created_at:type_name -> google.protobuf.Timestamp
+	77, // 41: listingpb.Auction.ends_at:type_name -> google.protobuf.Timestamp
+	77, // 42: listingpb.Auction.closed_at:type_name -> google.protobuf.Timestamp
+	48, // 43: listingpb.Auction.bids:type_name -> listingpb.Bid
+	77, // 44: listingpb.Collection.created_at:type_name -> google.protobuf.Timestamp
+	52, // 45: listingpb.GetCollectionsResponse.collections:type_name -> listingpb.Collection
+	77, // 46: listingpb.Question.answered_at:type_name -> google.protobuf.Timestamp
+	77, // 47: listingpb.Question.created_at:type_name -> google.protobuf.Timestamp
+	61, // 48: listingpb.GetQuestionsResponse.questions:type_name -> listingpb.Question
+	68, // 49: listingpb.SuggestResponse.suggestions:type_name -> listingpb.Suggestion
+	71, // 50: listingpb.HistoryEntry.changes:type_name -> listingpb.FieldChange
+	77, // 51: listingpb.HistoryEntry.created_at:type_name -> google.protobuf.Timestamp
+	72, // 52: listingpb.GetListingHistoryResponse.entries:type_name -> listingpb.HistoryEntry
+	2,  // 53: listingpb.ListingService.GetAllListings:input_type -> listingpb.GetAllListingsRequest
+	3,  // 54: listingpb.ListingService.GetListing:input_type -> listingpb.GetListingRequest
+	6,  // 55: listingpb.ListingService.AddListing:input_type -> listingpb.AddListingRequest
+	8,  // 56: listingpb.ListingService.EditListing:input_type -> listingpb.EditListingRequest
+	9,  // 57: listingpb.ListingService.DeleteListing:input_type -> listingpb.DeleteListingRequest
+	10, // 58: listingpb.ListingService.AddLike:input_type -> listingpb.AddLikeRequest
+	11, // 59: listingpb.ListingService.RemoveLike:input_type -> listingpb.RemoveLikeRequest
+	12, // 60: listingpb.ListingService.GetTrash:input_type -> listingpb.GetTrashRequest
+	13, // 61: listingpb.ListingService.RestoreListing:input_type -> listingpb.RestoreListingRequest
+	16, // 62: listingpb.ListingService.CreateImportJob:input_type -> listingpb.CreateImportJobRequest
+	17, // 63: listingpb.ListingService.AppendImportRows:input_type -> listingpb.AppendImportRowsRequest
+	19, // 64: listingpb.ListingService.GetImportJob:input_type -> listingpb.GetImportJobRequest
+	21, // 65: listingpb.ListingService.GetFeedToken:input_type -> listingpb.FeedTokenRequest
+	23, // 66: listingpb.ListingService.StreamFeed:input_type -> listingpb.StreamFeedRequest
+	0,  // 67: listingpb.ListingService.GetCategories:input_type -> listingpb.Empty
+	27, // 68: listingpb.ListingService.GetListingFacets:input_type -> listingpb.GetListingFacetsRequest
+	32, // 69: listingpb.ListingService.GetSimilarListings:input_type -> listingpb.GetSimilarListingsRequest
+	33, // 70: listingpb.ListingService.GetRecommendations:input_type -> listingpb.GetRecommendationsRequest
+	34, // 71: listingpb.ListingService.RecordView:input_type -> listingpb.RecordViewRequest
+	35, // 72: listingpb.ListingService.CreatePromotion:input_type -> listingpb.CreatePromotionRequest
+	37, // 73: listingpb.ListingService.GetPromotions:input_type -> listingpb.GetPromotionsRequest
+	39, // 74: listingpb.ListingService.GetDuplicates:input_type -> listingpb.GetDuplicatesRequest
+	42, // 75: listingpb.ListingService.MakeOffer:input_type -> listingpb.MakeOfferRequest
+	43, // 76: listingpb.ListingService.RespondOffer:input_type -> listingpb.RespondOfferRequest
+	45, // 77: listingpb.ListingService.GetOffers:input_type -> listingpb.GetOffersRequest
+	50, // 78: listingpb.ListingService.PlaceBid:input_type -> listingpb.PlaceBidRequest
+	51, // 79: listingpb.ListingService.GetAuction:input_type -> listingpb.GetAuctionRequest
+	53, // 80: listingpb.ListingService.CreateCollection:input_type -> listingpb.CollectionRequest
+	53, // 81: listingpb.ListingService.RenameCollection:input_type -> listingpb.CollectionRequest
+	53, // 82: listingpb.ListingService.DeleteCollection:input_type -> listingpb.CollectionRequest
+	54, // 83: listingpb.ListingService.GetCollections:input_type -> listingpb.GetCollectionsRequest
+	56, // 84: listingpb.ListingService.AddToCollection:input_type -> listingpb.CollectionItemRequest
+	56, // 85: listingpb.ListingService.RemoveFromCollection:input_type -> listingpb.CollectionItemRequest
+	57, // 86: listingpb.ListingService.ShareCollection:input_type -> listingpb.ShareCollectionRequest
+	58, // 87: listingpb.ListingService.GetSharedCollection:input_type -> listingpb.GetSharedCollectionRequest
+	59, // 88: listingpb.ListingService.FollowUser:input_type -> listingpb.FollowRequest
+	59, // 89: listingpb.ListingService.UnfollowUser:input_type -> listingpb.FollowRequest
+	59, // 90: listingpb.ListingService.GetFollowStats:input_type -> listingpb.FollowRequest
+	62, // 91: listingpb.ListingService.AskQuestion:input_type -> listingpb.AskQuestionRequest
+	63, // 92: listingpb.ListingService.AnswerQuestion:input_type -> listingpb.AnswerQuestionRequest
+	64, // 93: listingpb.ListingService.GetQuestions:input_type -> listingpb.GetQuestionsRequest
+	66, // 94: listingpb.ListingService.HideQuestion:input_type -> listingpb.HideQuestionRequest
+	67, // 95: listingpb.ListingService.SuggestListings:input_type -> listingpb.SuggestRequest
+	70, // 96: listingpb.ListingService.GetListingHistory:input_type -> listingpb.GetListingHistoryRequest
+	5,  // 97: listingpb.ListingService.GetAllListings:output_type -> listingpb.GetAllListingsResponse
+	1,  // 98: listingpb.ListingService.GetListing:output_type -> listingpb.Listing
+	7,  // 99: listingpb.ListingService.AddListing:output_type -> listingpb.AddListingResponse
+	0,  // 100: listingpb.ListingService.EditListing:output_type -> listingpb.Empty
+	0,  // 101: listingpb.ListingService.DeleteListing:output_type -> listingpb.Empty
+	0,  // 102: listingpb.ListingService.AddLike:output_type -> listingpb.Empty
+	0,  // 103: listingpb.ListingService.RemoveLike:output_type -> listingpb.Empty
+	5,  // 104: listingpb.ListingService.GetTrash:output_type -> listingpb.GetAllListingsResponse
+	0,  // 105: listingpb.ListingService.RestoreListing:output_type -> listingpb.Empty
+	20, // 106: listingpb.ListingService.CreateImportJob:output_type -> listingpb.ImportJob
+	18, // 107: listingpb.ListingService.AppendImportRows:output_type -> listingpb.AppendImportRowsResponse
+	20, // 108: listingpb.ListingService.GetImportJob:output_type -> listingpb.ImportJob
+	22, // 109: listingpb.ListingService.GetFeedToken:output_type -> listingpb.FeedTokenResponse
+	1,  // 110: listingpb.ListingService.StreamFeed:output_type -> listingpb.Listing
+	26, // 111: listingpb.ListingService.GetCategories:output_type -> listingpb.GetCategoriesResponse
+	31, // 112: listingpb.ListingService.GetListingFacets:output_type -> listingpb.ListingFacets
+	5,  // 113: listingpb.ListingService.GetSimilarListings:output_type -> listingpb.GetAllListingsResponse
+	5,  // 114: listingpb.ListingService.GetRecommendations:output_type -> listingpb.GetAllListingsResponse
+	0,  // 115: listingpb.ListingService.RecordView:output_type -> listingpb.Empty
+	36, // 116: listingpb.ListingService.CreatePromotion:output_type -> listingpb.Promotion
+	38, // 117: listingpb.ListingService.GetPromotions:output_type -> listingpb.GetPromotionsResponse
+	41, // 118: listingpb.ListingService.GetDuplicates:output_type -> listingpb.GetDuplicatesResponse
+	44, // 119: listingpb.ListingService.MakeOffer:output_type -> listingpb.Offer
+	44, // 120: listingpb.ListingService.RespondOffer:output_type -> listingpb.Offer
+	46, // 121: listingpb.ListingService.GetOffers:output_type -> listingpb.GetOffersResponse
+	49, // 122: listingpb.ListingService.PlaceBid:output_type -> listingpb.Auction
+	49, // 123: listingpb.ListingService.GetAuction:output_type -> listingpb.Auction
+	52, // 124: listingpb.ListingService.CreateCollection:output_type -> listingpb.Collection
+	52, // 125: listingpb.ListingService.RenameCollection:output_type -> listingpb.Collection
+	0,  // 126: listingpb.ListingService.DeleteCollection:output_type -> listingpb.Empty
+	55, // 127: listingpb.ListingService.GetCollections:output_type -> listingpb.GetCollectionsResponse
+	0,  // 128: listingpb.ListingService.AddToCollection:output_type -> listingpb.Empty
+	0,  // 129: listingpb.ListingService.RemoveFromCollection:output_type -> listingpb.Empty
+	52, // 130: listingpb.ListingService.ShareCollection:output_type -> listingpb.Collection
+	52, // 131: listingpb.ListingService.GetSharedCollection:output_type -> listingpb.Collection
+	60, // 132: listingpb.ListingService.FollowUser:output_type -> listingpb.FollowStats
+	60, // 133: listingpb.ListingService.UnfollowUser:output_type -> listingpb.FollowStats
+	60, // 134: listingpb.ListingService.GetFollowStats:output_type -> listingpb.FollowStats
+	61, // 135: listingpb.ListingService.AskQuestion:output_type -> listingpb.Question
+	61, // 136: listingpb.ListingService.AnswerQuestion:output_type -> listingpb.Question
+	65, // 137: listingpb.ListingService.GetQuestions:output_type -> listingpb.GetQuestionsResponse
+	61, // 138: listingpb.ListingService.HideQuestion:output_type -> listingpb.Question
+	69, // 139: listingpb.ListingService.SuggestListings:output_type -> listingpb.SuggestResponse
+	73, // 140: listingpb.ListingService.GetListingHistory:output_type -> listingpb.GetListingHistoryResponse
+	97, // [97:141] is the sub-list for method output_type
+	53, // [53:97] is the sub-list for method input_type
+	53, // [53:53] is the sub-list for extension type_name
+	53, // [53:53] is the sub-list for extension extendee
+	0,  // [0:53] is the sub-list for field type_name
 }
 
 func init() { file_listing_proto_init() }
@@ -856,13 +5850,17 @@ func file_listing_proto_init() {
 	if File_listing_proto != nil {
 		return
 	}
+	file_listing_proto_msgTypes[4].OneofWrappers = []any{}
+	file_listing_proto_msgTypes[28].OneofWrappers = []any{}
+	file_listing_proto_msgTypes[40].OneofWrappers = []any{}
+	file_listing_proto_msgTypes[49].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_listing_proto_rawDesc), len(file_listing_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   77,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
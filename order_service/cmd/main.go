@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"orderService/orderpb"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+type server struct {
+	orderpb.UnimplementedOrderServiceServer
+	sql      *pgxpool.Pool
+	payments paymentProvider
+}
+
+var (
+	paymentTTL          time.Duration // сколько заказ ждёт оплаты, прежде чем отменяется и освобождает объявление
+	orderExpireInterval time.Duration // как часто отменяются неоплаченные заказы
+)
+
+var (
+	paymentProviderName  string        // платёжный провайдер, пока доступен только fake
+	webhookURL           string        // адрес, на который провайдер присылает уведомления о платежах
+	webhookSecret        string        // общий с API ключ подписи уведомлений
+	fakePaymentDelay     time.Duration // через сколько фейковый провайдер присылает уведомление
+	fakePaymentOutcome   string        // чем заканчиваются платежи фейкового провайдера: succeeded или canceled
+	webhookDeliveryTries int           // сколько раз фейковый провайдер пытается доставить уведомление
+)
+
+func init() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal(".env file not found")
+	}
+
+	ttlMinutes, err := envInt("ORDER_PAYMENT_TTL", 30)
+	if err != nil || ttlMinutes <= 0 {
+		log.Fatalf("invalid ORDER_PAYMENT_TTL: %v", err)
+	}
+	paymentTTL = time.Duration(ttlMinutes) * time.Minute
+
+	expireMinutes, err := envInt("ORDER_EXPIRE_INTERVAL", 1)
+	if err != nil || expireMinutes <= 0 {
+		log.Fatalf("invalid ORDER_EXPIRE_INTERVAL: %v", err)
+	}
+	orderExpireInterval = time.Duration(expireMinutes) * time.Minute
+
+	paymentProviderName = os.Getenv("ORDER_PAYMENT_PROVIDER")
+	if paymentProviderName == "" {
+		paymentProviderName = providerFake
+	}
+	if paymentProviderName != providerFake {
+		log.Fatalf("invalid ORDER_PAYMENT_PROVIDER: %q", paymentProviderName)
+	}
+
+	webhookURL = os.Getenv("ORDER_WEBHOOK_URL")
+	webhookSecret = os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if webhookURL == "" || webhookSecret == "" {
+		log.Fatal("ORDER_WEBHOOK_URL and PAYMENT_WEBHOOK_SECRET are required")
+	}
+
+	delaySeconds, err := envInt("ORDER_FAKE_PAYMENT_DELAY", 5)
+	if err != nil || delaySeconds < 0 {
+		log.Fatalf("invalid ORDER_FAKE_PAYMENT_DELAY: %v", err)
+	}
+	fakePaymentDelay = time.Duration(delaySeconds) * time.Second
+
+	fakePaymentOutcome = os.Getenv("ORDER_FAKE_PAYMENT_OUTCOME")
+	if fakePaymentOutcome == "" {
+		fakePaymentOutcome = eventPaymentSucceeded
+	}
+	if fakePaymentOutcome != eventPaymentSucceeded && fakePaymentOutcome != eventPaymentCanceled {
+		log.Fatalf("invalid ORDER_FAKE_PAYMENT_OUTCOME: %q", fakePaymentOutcome)
+	}
+
+	webhookDeliveryTries, err = envInt("ORDER_WEBHOOK_TRIES", 5)
+	if err != nil || webhookDeliveryTries <= 0 {
+		log.Fatalf("invalid ORDER_WEBHOOK_TRIES: %v", err)
+	}
+}
+
+// envInt читает целочисленную переменную окружения, подставляя значение по умолчанию, если она не задана
+func envInt(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
+}
+
+const (
+	order = "order"
+)
+
+var acl = map[string][]string{
+	// OrderService methods
+	"/orderpb.OrderService/CreateOrder":          {order},
+	"/orderpb.OrderService/GetOrder":             {order},
+	"/orderpb.OrderService/GetOrders":            {order},
+	"/orderpb.OrderService/CancelOrder":          {order},
+	"/orderpb.OrderService/RefundOrder":          {order},
+	"/orderpb.OrderService/HandlePaymentWebhook": {order},
+}
+
+// UnaryInterceptor — перехватчик запросов
+func UnaryInterceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "missing metadata")
+	}
+
+	authHeader := md.Get("authorization")
+	if len(authHeader) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	token := strings.TrimPrefix(authHeader[0], "Bearer ")
+	role, err := getRoleByToken(token)
+	if err != nil {
+		return nil, status.Error(codes.PermissionDenied, "invalid token")
+	}
+
+	allowedRoles, ok := acl[info.FullMethod]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method not allowed")
+	}
+
+	if !contains(allowedRoles, role) {
+		return nil, status.Error(codes.PermissionDenied, "access denied")
+	}
+
+	return handler(ctx, req)
+}
+
+func getRoleByToken(token string) (string, error) {
+	switch token {
+	case "order-token":
+		return order, nil
+	default:
+		return "", status.Error(codes.Unauthenticated, "unknown token")
+	}
+}
+
+func contains(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+	return false
+}
+
+func main() {
+	dbHost := os.Getenv("POSTGRES_HOST")
+	dbPort := os.Getenv("POSTGRES_PORT")
+	dbUser := os.Getenv("POSTGRES_USER")
+	dbPass := os.Getenv("POSTGRES_PASS")
+	dbName := os.Getenv("POSTGRES_DB")
+
+	serverPort := os.Getenv("ORDER_ADDR")
+
+	connString := fmt.Sprintf("postgres://%s:%s@%s:%s/%s",
+		dbUser, dbPass, dbHost, dbPort, dbName)
+
+	ctx := context.Background()
+	conn, err := pgxpool.New(ctx, connString)
+	if err != nil {
+		log.Fatalf("unable to connect to database: %v\n", err)
+	}
+	defer conn.Close()
+
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryInterceptor),
+	)
+	server := &server{
+		sql: conn,
+		payments: &fakeProvider{
+			webhookURL: webhookURL,
+			secret:     []byte(webhookSecret),
+			delay:      fakePaymentDelay,
+			outcome:    fakePaymentOutcome,
+			tries:      webhookDeliveryTries,
+			client:     &http.Client{Timeout: 10 * time.Second},
+		},
+	}
+	orderpb.RegisterOrderServiceServer(grpcServer, server)
+
+	go server.expireOrders(ctx)
+
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", ":"+serverPort)
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+	log.Printf("server is running on port %s", serverPort)
+	if err := grpcServer.Serve(listener); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
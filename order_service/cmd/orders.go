@@ -0,0 +1,499 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"orderService/orderpb"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Статусы заказов
+const (
+	orderPending   = "pending"
+	orderPaid      = "paid"
+	orderCancelled = "cancelled"
+	orderRefunded  = "refunded"
+)
+
+// orderTransitions - допустимые переходы статусов заказа, остальные статусы конечные
+var orderTransitions = map[string][]string{
+	orderPending: {orderPaid, orderCancelled},
+	orderPaid:    {orderRefunded},
+}
+
+// Статусы объявлений, которые меняет сервис заказов
+const (
+	listingActive   = "active"
+	listingReserved = "reserved"
+	listingSold     = "sold"
+)
+
+// historyOrder - действие в истории объявления, которое записывает сервис заказов при смене статуса
+const historyOrder = "order"
+
+// listingStatusHistory добавляет в историю объявления смену статуса из $2 в $3, сделанную сервисом заказов.
+// Формат записи задаёт функция add_listing_history, общая с сервисом объявлений
+const listingStatusHistory = `
+    SELECT add_listing_history($1, NULL, $4::text, jsonb_build_object('status', $2::text), jsonb_build_object('status', $3::text), $5)
+`
+
+// offerAccepted - статус принятого предложения цены, по которому покупатель может оформить заказ
+const offerAccepted = "accepted"
+
+// Роли пользователя в заказе для фильтрации списка заказов
+const (
+	roleBuyer  = "buyer"
+	roleSeller = "seller"
+)
+
+const orderColumns = `id, listing_id, buyer_id, seller_id, offer_id, amount, status, payment_id, payment_url,
+    created_at, expires_at, paid_at, cancelled_at, refund_requested_at, refunded_at`
+
+// canTransition проверяет, что заказ можно перевести из статуса from в статус to
+func canTransition(from, to string) bool {
+	return slices.Contains(orderTransitions[from], to)
+}
+
+func timestampOrNil(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func scanOrder(row pgx.Row) (*orderpb.Order, error) {
+	var o orderpb.Order
+	var listingID, offerID, paymentID, paymentURL *string
+	var createdAt, expiresAt time.Time
+	var paidAt, cancelledAt, refundRequestedAt, refundedAt *time.Time
+	err := row.Scan(&o.Id, &listingID, &o.BuyerId, &o.SellerId, &offerID, &o.Amount, &o.Status, &paymentID, &paymentURL,
+		&createdAt, &expiresAt, &paidAt, &cancelledAt, &refundRequestedAt, &refundedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range []struct {
+		dst *string
+		src *string
+	}{
+		{&o.ListingId, listingID},
+		{&o.OfferId, offerID},
+		{&o.PaymentId, paymentID},
+		{&o.PaymentUrl, paymentURL},
+	} {
+		if f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+
+	o.CreatedAt = timestamppb.New(createdAt)
+	o.ExpiresAt = timestamppb.New(expiresAt)
+	o.PaidAt = timestampOrNil(paidAt)
+	o.CancelledAt = timestampOrNil(cancelledAt)
+	o.RefundRequestedAt = timestampOrNil(refundRequestedAt)
+	o.RefundedAt = timestampOrNil(refundedAt)
+	return &o, nil
+}
+
+// lockOrder блокирует заказ до конца транзакции.
+// Заказ, в котором пользователь не участвует, для него не существует
+func lockOrder(ctx context.Context, tx pgx.Tx, orderID, userID string) (*orderpb.Order, error) {
+	o, err := scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE id = $1 FOR UPDATE`, orderID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query order: %v", err)
+	}
+	if userID != o.BuyerId && userID != o.SellerId {
+		return nil, status.Error(codes.NotFound, "order not found")
+	}
+	return o, nil
+}
+
+// cancelOrder отменяет ожидающий оплаты заказ и возвращает объявление в продажу
+func cancelOrder(ctx context.Context, tx pgx.Tx, o *orderpb.Order, now time.Time) error {
+	_, err := tx.Exec(ctx, `UPDATE orders SET status = $1, cancelled_at = $2 WHERE id = $3`, orderCancelled, now, o.Id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to cancel order: %v", err)
+	}
+	if err := setListingStatus(ctx, tx, o.ListingId, listingReserved, listingActive); err != nil {
+		return err
+	}
+	o.Status = orderCancelled
+	o.CancelledAt = timestamppb.New(now)
+	return nil
+}
+
+// setListingStatus переводит объявление из статуса from в статус to, объявление в другом статусе не меняется
+func setListingStatus(ctx context.Context, tx pgx.Tx, listingID, from, to string) error {
+	if listingID == "" {
+		return nil
+	}
+	tag, err := tx.Exec(ctx, `UPDATE listings SET status = $1 WHERE id = $2 AND status = $3`, to, listingID, from)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to update listing: %v", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return nil
+	}
+
+	_, err = tx.Exec(ctx, listingStatusHistory, listingID, from, to, historyOrder, time.Now())
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to save listing history: %v", err)
+	}
+	return nil
+}
+
+// CreateOrder оформляет заказ на объявление и резервирует его до оплаты.
+// Заказ по принятому предложению цены оформляется на сумму предложения, иначе - на цену объявления
+func (s *server) CreateOrder(ctx context.Context, req *orderpb.CreateOrderRequest) (*orderpb.Order, error) {
+	now := time.Now()
+	o := &orderpb.Order{
+		Id:        uuid.New().String(),
+		ListingId: req.ListingId,
+		BuyerId:   req.BuyerId,
+		OfferId:   req.OfferId,
+		Status:    orderPending,
+		CreatedAt: timestamppb.New(now),
+		ExpiresAt: timestamppb.New(now.Add(paymentTTL)),
+	}
+
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		var listingStatus string
+		err := tx.QueryRow(ctx, `
+            SELECT author_id, price, status FROM listings WHERE id = $1 AND deleted_at IS NULL FOR UPDATE
+        `, req.ListingId).Scan(&o.SellerId, &o.Amount, &listingStatus)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return status.Error(codes.NotFound, "listing not found")
+			}
+			return status.Errorf(codes.Internal, "failed to query listing: %v", err)
+		}
+		if o.SellerId == req.BuyerId {
+			return status.Error(codes.PermissionDenied, "you cannot order your own listing")
+		}
+
+		var auction bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM auctions WHERE listing_id = $1)`, req.ListingId).Scan(&auction)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query auction: %v", err)
+		}
+		if auction {
+			return status.Error(codes.FailedPrecondition, "listing is sold at auction")
+		}
+
+		// Принятое предложение уже зарезервировало объявление для покупателя
+		wantStatus := listingActive
+		if req.OfferId != "" {
+			var buyerID, offerStatus string
+			err := tx.QueryRow(ctx, `
+                SELECT buyer_id, amount, status FROM offers WHERE id = $1 AND listing_id = $2
+            `, req.OfferId, req.ListingId).Scan(&buyerID, &o.Amount, &offerStatus)
+			if errors.Is(err, pgx.ErrNoRows) || (err == nil && buyerID != req.BuyerId) {
+				return status.Error(codes.NotFound, "offer not found")
+			}
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to query offer: %v", err)
+			}
+			if offerStatus != offerAccepted {
+				return status.Errorf(codes.FailedPrecondition, "offer is %s", offerStatus)
+			}
+			wantStatus = listingReserved
+		}
+		if listingStatus != wantStatus {
+			return status.Errorf(codes.FailedPrecondition, "listing is %s", listingStatus)
+		}
+
+		var ordered bool
+		err = tx.QueryRow(ctx, `
+            SELECT EXISTS (SELECT 1 FROM orders WHERE listing_id = $1 AND status IN ($2, $3))
+        `, req.ListingId, orderPending, orderPaid).Scan(&ordered)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query orders: %v", err)
+		}
+		if ordered {
+			return status.Error(codes.FailedPrecondition, "listing already has an active order")
+		}
+
+		var offerID *string
+		if req.OfferId != "" {
+			offerID = &req.OfferId
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO orders (id, listing_id, buyer_id, seller_id, offer_id, amount, status, created_at, expires_at)
+            VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+        `, o.Id, o.ListingId, o.BuyerId, o.SellerId, offerID, o.Amount, o.Status, now, now.Add(paymentTTL))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to create order: %v", err)
+		}
+
+		return setListingStatus(ctx, tx, req.ListingId, listingStatus, listingReserved)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Платёж создаётся после фиксации заказа: уведомление провайдера может прийти раньше, чем вернётся CreatePayment,
+	// и тогда провайдер повторит его, когда идентификатор платежа будет сохранён
+	p, err := s.payments.CreatePayment(ctx, o.Id, o.Amount)
+	if err != nil {
+		log.Printf("failed to create payment for order %s: %v", o.Id, err)
+		if err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+			return cancelOrder(ctx, tx, o, time.Now())
+		}); err != nil {
+			log.Printf("failed to cancel order %s: %v", o.Id, err)
+		}
+		return nil, status.Error(codes.Unavailable, "payment provider is unavailable")
+	}
+
+	_, err = s.sql.Exec(ctx, `UPDATE orders SET payment_id = $1, payment_url = $2 WHERE id = $3`, p.ID, p.URL, o.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to save payment: %v", err)
+	}
+	o.PaymentId = p.ID
+	o.PaymentUrl = p.URL
+	return o, nil
+}
+
+// GetOrder возвращает заказ, в котором пользователь покупатель или продавец
+func (s *server) GetOrder(ctx context.Context, req *orderpb.GetOrderRequest) (*orderpb.Order, error) {
+	o, err := scanOrder(s.sql.QueryRow(ctx, `
+        SELECT `+orderColumns+` FROM orders WHERE id = $1 AND (buyer_id = $2 OR seller_id = $2)
+    `, req.OrderId, req.UserId))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, status.Error(codes.NotFound, "order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to query order: %v", err)
+	}
+	return o, nil
+}
+
+// GetOrders возвращает заказы пользователя, начиная с последних. Роль buyer или seller оставляет
+// только покупки или только продажи
+func (s *server) GetOrders(ctx context.Context, req *orderpb.GetOrdersRequest) (*orderpb.GetOrdersResponse, error) {
+	if req.Role != "" && req.Role != roleBuyer && req.Role != roleSeller {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown role %q", req.Role)
+	}
+
+	rows, err := s.sql.Query(ctx, `
+        SELECT `+orderColumns+` FROM orders
+        WHERE (buyer_id = $1 AND $2 <> $4) OR (seller_id = $1 AND $2 <> $3)
+        ORDER BY created_at DESC
+    `, req.UserId, req.Role, roleBuyer, roleSeller)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "query error: %v", err)
+	}
+	defer rows.Close()
+
+	resp := &orderpb.GetOrdersResponse{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan error: %v", err)
+		}
+		resp.Orders = append(resp.Orders, o)
+	}
+	return resp, nil
+}
+
+// CancelOrder отменяет неоплаченный заказ по просьбе покупателя или продавца
+func (s *server) CancelOrder(ctx context.Context, req *orderpb.OrderActionRequest) (*orderpb.Order, error) {
+	var o *orderpb.Order
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		var err error
+		if o, err = lockOrder(ctx, tx, req.OrderId, req.UserId); err != nil {
+			return err
+		}
+		if !canTransition(o.Status, orderCancelled) {
+			return status.Errorf(codes.FailedPrecondition, "order is %s", o.Status)
+		}
+		return cancelOrder(ctx, tx, o, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// RefundOrder запрашивает у провайдера возврат оплаченного заказа, вернуть деньги может только продавец.
+// Заказ становится возвращённым, когда провайдер пришлёт уведомление refund.succeeded
+func (s *server) RefundOrder(ctx context.Context, req *orderpb.OrderActionRequest) (*orderpb.Order, error) {
+	now := time.Now()
+	var o *orderpb.Order
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		var err error
+		if o, err = lockOrder(ctx, tx, req.OrderId, req.UserId); err != nil {
+			return err
+		}
+		if req.UserId != o.SellerId {
+			return status.Error(codes.PermissionDenied, "only the seller can refund the order")
+		}
+		if !canTransition(o.Status, orderRefunded) {
+			return status.Errorf(codes.FailedPrecondition, "order is %s", o.Status)
+		}
+		if o.RefundRequestedAt != nil {
+			return status.Error(codes.FailedPrecondition, "refund is already requested")
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE orders SET refund_requested_at = $1 WHERE id = $2`, now, o.Id)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to update order: %v", err)
+		}
+		o.RefundRequestedAt = timestamppb.New(now)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.payments.Refund(ctx, o.PaymentId, o.Amount); err != nil {
+		log.Printf("failed to refund order %s: %v", o.Id, err)
+		if _, err := s.sql.Exec(ctx, `UPDATE orders SET refund_requested_at = NULL WHERE id = $1`, o.Id); err != nil {
+			log.Printf("failed to reset refund of order %s: %v", o.Id, err)
+		}
+		return nil, status.Error(codes.Unavailable, "payment provider is unavailable")
+	}
+	return o, nil
+}
+
+// HandlePaymentWebhook применяет уведомление платёжного провайдера к заказу.
+// Провайдер может прислать одно уведомление несколько раз: обработанные уведомления запоминаются
+// и при повторе возвращают текущее состояние заказа без изменений
+func (s *server) HandlePaymentWebhook(ctx context.Context, req *orderpb.PaymentWebhookRequest) (*orderpb.PaymentWebhookResponse, error) {
+	if req.EventId == "" || req.PaymentId == "" {
+		return nil, status.Error(codes.InvalidArgument, "event_id and payment_id are required")
+	}
+
+	var next string
+	switch req.Type {
+	case eventPaymentSucceeded:
+		next = orderPaid
+	case eventPaymentCanceled:
+		next = orderCancelled
+	case eventRefundSucceeded:
+		next = orderRefunded
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unknown event type %q", req.Type)
+	}
+
+	now := time.Now()
+	resp := &orderpb.PaymentWebhookResponse{}
+	var refund bool
+	err := pgx.BeginFunc(ctx, s.sql, func(tx pgx.Tx) error {
+		o, err := scanOrder(tx.QueryRow(ctx, `SELECT `+orderColumns+` FROM orders WHERE payment_id = $1 FOR UPDATE`, req.PaymentId))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return status.Error(codes.NotFound, "payment not found")
+			}
+			return status.Errorf(codes.Internal, "failed to query order: %v", err)
+		}
+		resp.Order = o
+
+		if req.Amount != o.Amount {
+			return status.Errorf(codes.InvalidArgument, "amount %d does not match order amount %d", req.Amount, o.Amount)
+		}
+
+		tag, err := tx.Exec(ctx, `
+            INSERT INTO payment_events (event_id, order_id, type, payment_id, received_at)
+            VALUES ($1, $2, $3, $4, $5)
+            ON CONFLICT (event_id) DO NOTHING
+        `, req.EventId, o.Id, req.Type, req.PaymentId, now)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to save event: %v", err)
+		}
+		if tag.RowsAffected() == 0 {
+			resp.Duplicate = true
+			return nil
+		}
+
+		if !canTransition(o.Status, next) {
+			// Оплата могла прийти после того, как заказ отменили, деньги в этом случае возвращаются покупателю
+			refund = next == orderPaid && o.Status == orderCancelled
+			log.Printf("ignored %s event %s for order %s in status %s", req.Type, req.EventId, o.Id, o.Status)
+			return nil
+		}
+
+		switch next {
+		case orderPaid:
+			_, err = tx.Exec(ctx, `UPDATE orders SET status = $1, paid_at = $2 WHERE id = $3`, next, now, o.Id)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to update order: %v", err)
+			}
+			o.Status = next
+			o.PaidAt = timestamppb.New(now)
+			return setListingStatus(ctx, tx, o.ListingId, listingReserved, listingSold)
+		case orderCancelled:
+			return cancelOrder(ctx, tx, o, now)
+		case orderRefunded:
+			_, err = tx.Exec(ctx, `UPDATE orders SET status = $1, refunded_at = $2 WHERE id = $3`, next, now, o.Id)
+			if err != nil {
+				return status.Errorf(codes.Internal, "failed to update order: %v", err)
+			}
+			o.Status = next
+			o.RefundedAt = timestamppb.New(now)
+			return setListingStatus(ctx, tx, o.ListingId, listingSold, listingActive)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if refund {
+		if err := s.payments.Refund(ctx, req.PaymentId, req.Amount); err != nil {
+			log.Printf("failed to refund late payment %s: %v", req.PaymentId, err)
+		}
+	}
+	return resp, nil
+}
+
+// expireOrders периодически отменяет заказы, которые не оплатили вовремя, и возвращает объявления в продажу
+func (s *server) expireOrders(ctx context.Context) {
+	ticker := time.NewTicker(orderExpireInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			var released int64
+			err := s.sql.QueryRow(ctx, `
+                WITH expired AS (
+                    UPDATE orders SET status = $1, cancelled_at = $2
+                    WHERE status = $3 AND expires_at <= $2
+                    RETURNING listing_id
+                ), released AS (
+                    UPDATE listings l SET status = $4
+                    FROM expired e
+                    WHERE l.id = e.listing_id AND l.status = $5
+                    RETURNING l.id
+                ), history AS (
+                    SELECT add_listing_history(id, NULL, $6::text,
+                        jsonb_build_object('status', $5::text), jsonb_build_object('status', $4::text), $2)
+                    FROM released
+                )
+                -- CTE без изменения данных выполняется, только если на него ссылается запрос
+                SELECT COUNT(*) FROM released, (SELECT COUNT(*) FROM history) h
+            `, orderCancelled, now, orderPending, listingActive, listingReserved, historyOrder).Scan(&released)
+			if err != nil {
+				log.Printf("failed to expire orders: %v", err)
+				continue
+			}
+			if released > 0 {
+				log.Printf("expired orders released %d listings", released)
+			}
+		}
+	}
+}
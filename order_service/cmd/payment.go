@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// providerFake - локальный провайдер, который не списывает деньги и сам присылает уведомления о платежах
+const providerFake = "fake"
+
+// Типы уведомлений платёжного провайдера
+const (
+	eventPaymentSucceeded = "payment.succeeded"
+	eventPaymentCanceled  = "payment.canceled"
+	eventRefundSucceeded  = "refund.succeeded"
+)
+
+// signatureHeader - заголовок с HMAC-SHA256 подписью тела уведомления
+const signatureHeader = "X-Payment-Signature"
+
+// payment платёж, созданный у провайдера
+type payment struct {
+	ID  string // Идентификатор платежа у провайдера
+	URL string // Страница оплаты для покупателя, если провайдер её предоставляет
+}
+
+// paymentProvider описывает платёжного провайдера. О результате платежа и возврата
+// провайдер сообщает уведомлением, которое API передаёт в HandlePaymentWebhook
+type paymentProvider interface {
+	// CreatePayment создаёт платёж по заказу
+	CreatePayment(ctx context.Context, orderID string, amount int64) (payment, error)
+
+	// Refund запрашивает возврат оплаченного платежа
+	Refund(ctx context.Context, paymentID string, amount int64) error
+}
+
+// webhookEvent тело уведомления платёжного провайдера
+type webhookEvent struct {
+	EventID   string `json:"event_id"`
+	Type      string `json:"type"`
+	PaymentID string `json:"payment_id"`
+	Amount    int64  `json:"amount"`
+}
+
+// fakeProvider завершает каждый платёж с исходом outcome, а каждый возврат - успешно,
+// и через delay присылает подписанное уведомление на webhookURL
+type fakeProvider struct {
+	webhookURL string
+	secret     []byte
+	delay      time.Duration
+	outcome    string
+	tries      int
+	client     *http.Client
+}
+
+// Проверка реализации интерфейса
+var _ paymentProvider = &fakeProvider{}
+
+func (p *fakeProvider) CreatePayment(_ context.Context, _ string, amount int64) (payment, error) {
+	id := "fake_" + uuid.NewString()
+	go p.deliver(webhookEvent{EventID: uuid.NewString(), Type: p.outcome, PaymentID: id, Amount: amount})
+	return payment{ID: id}, nil
+}
+
+func (p *fakeProvider) Refund(_ context.Context, paymentID string, amount int64) error {
+	go p.deliver(webhookEvent{EventID: uuid.NewString(), Type: eventRefundSucceeded, PaymentID: paymentID, Amount: amount})
+	return nil
+}
+
+// deliver отправляет уведомление и, как настоящий провайдер, повторяет отправку, пока не получит ответ 2xx
+func (p *fakeProvider) deliver(event webhookEvent) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("failed to encode webhook %s: %v", event.EventID, err)
+		return
+	}
+
+	delay := p.delay
+	for try := 1; try <= p.tries; try++ {
+		time.Sleep(delay)
+		delay = max(2*delay, time.Second)
+
+		if err := p.send(body); err != nil {
+			log.Printf("failed to deliver webhook %s (try %d of %d): %v", event.EventID, try, p.tries, err)
+			continue
+		}
+		return
+	}
+}
+
+func (p *fakeProvider) send(body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, p.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(signatureHeader, sign(p.secret, body))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// sign возвращает HMAC-SHA256 подпись тела уведомления в шестнадцатеричном виде
+func sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
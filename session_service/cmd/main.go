@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"log"
+	"math"
 	"net"
 	"os"
 	"sessionService/sessionpb"
@@ -81,15 +82,42 @@ func (s *server) DeleteSession(ctx context.Context, req *sessionpb.SessionIDRequ
 	}, nil
 }
 
+// DeleteUserSessions завершает все сессии пользователя, кроме указанной. Нужна после смены
+// или сброса пароля, чтобы украденный пароль не оставлял доступа с других устройств
+func (s *server) DeleteUserSessions(ctx context.Context, req *sessionpb.DeleteUserSessionsRequest) (*sessionpb.DeleteUserSessionsResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing user_id")
+	}
+
+	resp, err := s.db.Select("sessions", "user", 0, math.MaxUint32, tarantool.IterEq, []interface{}{req.UserId})
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted int64
+	for _, item := range resp.Data {
+		sessionID := item.([]interface{})[0].(string)
+		if sessionID == req.ExceptSessionId {
+			continue
+		}
+		if _, err := s.db.Delete("sessions", "primary", []interface{}{sessionID}); err != nil {
+			return nil, err
+		}
+		deleted++
+	}
+	return &sessionpb.DeleteUserSessionsResponse{Deleted: deleted}, nil
+}
+
 const (
 	session = "session"
 )
 
 var acl = map[string][]string{
 	// SessionService methods
-	"/sessionpb.SessionService/GetSession":    {session},
-	"/sessionpb.SessionService/SetSession":    {session},
-	"/sessionpb.SessionService/DeleteSession": {session},
+	"/sessionpb.SessionService/GetSession":         {session},
+	"/sessionpb.SessionService/SetSession":         {session},
+	"/sessionpb.SessionService/DeleteSession":      {session},
+	"/sessionpb.SessionService/DeleteUserSessions": {session},
 }
 
 // UnaryInterceptor — перехватчик запросов
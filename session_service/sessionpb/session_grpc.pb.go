@@ -1,7 +1,7 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
 // versions:
 // - protoc-gen-go-grpc v1.5.1
-// - protoc             v6.30.2
+// - protoc             v6.31.1
 // source: session.proto
 
 package sessionpb
@@ -19,9 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SessionService_GetSession_FullMethodName    = "/sessionpb.SessionService/GetSession"
-	SessionService_SetSession_FullMethodName    = "/sessionpb.SessionService/SetSession"
-	SessionService_DeleteSession_FullMethodName = "/sessionpb.SessionService/DeleteSession"
+	SessionService_GetSession_FullMethodName         = "/sessionpb.SessionService/GetSession"
+	SessionService_SetSession_FullMethodName         = "/sessionpb.SessionService/SetSession"
+	SessionService_DeleteSession_FullMethodName      = "/sessionpb.SessionService/DeleteSession"
+	SessionService_DeleteUserSessions_FullMethodName = "/sessionpb.SessionService/DeleteUserSessions"
 )
 
 // SessionServiceClient is the client API for SessionService service.
@@ -31,6 +32,7 @@ type SessionServiceClient interface {
 	GetSession(ctx context.Context, in *SessionIDRequest, opts ...grpc.CallOption) (*SessionResponse, error)
 	SetSession(ctx context.Context, in *SetSessionRequest, opts ...grpc.CallOption) (*Empty, error)
 	DeleteSession(ctx context.Context, in *SessionIDRequest, opts ...grpc.CallOption) (*DeleteSessionResponse, error)
+	DeleteUserSessions(ctx context.Context, in *DeleteUserSessionsRequest, opts ...grpc.CallOption) (*DeleteUserSessionsResponse, error)
 }
 
 type sessionServiceClient struct {
@@ -71,6 +73,16 @@ func (c *sessionServiceClient) DeleteSession(ctx context.Context, in *SessionIDR
 	return out, nil
 }
 
+func (c *sessionServiceClient) DeleteUserSessions(ctx context.Context, in *DeleteUserSessionsRequest, opts ...grpc.CallOption) (*DeleteUserSessionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteUserSessionsResponse)
+	err := c.cc.Invoke(ctx, SessionService_DeleteUserSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SessionServiceServer is the server API for SessionService service.
 // All implementations must embed UnimplementedSessionServiceServer
 // for forward compatibility.
@@ -78,6 +90,7 @@ type SessionServiceServer interface {
 	GetSession(context.Context, *SessionIDRequest) (*SessionResponse, error)
 	SetSession(context.Context, *SetSessionRequest) (*Empty, error)
 	DeleteSession(context.Context, *SessionIDRequest) (*DeleteSessionResponse, error)
+	DeleteUserSessions(context.Context, *DeleteUserSessionsRequest) (*DeleteUserSessionsResponse, error)
 	mustEmbedUnimplementedSessionServiceServer()
 }
 
@@ -97,6 +110,9 @@ func (UnimplementedSessionServiceServer) SetSession(context.Context, *SetSession
 func (UnimplementedSessionServiceServer) DeleteSession(context.Context, *SessionIDRequest) (*DeleteSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteSession not implemented")
 }
+func (UnimplementedSessionServiceServer) DeleteUserSessions(context.Context, *DeleteUserSessionsRequest) (*DeleteUserSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserSessions not implemented")
+}
 func (UnimplementedSessionServiceServer) mustEmbedUnimplementedSessionServiceServer() {}
 func (UnimplementedSessionServiceServer) testEmbeddedByValue()                        {}
 
@@ -172,6 +188,24 @@ func _SessionService_DeleteSession_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SessionService_DeleteUserSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteUserSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServiceServer).DeleteUserSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SessionService_DeleteUserSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServiceServer).DeleteUserSessions(ctx, req.(*DeleteUserSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SessionService_ServiceDesc is the grpc.ServiceDesc for SessionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -191,6 +225,10 @@ var SessionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSession",
 			Handler:    _SessionService_DeleteSession_Handler,
 		},
+		{
+			MethodName: "DeleteUserSessions",
+			Handler:    _SessionService_DeleteUserSessions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "session.proto",
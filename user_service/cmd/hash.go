@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Параметры Argon2id по рекомендации OWASP. При их изменении старые хэши
+// пересчитываются при следующем входе
+const (
+	argonTime    = 2
+	argonMemory  = 19 * 1024 // КиБ
+	argonThreads = 1
+	argonKeyLen  = 32
+	argonSaltLen = 16
+)
+
+const argonPrefix = "$argon2id$"
+
+// errInvalidHash - сохранённый хэш не разбирается
+var errInvalidHash = errors.New("invalid password hash")
+
+// dummyHash сверяется, когда пользователя нет, чтобы время ответа не выдавало существующие логины
+var dummyHash = hashWithSalt("dummy password", make([]byte, argonSaltLen))
+
+// argonParams параметры, с которыми был посчитан хэш
+type argonParams struct {
+	time    uint32
+	memory  uint32
+	threads uint8
+}
+
+// hashPassword возвращает хэш пароля Argon2id со случайной солью в формате PHC:
+// $argon2id$v=19$m=...,t=...,p=...$соль$хэш
+func hashPassword(password string) (string, error) {
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	return hashWithSalt(password, salt), nil
+}
+
+func hashWithSalt(password string, salt []byte) string {
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argonPrefix, argon2.Version, argonMemory, argonTime, argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key))
+}
+
+// parseHash разбирает хэш в формате PHC
+func parseHash(stored string) (argonParams, []byte, []byte, error) {
+	var p argonParams
+	parts := strings.Split(strings.TrimPrefix(stored, argonPrefix), "$")
+	if len(parts) != 4 {
+		return p, nil, nil, errInvalidHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[0], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, errInvalidHash
+	}
+	if _, err := fmt.Sscanf(parts[1], "m=%d,t=%d,p=%d", &p.memory, &p.time, &p.threads); err != nil {
+		return p, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return p, nil, nil, errInvalidHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, errInvalidHash
+	}
+	return p, salt, key, nil
+}
+
+// verifyPassword сверяет пароль с сохранённым значением. Учётные записи, созданные до перехода на хэши,
+// хранят пароль в обратимом шифровании API, их API присылает в legacy. rehash сообщает, что пароль
+// верный, но сохранённое значение нужно заменить хэшем с текущими параметрами
+func verifyPassword(stored, password, legacy string) (ok bool, rehash bool, err error) {
+	if !strings.HasPrefix(stored, argonPrefix) {
+		ok = legacy != "" && subtle.ConstantTimeCompare([]byte(stored), []byte(legacy)) == 1
+		return ok, ok, nil
+	}
+
+	params, salt, key, err := parseHash(stored)
+	if err != nil {
+		return false, false, err
+	}
+	actual := argon2.IDKey([]byte(password), salt, params.time, params.memory, params.threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false, nil
+	}
+
+	rehash = params != argonParams{time: argonTime, memory: argonMemory, threads: argonThreads} || len(key) != argonKeyLen
+	return true, rehash, nil
+}
+
+// rehashPassword заменяет сохранённое значение пароля хэшем с текущими параметрами. Замена
+// выполняется, только если пароль не успели сменить. Ошибка не мешает входу и только пишется в лог
+func (s *server) rehashPassword(ctx context.Context, userID, stored, password string) {
+	hash, err := hashPassword(password)
+	if err == nil {
+		_, err = s.db.Exec(ctx, `UPDATE users SET pass = $3 WHERE id = $1 AND pass = $2`, userID, stored, hash)
+	}
+	if err != nil {
+		log.Printf("failed to rehash password of user %s: %v", userID, err)
+	}
+}
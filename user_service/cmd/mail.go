@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mime"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
+)
+
+// Способы отправки писем
+const (
+	senderOutbox = "outbox" // письма только сохраняются в mail_outbox, например для тестов и локального запуска
+	senderSMTP   = "smtp"
+)
+
+const (
+	maxMailAttempts = 5  // после стольких неудачных попыток письмо больше не отправляется
+	mailBatchSize   = 50 // сколько писем отправляется за один проход
+)
+
+// mailMessage письмо пользователю
+type mailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// mailSender описывает способ доставки писем из mail_outbox
+type mailSender interface {
+	// Send отправляет письмо
+	Send(ctx context.Context, m mailMessage) error
+}
+
+// smtpSender отправляет письма через SMTP сервер без авторизации, например локальный MailHog
+type smtpSender struct {
+	addr string
+	from string
+}
+
+// Проверка реализации интерфейса
+var _ mailSender = &smtpSender{}
+
+func (s *smtpSender) Send(_ context.Context, m mailMessage) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.from)
+	fmt.Fprintf(&msg, "To: %s\r\n", m.To)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(m.Body, "\n", "\r\n"))
+
+	return smtp.SendMail(s.addr, nil, s.from, []string{m.To}, []byte(msg.String()))
+}
+
+// enqueueMail сохраняет письмо в mail_outbox в транзакции изменения, из-за которого оно отправляется.
+// Так письмо не теряется при сбое отправки и не уходит, если изменение откатилось
+func enqueueMail(ctx context.Context, tx pgx.Tx, m mailMessage) error {
+	_, err := tx.Exec(ctx, `
+        INSERT INTO mail_outbox (id, recipient, subject, body, created_at)
+        VALUES ($1, $2, $3, $4, $5)
+    `, uuid.New(), m.To, m.Subject, m.Body, time.Now())
+	return err
+}
+
+// sendOutbox периодически отправляет накопившиеся письма
+func (s *server) sendOutbox(ctx context.Context) {
+	ticker := time.NewTicker(mailSendInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.sendPendingMail(ctx); err != nil {
+				log.Printf("failed to send outbox mail: %v", err)
+			}
+		}
+	}
+}
+
+// sendPendingMail отправляет очередную порцию писем. Строки блокируются до конца прохода,
+// поэтому несколько экземпляров сервиса не отправят одно письмо дважды
+func (s *server) sendPendingMail(ctx context.Context) error {
+	return pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+            SELECT id, recipient, subject, body FROM mail_outbox
+            WHERE sent_at IS NULL AND attempts < $1
+            ORDER BY created_at
+            LIMIT $2
+            FOR UPDATE SKIP LOCKED
+        `, maxMailAttempts, mailBatchSize)
+		if err != nil {
+			return err
+		}
+
+		type pendingMail struct {
+			id string
+			mailMessage
+		}
+		var pending []pendingMail
+		for rows.Next() {
+			var p pendingMail
+			if err := rows.Scan(&p.id, &p.To, &p.Subject, &p.Body); err != nil {
+				rows.Close()
+				return err
+			}
+			pending = append(pending, p)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		for _, p := range pending {
+			if sendErr := s.mail.Send(ctx, p.mailMessage); sendErr != nil {
+				log.Printf("failed to send mail %s: %v", p.id, sendErr)
+				_, err := tx.Exec(ctx, `
+                    UPDATE mail_outbox SET attempts = attempts + 1, last_error = $2 WHERE id = $1
+                `, p.id, sendErr.Error())
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			_, err = tx.Exec(ctx, `
+                UPDATE mail_outbox SET attempts = attempts + 1, sent_at = $2 WHERE id = $1
+            `, p.id, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
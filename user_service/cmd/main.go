@@ -2,15 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 	"userService/userpb"
 
 	"github.com/google/uuid"
 	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -21,30 +25,107 @@ import (
 
 type server struct {
 	userpb.UnimplementedUserServiceServer
-	db *pgx.Conn
+	db   *pgxpool.Pool
+	mail mailSender // nil - письма остаются в mail_outbox
+}
+
+var (
+	resetTokenTTL    time.Duration // сколько действует ссылка сброса пароля
+	resetURL         string        // страница сброса пароля, токен добавляется параметром token
+	mailSenderName   string        // чем отправляются письма из mail_outbox: outbox (не отправлять) или smtp
+	smtpAddr         string        // адрес SMTP сервера, host:port
+	mailFrom         string        // адрес отправителя писем
+	mailSendInterval time.Duration // как часто отправляются письма из mail_outbox
+)
+
+func init() {
+	err := godotenv.Load()
+	if err != nil {
+		log.Fatal(".env file not found")
+	}
+
+	ttlMinutes, err := envInt("USER_RESET_TTL", 60)
+	if err != nil || ttlMinutes <= 0 {
+		log.Fatalf("invalid USER_RESET_TTL: %v", err)
+	}
+	resetTokenTTL = time.Duration(ttlMinutes) * time.Minute
+
+	resetURL = os.Getenv("USER_RESET_URL")
+
+	mailSenderName = os.Getenv("USER_MAIL_SENDER")
+	if mailSenderName == "" {
+		mailSenderName = senderOutbox
+	}
+	if mailSenderName != senderOutbox && mailSenderName != senderSMTP {
+		log.Fatalf("invalid USER_MAIL_SENDER: %q", mailSenderName)
+	}
+
+	smtpAddr = os.Getenv("USER_SMTP_ADDR")
+	if mailSenderName == senderSMTP && smtpAddr == "" {
+		log.Fatal("USER_SMTP_ADDR is required for smtp mail sender")
+	}
+	mailFrom = os.Getenv("USER_MAIL_FROM")
+
+	intervalSeconds, err := envInt("USER_MAIL_INTERVAL", 10)
+	if err != nil || intervalSeconds <= 0 {
+		log.Fatalf("invalid USER_MAIL_INTERVAL: %v", err)
+	}
+	mailSendInterval = time.Duration(intervalSeconds) * time.Second
+}
+
+// envInt читает целочисленную переменную окружения, подставляя значение по умолчанию, если она не задана
+func envInt(name string, def int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return def, nil
+	}
+	return strconv.Atoi(value)
 }
 
 func (s *server) AddUser(ctx context.Context, req *userpb.NewUserRequest) (*userpb.UserIDResponse, error) {
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+
 	id := uuid.New()
-	_, err := s.db.Exec(ctx, `
+	_, err = s.db.Exec(ctx, `
 		INSERT INTO users (id, username, pass) 
 		VALUES ($1, $2, $3)
-	`, id, req.Username, req.Password)
+	`, id, req.Username, hash)
 	if err != nil {
 		return nil, err
 	}
 	return &userpb.UserIDResponse{Id: id.String()}, nil
 }
 
+// CheckCredentials проверяет логин и пароль. Пароль в прежнем обратимом шифровании после
+// успешного входа заменяется хэшем
 func (s *server) CheckCredentials(ctx context.Context, req *userpb.CredentialsRequest) (*userpb.CredentialsResponse, error) {
 	var id uuid.UUID
+	var stored string
 	err := s.db.QueryRow(ctx, `
-		SELECT id FROM users 
-		WHERE username = $1 AND pass = $2
-	`, req.Username, req.Password).Scan(&id)
+		SELECT id, pass FROM users 
+		WHERE username = $1
+	`, req.Username).Scan(&id, &stored)
+	if errors.Is(err, pgx.ErrNoRows) {
+		verifyPassword(dummyHash, req.Password, "") //nolint:errcheck
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
 	if err != nil {
 		return nil, err
 	}
+
+	ok, rehash, err := verifyPassword(stored, req.Password, req.LegacyPassword)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to verify password: %v", err)
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+	if rehash {
+		s.rehashPassword(ctx, id.String(), stored, req.Password)
+	}
 	return &userpb.CredentialsResponse{Id: id.String()}, nil
 }
 
@@ -67,9 +148,14 @@ const (
 
 var acl = map[string][]string{
 	// UserService methods
-	"/user.UserService/AddUser":          {user},
-	"/user.UserService/CheckCredentials": {user},
-	"/user.UserService/UserExists":       {user},
+	"/user.UserService/AddUser":              {user},
+	"/user.UserService/CheckCredentials":     {user},
+	"/user.UserService/UserExists":           {user},
+	"/user.UserService/GetUser":              {user},
+	"/user.UserService/UpdateProfile":        {user},
+	"/user.UserService/ChangePassword":       {user},
+	"/user.UserService/RequestPasswordReset": {user},
+	"/user.UserService/ResetPassword":        {user},
 }
 
 func UnaryInterceptor(
@@ -125,11 +211,6 @@ func contains(list []string, target string) bool {
 }
 
 func main() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal(".env file not found")
-	}
-
 	dbHost := os.Getenv("POSTGRES_HOST")
 	dbPort := os.Getenv("POSTGRES_PORT")
 	dbUser := os.Getenv("POSTGRES_USER")
@@ -141,20 +222,18 @@ func main() {
 		dbUser, dbPass, dbHost, dbPort, dbName)
 
 	ctx := context.Background()
-	conn, err := pgx.Connect(ctx, connString)
+	conn, err := pgxpool.New(ctx, connString)
 	if err != nil {
 		log.Fatalf("unable to connect to database: %v\n", err)
 	}
-
-	defer func() {
-		err := conn.Close(ctx)
-		if err != nil {
-			log.Fatalf("failed to close connection: %v\n", err)
-		}
-	}()
+	defer conn.Close()
 
 	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(UnaryInterceptor))
 	server := &server{db: conn}
+	if mailSenderName == senderSMTP {
+		server.mail = &smtpSender{addr: smtpAddr, from: mailFrom}
+		go server.sendOutbox(ctx)
+	}
 	userpb.RegisterUserServiceServer(grpcServer, server)
 
 	reflection.Register(grpcServer)
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+	"userService/userpb"
+
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// resetRequestInterval - новая ссылка сброса не выдаётся чаще, чтобы запросами нельзя было засыпать почту
+const resetRequestInterval = time.Minute
+
+// hashResetToken возвращает хэш токена сброса. В базе хранится только хэш, поэтому утечка
+// таблицы не даёт сбросить чужой пароль
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// newResetToken создаёт случайный токен сброса пароля для ссылки в письме
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// resetMail собирает письмо со ссылкой сброса пароля
+func resetMail(to, username, token string) mailMessage {
+	link := resetURL + "?token=" + url.QueryEscape(token)
+	return mailMessage{
+		To:      to,
+		Subject: "Сброс пароля",
+		Body: "Здравствуйте, " + username + "!\n\n" +
+			"Чтобы задать новый пароль, перейдите по ссылке:\n" + link + "\n\n" +
+			"Ссылка действует " + strconv.Itoa(int(resetTokenTTL.Minutes())) + " мин. и только один раз. " +
+			"Если вы не запрашивали сброс, просто проигнорируйте это письмо.\n",
+	}
+}
+
+// ChangePassword меняет пароль по старому паролю. Неиспользованные ссылки сброса после смены недействительны
+func (s *server) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*userpb.Empty, error) {
+	if _, err := uuid.Parse(req.UserId); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user_id: %v", err)
+	}
+	if req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing new_password")
+	}
+
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var pass string
+		err := tx.QueryRow(ctx, `SELECT pass FROM users WHERE id = $1 FOR UPDATE`, req.UserId).Scan(&pass)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Error(codes.NotFound, "user not found")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query user: %v", err)
+		}
+		ok, _, err := verifyPassword(pass, req.OldPassword, req.LegacyOldPassword)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to verify password: %v", err)
+		}
+		if !ok {
+			return status.Error(codes.PermissionDenied, "wrong password")
+		}
+
+		hash, err := hashPassword(req.NewPassword)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to hash password: %v", err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE users SET pass = $2 WHERE id = $1`, req.UserId, hash); err != nil {
+			return status.Errorf(codes.Internal, "failed to update password: %v", err)
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1`, req.UserId); err != nil {
+			return status.Errorf(codes.Internal, "failed to revoke reset tokens: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
+// RequestPasswordReset отправляет на почту пользователя ссылку сброса пароля. Ответ не зависит от того,
+// есть ли такой пользователь и указана ли у него почта, чтобы по нему нельзя было перебирать логины
+func (s *server) RequestPasswordReset(ctx context.Context, req *userpb.UsernameRequest) (*userpb.Empty, error) {
+	now := time.Now()
+	err := pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		var userID, email string
+		err := tx.QueryRow(ctx, `SELECT id, email FROM users WHERE username = $1 FOR UPDATE`, req.Username).Scan(&userID, &email)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query user: %v", err)
+		}
+		if email == "" {
+			log.Printf("password reset skipped for user %s: no email", userID)
+			return nil
+		}
+
+		var recent bool
+		err = tx.QueryRow(ctx, `
+            SELECT EXISTS (
+                SELECT 1 FROM password_reset_tokens
+                WHERE user_id = $1 AND used_at IS NULL AND created_at > $2
+            )
+        `, userID, now.Add(-resetRequestInterval)).Scan(&recent)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to query reset tokens: %v", err)
+		}
+		if recent {
+			return nil
+		}
+
+		token, err := newResetToken()
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to generate reset token: %v", err)
+		}
+
+		// Действует только последняя выданная ссылка
+		if _, err := tx.Exec(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1`, userID); err != nil {
+			return status.Errorf(codes.Internal, "failed to revoke reset tokens: %v", err)
+		}
+		_, err = tx.Exec(ctx, `
+            INSERT INTO password_reset_tokens (token_hash, user_id, created_at, expires_at)
+            VALUES ($1, $2, $3, $4)
+        `, hashResetToken(token), userID, now, now.Add(resetTokenTTL))
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to save reset token: %v", err)
+		}
+
+		if err := enqueueMail(ctx, tx, resetMail(email, req.Username, token)); err != nil {
+			return status.Errorf(codes.Internal, "failed to enqueue mail: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.Empty{}, nil
+}
+
+// ResetPassword задаёт новый пароль по токену из письма. Токен одноразовый, после сброса
+// остальные ссылки пользователя тоже перестают действовать
+func (s *server) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.UserIDResponse, error) {
+	if req.Token == "" || req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing token or new_password")
+	}
+	// Хэш считается до транзакции, чтобы не держать блокировку токена на время вычисления
+	hash, err := hashPassword(req.NewPassword)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to hash password: %v", err)
+	}
+
+	var userID string
+	err = pgx.BeginFunc(ctx, s.db, func(tx pgx.Tx) error {
+		now := time.Now()
+		err := tx.QueryRow(ctx, `
+            UPDATE password_reset_tokens SET used_at = $2
+            WHERE token_hash = $1 AND used_at IS NULL AND expires_at > $2
+            RETURNING user_id
+        `, hashResetToken(req.Token), now).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return status.Error(codes.NotFound, "reset token not found or expired")
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to use reset token: %v", err)
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE users SET pass = $2 WHERE id = $1`, userID, hash); err != nil {
+			return status.Errorf(codes.Internal, "failed to update password: %v", err)
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM password_reset_tokens WHERE user_id = $1 AND used_at IS NULL`, userID)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to revoke reset tokens: %v", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userpb.UserIDResponse{Id: userID}, nil
+}
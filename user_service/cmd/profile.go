@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"userService/userpb"
+
+	"github.com/google/uuid"
+	pgx "github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Ограничения длины полей профиля в символах
+const (
+	maxDisplayName = 50
+	maxBio         = 1000
+	maxCity        = 100
+	maxAvatarURL   = 500
+	maxEmail       = 254
+)
+
+// sqlStateUniqueViolation - код ошибки PostgreSQL при нарушении уникальности
+const sqlStateUniqueViolation = "23505"
+
+const profileColumns = `id, username, display_name, avatar_url, bio, city, registered_at, email`
+
+// scanProfile читает профиль из строки с колонками profileColumns
+func scanProfile(row pgx.Row) (*userpb.UserProfile, error) {
+	var p userpb.UserProfile
+	var registeredAt time.Time
+	err := row.Scan(&p.Id, &p.Username, &p.DisplayName, &p.AvatarUrl, &p.Bio, &p.City, &registeredAt, &p.Email)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	// Уникальна только почта, других ограничений изменение профиля не нарушает
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == sqlStateUniqueViolation {
+		return nil, status.Error(codes.InvalidArgument, "email already in use")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query user: %v", err)
+	}
+	p.RegisteredAt = timestamppb.New(registeredAt)
+	return &p, nil
+}
+
+// GetUser возвращает профиль пользователя. Почту показывать другим пользователям или нет, решает API
+func (s *server) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.UserProfile, error) {
+	if _, err := uuid.Parse(req.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+	return scanProfile(s.db.QueryRow(ctx, `SELECT `+profileColumns+` FROM users WHERE id = $1`, req.Id))
+}
+
+// trimProfileField обрезает пробелы по краям поля и проверяет его длину
+func trimProfileField(value *string, name string, maxLen int) error {
+	if value == nil {
+		return nil
+	}
+	*value = strings.TrimSpace(*value)
+	if utf8.RuneCountInString(*value) > maxLen {
+		return status.Errorf(codes.InvalidArgument, "%s is too long", name)
+	}
+	return nil
+}
+
+// UpdateProfile изменяет заданные поля профиля и возвращает профиль после изменения
+func (s *server) UpdateProfile(ctx context.Context, req *userpb.UpdateProfileRequest) (*userpb.UserProfile, error) {
+	if _, err := uuid.Parse(req.Id); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid id: %v", err)
+	}
+	for _, field := range []struct {
+		value  *string
+		name   string
+		maxLen int
+	}{
+		{req.DisplayName, "display_name", maxDisplayName},
+		{req.AvatarUrl, "avatar_url", maxAvatarURL},
+		{req.Bio, "bio", maxBio},
+		{req.City, "city", maxCity},
+		{req.Email, "email", maxEmail},
+	} {
+		if err := trimProfileField(field.value, field.name, field.maxLen); err != nil {
+			return nil, err
+		}
+	}
+	// Аватар загружается через API, чужие адреса не принимаются, чтобы профиль не ссылался на сторонние сайты
+	if req.AvatarUrl != nil && *req.AvatarUrl != "" && !strings.HasPrefix(*req.AvatarUrl, "/uploads/") {
+		return nil, status.Error(codes.InvalidArgument, "avatar_url must point to an uploaded image")
+	}
+	// Почта нужна для писем сброса пароля, поэтому принимается только адрес без имени и комментариев
+	if req.Email != nil && *req.Email != "" {
+		addr, err := mail.ParseAddress(*req.Email)
+		if err != nil || addr.Address != *req.Email {
+			return nil, status.Error(codes.InvalidArgument, "invalid email")
+		}
+	}
+
+	return scanProfile(s.db.QueryRow(ctx, `
+        UPDATE users SET
+            display_name = COALESCE($2, display_name),
+            avatar_url = COALESCE($3, avatar_url),
+            bio = COALESCE($4, bio),
+            city = COALESCE($5, city),
+            email = COALESCE($6, email)
+        WHERE id = $1
+        RETURNING `+profileColumns,
+		req.Id, req.DisplayName, req.AvatarUrl, req.Bio, req.City, req.Email))
+}